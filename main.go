@@ -11,6 +11,7 @@ import (
 	"github.com/go-errors/errors"
 	"github.com/integrii/flaggy"
 	"github.com/jesseduffield/lazygit/pkg/app"
+	"github.com/jesseduffield/lazygit/pkg/cheatsheet"
 	"github.com/jesseduffield/lazygit/pkg/config"
 	"github.com/jesseduffield/lazygit/pkg/env"
 	yaml "github.com/jesseduffield/yaml"
@@ -48,12 +49,24 @@ func main() {
 	configFlag := false
 	flaggy.Bool(&configFlag, "c", "config", "Print the default config")
 
+	configSchemaFlag := false
+	flaggy.Bool(&configSchemaFlag, "", "config-schema", "Print a JSON schema for the config, for editors to check and autocomplete it with")
+
 	configDirFlag := false
 	flaggy.Bool(&configDirFlag, "cd", "print-config-dir", "Print the config directory")
 
 	useConfigDir := ""
 	flaggy.String(&useConfigDir, "ucd", "use-config-dir", "override default config directory with provided directory")
 
+	cheatsheetFlag := false
+	flaggy.Bool(&cheatsheetFlag, "", "cheatsheet", "Print a cheatsheet of your keybindings (see --lang and --format)")
+
+	cheatsheetLang := ""
+	flaggy.String(&cheatsheetLang, "", "lang", "Language of the cheatsheet, e.g. en. Defaults to your language")
+
+	cheatsheetFormat := "md"
+	flaggy.String(&cheatsheetFormat, "", "format", "Format of the cheatsheet: md or json")
+
 	workTree := ""
 	flaggy.String(&workTree, "w", "work-tree", "equivalent of the --work-tree git argument")
 
@@ -99,6 +112,15 @@ func main() {
 		os.Exit(0)
 	}
 
+	if configSchemaFlag {
+		schema, err := config.JSONSchema()
+		if err != nil {
+			log.Fatal(err.Error())
+		}
+		fmt.Printf("%s\n", schema)
+		os.Exit(0)
+	}
+
 	if configDirFlag {
 		fmt.Printf("%s\n", config.ConfigDir())
 		os.Exit(0)
@@ -120,6 +142,15 @@ func main() {
 		log.Fatal(err.Error())
 	}
 
+	if cheatsheetFlag {
+		content, err := cheatsheet.Generate(appConfig, cheatsheetLang, cheatsheetFormat)
+		if err != nil {
+			log.Fatal(err.Error())
+		}
+		fmt.Print(content)
+		os.Exit(0)
+	}
+
 	app, err := app.NewApp(appConfig, filterPath)
 
 	if err == nil {
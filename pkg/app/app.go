@@ -112,6 +112,12 @@ func NewApp(config config.AppConfigurer, filterPath string) (*App, error) {
 		return app, nil
 	}
 
+	// we only refuse the config over values we can't use, like keys we can't
+	// bind. The gui tells the user about any other problems once it's up
+	if err := config.CheckUserConfig(gui.UserConfigValidators()...); err != nil {
+		return app, err
+	}
+
 	app.OSCommand = oscommands.NewOSCommand(app.Log, config)
 
 	app.Updater, err = updates.NewUpdater(app.Log, config, app.OSCommand, app.Tr)
@@ -287,6 +293,10 @@ func (app *App) Close() error {
 func (app *App) KnownError(err error) (string, bool) {
 	errorMessage := err.Error()
 
+	if _, ok := err.(config.ConfigErrors); ok {
+		return fmt.Sprintf("%s\n\n%s", app.Tr.InvalidConfig, errorMessage), true
+	}
+
 	knownErrorMessages := []string{app.Tr.MinGitVersionError}
 
 	for _, message := range knownErrorMessages {
@@ -0,0 +1,117 @@
+// Package cheatsheet lists lazygit's keybindings, for the docs and for users
+// wanting a reference for their own config.
+package cheatsheet
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"sort"
+	"strings"
+
+	"github.com/jesseduffield/lazygit/pkg/config"
+	"github.com/jesseduffield/lazygit/pkg/gui"
+	"github.com/jesseduffield/lazygit/pkg/i18n"
+	"github.com/sirupsen/logrus"
+)
+
+// Generate returns a cheatsheet of the keybindings in the given config, in the
+// given language and format, which is either "md" or "json". If language is
+// blank we use the user's language
+func Generate(appConfig config.AppConfigurer, language string, format string) (string, error) {
+	if err := appConfig.CheckUserConfig(gui.UserConfigValidators()...); err != nil {
+		return "", err
+	}
+
+	logger := logrus.New()
+	logger.Out = ioutil.Discard
+	log := logrus.NewEntry(logger)
+
+	var tr *i18n.TranslationSet
+	if language == "" {
+		tr = i18n.NewTranslationSet(log)
+	} else {
+		if _, ok := i18n.GetTranslationSets()[language]; !ok {
+			return "", fmt.Errorf("unknown language '%s', expected one of: %s", language, strings.Join(Languages(), ", "))
+		}
+		tr = i18n.NewTranslationSetForLanguage(language)
+	}
+
+	// we only need the gui for its keybindings, so we don't give it a repo
+	g, err := gui.NewGui(log, nil, nil, tr, appConfig, nil, "", false)
+	if err != nil {
+		return "", err
+	}
+	sections := g.GetBindingSections()
+
+	switch format {
+	case "md":
+		return formatMarkdown(tr, sections), nil
+	case "json":
+		return formatJSON(sections)
+	default:
+		return "", fmt.Errorf("unknown format '%s', expected one of: md, json", format)
+	}
+}
+
+// Languages are the languages we can generate a cheatsheet in
+func Languages() []string {
+	languages := []string{}
+	for language := range i18n.GetTranslationSets() {
+		languages = append(languages, language)
+	}
+	sort.Strings(languages)
+
+	return languages
+}
+
+func formatMarkdown(tr *i18n.TranslationSet, sections []*gui.BindingSection) string {
+	content := fmt.Sprintf("# Lazygit %s\n", tr.Keybindings)
+
+	for _, section := range sections {
+		content += fmt.Sprintf("\n## %s\n\n", section.Title)
+		content += "<pre>\n"
+		for _, binding := range section.Bindings {
+			if binding.Alternative != "" {
+				content += fmt.Sprintf("  <kbd>%s</kbd>: %s (%s)\n", gui.GetKeyDisplay(binding.Key), binding.Description, binding.Alternative)
+			} else {
+				content += fmt.Sprintf("  <kbd>%s</kbd>: %s\n", gui.GetKeyDisplay(binding.Key), binding.Description)
+			}
+		}
+		content += "</pre>\n"
+	}
+
+	return content
+}
+
+type jsonSection struct {
+	Title    string        `json:"title"`
+	Bindings []jsonBinding `json:"bindings"`
+}
+
+type jsonBinding struct {
+	Key         string `json:"key"`
+	Description string `json:"description"`
+	Alternative string `json:"alternative,omitempty"`
+}
+
+func formatJSON(sections []*gui.BindingSection) (string, error) {
+	jsonSections := make([]jsonSection, len(sections))
+	for i, section := range sections {
+		jsonSections[i] = jsonSection{Title: section.Title, Bindings: make([]jsonBinding, len(section.Bindings))}
+		for j, binding := range section.Bindings {
+			jsonSections[i].Bindings[j] = jsonBinding{
+				Key:         gui.GetKeyDisplay(binding.Key),
+				Description: binding.Description,
+				Alternative: binding.Alternative,
+			}
+		}
+	}
+
+	output, err := json.MarshalIndent(jsonSections, "", "  ")
+	if err != nil {
+		return "", err
+	}
+
+	return string(output) + "\n", nil
+}
@@ -0,0 +1,139 @@
+package commands
+
+import (
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/jesseduffield/lazygit/pkg/commands/models"
+)
+
+// GetBisectInfo reads the state of any in-progress bisect from the .git directory
+func (c *GitCommand) GetBisectInfo() *models.BisectInfo {
+	info := models.NewBisectInfo()
+
+	startContent, err := ioutil.ReadFile(filepath.Join(c.DotGitDir, "BISECT_START"))
+	if err != nil {
+		// no BISECT_START file means we're not bisecting
+		return info
+	}
+	info.Started = true
+	info.StartRef = strings.TrimSpace(string(startContent))
+
+	termsContent, err := ioutil.ReadFile(filepath.Join(c.DotGitDir, "BISECT_TERMS"))
+	if err == nil {
+		terms := strings.Split(strings.TrimSpace(string(termsContent)), "\n")
+		if len(terms) == 2 {
+			info.NewTerm = terms[0]
+			info.OldTerm = terms[1]
+		}
+	}
+
+	logContent, err := ioutil.ReadFile(filepath.Join(c.DotGitDir, "BISECT_LOG"))
+	if err == nil {
+		info.Statuses, info.FirstNewSha = parseBisectLog(string(logContent), info.NewTerm, info.OldTerm)
+	}
+
+	currentSha, err := c.RunCommandWithOutput("git rev-parse HEAD")
+	if err == nil {
+		info.CurrentSha = strings.TrimSpace(currentSha)
+	}
+
+	if info.Bisecting() {
+		oldShas := info.Shas(models.BisectStatusOld)
+		sort.Strings(oldShas)
+		output, err := c.OSCommand.RunCommandWithOutput(
+			"git rev-list --bisect-vars refs/bisect/%s --not %s", info.NewTerm, strings.Join(oldShas, " "),
+		)
+		if err == nil {
+			info.Candidates, info.Steps = parseBisectVars(output)
+		}
+	}
+
+	return info
+}
+
+// parseBisectLog obtains the commits we've marked from the comments git leaves in
+// BISECT_LOG, which contain the full sha of each commit regardless of how we
+// referred to it when marking it, e.g.
+// # bad: [755254c90116b70810e9e41f4a60d0cb83da2179] eighth commit
+// git bisect bad 755254c90116b70810e9e41f4a60d0cb83da2179
+// # first bad commit: [83f9db6a6c0df3cdc56ca13d6c20f3f92b16d4a5] fifth commit
+func parseBisectLog(content string, newTerm string, oldTerm string) (map[string]models.BisectStatus, string) {
+	statuses := map[string]models.BisectStatus{}
+	firstNewSha := ""
+
+	re := regexp.MustCompile(`^# (.+): \[([0-9a-f]+)\]`)
+	for _, line := range strings.Split(content, "\n") {
+		match := re.FindStringSubmatch(line)
+		if match == nil {
+			continue
+		}
+		label, sha := match[1], match[2]
+
+		switch label {
+		case newTerm:
+			statuses[sha] = models.BisectStatusNew
+		case oldTerm:
+			statuses[sha] = models.BisectStatusOld
+		case "skip":
+			statuses[sha] = models.BisectStatusSkipped
+		case fmt.Sprintf("first %s commit", newTerm):
+			firstNewSha = sha
+		}
+	}
+
+	return statuses, firstNewSha
+}
+
+// parseBisectVars picks the number of remaining candidates and steps out of the
+// output of `git rev-list --bisect-vars`
+func parseBisectVars(output string) (int, int) {
+	candidates := 0
+	steps := 0
+	for _, line := range strings.Split(output, "\n") {
+		split := strings.SplitN(strings.TrimSpace(line), "=", 2)
+		if len(split) != 2 {
+			continue
+		}
+		value, err := strconv.Atoi(split[1])
+		if err != nil {
+			continue
+		}
+		switch split[0] {
+		case "bisect_all":
+			candidates = value
+		case "bisect_steps":
+			steps = value
+		}
+	}
+
+	return candidates, steps
+}
+
+func (c *GitCommand) BisectStart() error {
+	return c.RunCommand("git bisect start")
+}
+
+// BisectMark marks a commit with the given term, e.g. 'bad', 'good', or 'skip'
+func (c *GitCommand) BisectMark(ref string, term string) error {
+	return c.RunCommand("git bisect %s %s", term, ref)
+}
+
+func (c *GitCommand) BisectSkip(ref string) error {
+	return c.BisectMark(ref, "skip")
+}
+
+func (c *GitCommand) BisectReset() error {
+	return c.RunCommand("git bisect reset")
+}
+
+// BisectRunCmdStr returns the command for letting git mark commits according
+// to the exit code of the given script
+func (c *GitCommand) BisectRunCmdStr(script string) string {
+	return fmt.Sprintf("git bisect run %s", script)
+}
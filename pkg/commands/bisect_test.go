@@ -0,0 +1,97 @@
+package commands
+
+import (
+	"testing"
+
+	"github.com/jesseduffield/lazygit/pkg/commands/models"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestParseBisectLog is a function.
+func TestParseBisectLog(t *testing.T) {
+	type scenario struct {
+		testName            string
+		content             string
+		newTerm             string
+		oldTerm             string
+		expectedStatuses    map[string]models.BisectStatus
+		expectedFirstNewSha string
+	}
+
+	scenarios := []scenario{
+		{
+			"no commits marked",
+			`git bisect start
+# status: waiting for both good and bad commits
+`,
+			"bad",
+			"good",
+			map[string]models.BisectStatus{},
+			"",
+		},
+		{
+			"good, bad and skipped commits",
+			`git bisect start
+# status: waiting for both good and bad commits
+# bad: [755254c90116b70810e9e41f4a60d0cb83da2179] eighth commit
+git bisect bad 755254c90116b70810e9e41f4a60d0cb83da2179
+# status: waiting for good commit(s), bad commit known
+# good: [024eac8e0e882c3a2824b21a8904f561a35fdc3b] first commit
+git bisect good 024eac8e0e882c3a2824b21a8904f561a35fdc3b
+# skip: [406d987aa3becb7f01106be355b21545687e86d5] fourth commit
+git bisect skip 406d987aa3becb7f01106be355b21545687e86d5
+`,
+			"bad",
+			"good",
+			map[string]models.BisectStatus{
+				"755254c90116b70810e9e41f4a60d0cb83da2179": models.BisectStatusNew,
+				"024eac8e0e882c3a2824b21a8904f561a35fdc3b": models.BisectStatusOld,
+				"406d987aa3becb7f01106be355b21545687e86d5": models.BisectStatusSkipped,
+			},
+			"",
+		},
+		{
+			"custom terms with culprit found",
+			`git bisect start '--term-new=broken' '--term-old=fixed'
+# broken: [755254c90116b70810e9e41f4a60d0cb83da2179] eighth commit
+git bisect broken 755254c90116b70810e9e41f4a60d0cb83da2179
+# fixed: [024eac8e0e882c3a2824b21a8904f561a35fdc3b] first commit
+git bisect fixed 024eac8e0e882c3a2824b21a8904f561a35fdc3b
+# broken: [83f9db6a6c0df3cdc56ca13d6c20f3f92b16d4a5] fifth commit
+git bisect broken 83f9db6a6c0df3cdc56ca13d6c20f3f92b16d4a5
+# first broken commit: [83f9db6a6c0df3cdc56ca13d6c20f3f92b16d4a5] fifth commit
+`,
+			"broken",
+			"fixed",
+			map[string]models.BisectStatus{
+				"755254c90116b70810e9e41f4a60d0cb83da2179": models.BisectStatusNew,
+				"024eac8e0e882c3a2824b21a8904f561a35fdc3b": models.BisectStatusOld,
+				"83f9db6a6c0df3cdc56ca13d6c20f3f92b16d4a5": models.BisectStatusNew,
+			},
+			"83f9db6a6c0df3cdc56ca13d6c20f3f92b16d4a5",
+		},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.testName, func(t *testing.T) {
+			statuses, firstNewSha := parseBisectLog(s.content, s.newTerm, s.oldTerm)
+			assert.EqualValues(t, s.expectedStatuses, statuses)
+			assert.EqualValues(t, s.expectedFirstNewSha, firstNewSha)
+		})
+	}
+}
+
+// TestParseBisectVars is a function.
+func TestParseBisectVars(t *testing.T) {
+	output := `bisect_rev='406d987aa3becb7f01106be355b21545687e86d5'
+bisect_nr=3
+bisect_good=3
+bisect_bad=2
+bisect_all=7
+bisect_steps=2
+`
+
+	candidates, steps := parseBisectVars(output)
+	assert.EqualValues(t, 7, candidates)
+	assert.EqualValues(t, 2, steps)
+}
@@ -0,0 +1,105 @@
+package commands
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/jesseduffield/lazygit/pkg/commands/models"
+)
+
+// GetBlame returns each line of the file as of the given ref along with the
+// commit that last changed it. An empty ref means the working tree
+func (c *GitCommand) GetBlame(fileName string, ref string) ([]*models.BlameLine, error) {
+	refArg := ""
+	if ref != "" {
+		refArg = " " + c.OSCommand.Quote(ref)
+	}
+
+	output, err := c.OSCommand.RunCommandWithOutput(fmt.Sprintf("git blame --porcelain%s -- %s", refArg, c.OSCommand.Quote(fileName)))
+	if err != nil {
+		return nil, err
+	}
+
+	return parseBlamePorcelain(output), nil
+}
+
+// parseBlamePorcelain parses the output of `git blame --porcelain`. Each line
+// of the file is preceded by a header giving the commit it came from, and the
+// first time a commit shows up the header is followed by the commit's details.
+// The lines of the file themselves are prefixed with a tab e.g.
+//
+//	944b16720f5ecdff43d993f0b76d74cc2c71d026 1 1 2
+//	author Jesse Duffield
+//	author-time 1613338425
+//	summary initial commit
+//	filename file.txt
+//		first line
+//	944b16720f5ecdff43d993f0b76d74cc2c71d026 2 2
+//		second line
+func parseBlamePorcelain(output string) []*models.BlameLine {
+	lines := []*models.BlameLine{}
+	commits := map[string]*models.BlameLine{}
+	var commit *models.BlameLine
+	lineNumber := 0
+	originalLineNumber := 0
+
+	for _, line := range strings.Split(output, "\n") {
+		if strings.HasPrefix(line, "\t") {
+			if commit != nil {
+				blameLine := *commit
+				blameLine.LineNumber = lineNumber
+				blameLine.OriginalLineNumber = originalLineNumber
+				blameLine.Content = line[1:]
+				lines = append(lines, &blameLine)
+				commit = nil
+			}
+			continue
+		}
+
+		split := strings.SplitN(line, " ", 2)
+		key := split[0]
+		value := ""
+		if len(split) > 1 {
+			value = split[1]
+		}
+
+		if commit == nil {
+			// we're expecting a header of the form '<sha> <orig line> <final line> [<lines in group>]'
+			fields := strings.Fields(value)
+			if len(fields) < 2 {
+				continue
+			}
+			var err error
+			if originalLineNumber, err = strconv.Atoi(fields[0]); err != nil {
+				continue
+			}
+			if lineNumber, err = strconv.Atoi(fields[1]); err != nil {
+				continue
+			}
+
+			if _, ok := commits[key]; !ok {
+				commits[key] = &models.BlameLine{Sha: key}
+			}
+			commit = commits[key]
+			continue
+		}
+
+		switch key {
+		case "author":
+			commit.Author = value
+		case "author-time":
+			commit.UnixTimestamp, _ = strconv.ParseInt(value, 10, 64)
+		case "summary":
+			commit.Summary = value
+		case "previous":
+			previous := strings.SplitN(value, " ", 2)
+			if len(previous) == 2 {
+				commit.PreviousSha = previous[0]
+				commit.PreviousFilename = previous[1]
+			}
+		}
+	}
+
+	return lines
+}
@@ -0,0 +1,122 @@
+package commands
+
+import (
+	"os/exec"
+	"testing"
+
+	"github.com/jesseduffield/lazygit/pkg/commands/models"
+	"github.com/jesseduffield/lazygit/pkg/secureexec"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestParseBlamePorcelain is a function.
+func TestParseBlamePorcelain(t *testing.T) {
+	output := `2c0160163a19ad7093964067d8c2cc1bf9c9e83e 1 1 1
+author Jesse Duffield
+author-mail <jesse@example.com>
+author-time 1613338425
+author-tz +0000
+summary initial commit
+boundary
+filename file.txt
+	first line
+88a5a5ac45eefe8dcc3885dc9ebd4c3d5d0e036c 3 2 2
+author Jesse Duffield
+author-mail <jesse@example.com>
+author-time 1613338500
+author-tz +0000
+summary second commit
+previous 2c0160163a19ad7093964067d8c2cc1bf9c9e83e old.txt
+filename file.txt
+	second line
+88a5a5ac45eefe8dcc3885dc9ebd4c3d5d0e036c 4 3
+	
+0000000000000000000000000000000000000000 4 4 1
+author Not Committed Yet
+author-time 1613338600
+summary Version of file.txt from file.txt
+previous 88a5a5ac45eefe8dcc3885dc9ebd4c3d5d0e036c file.txt
+filename file.txt
+		indented line
+`
+
+	initialCommit := models.BlameLine{
+		Sha:           "2c0160163a19ad7093964067d8c2cc1bf9c9e83e",
+		Author:        "Jesse Duffield",
+		UnixTimestamp: 1613338425,
+		Summary:       "initial commit",
+	}
+	secondCommit := models.BlameLine{
+		Sha:              "88a5a5ac45eefe8dcc3885dc9ebd4c3d5d0e036c",
+		Author:           "Jesse Duffield",
+		UnixTimestamp:    1613338500,
+		Summary:          "second commit",
+		PreviousSha:      "2c0160163a19ad7093964067d8c2cc1bf9c9e83e",
+		PreviousFilename: "old.txt",
+	}
+
+	line := func(commit models.BlameLine, originalLineNumber int, lineNumber int, content string) *models.BlameLine {
+		commit.OriginalLineNumber = originalLineNumber
+		commit.LineNumber = lineNumber
+		commit.Content = content
+		return &commit
+	}
+
+	expected := []*models.BlameLine{
+		line(initialCommit, 1, 1, "first line"),
+		line(secondCommit, 3, 2, "second line"),
+		line(secondCommit, 4, 3, ""),
+		{
+			Sha:                "0000000000000000000000000000000000000000",
+			Author:             "Not Committed Yet",
+			UnixTimestamp:      1613338600,
+			Summary:            "Version of file.txt from file.txt",
+			PreviousSha:        "88a5a5ac45eefe8dcc3885dc9ebd4c3d5d0e036c",
+			PreviousFilename:   "file.txt",
+			OriginalLineNumber: 4,
+			LineNumber:         4,
+			Content:            "\tindented line",
+		},
+	}
+
+	lines := parseBlamePorcelain(output)
+	assert.EqualValues(t, expected, lines)
+	assert.True(t, lines[0].Committed())
+	assert.False(t, lines[3].Committed())
+}
+
+// TestGitCommandGetBlame is a function.
+func TestGitCommandGetBlame(t *testing.T) {
+	type scenario struct {
+		testName string
+		ref      string
+		expected []string
+	}
+
+	scenarios := []scenario{
+		{
+			"working tree",
+			"",
+			[]string{"blame", "--porcelain", "--", "file.txt"},
+		},
+		{
+			"old commit",
+			"2c016016^",
+			[]string{"blame", "--porcelain", "2c016016^", "--", "file.txt"},
+		},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.testName, func(t *testing.T) {
+			gitCmd := NewDummyGitCommand()
+			gitCmd.OSCommand.Command = func(cmd string, args ...string) *exec.Cmd {
+				assert.EqualValues(t, "git", cmd)
+				assert.EqualValues(t, s.expected, args)
+				return secureexec.Command("echo")
+			}
+
+			_, err := gitCmd.GetBlame("file.txt", s.ref)
+			assert.NoError(t, err)
+		})
+	}
+}
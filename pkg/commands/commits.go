@@ -7,6 +7,7 @@ import (
 
 	"github.com/jesseduffield/lazygit/pkg/commands/models"
 	"github.com/jesseduffield/lazygit/pkg/commands/oscommands"
+	"github.com/jesseduffield/lazygit/pkg/utils"
 )
 
 // RenameCommit renames the topmost commit with the given name
@@ -72,6 +73,41 @@ func (c *GitCommand) ShowCmdStr(sha string, filterPath string) string {
 	return fmt.Sprintf("git show --submodule --color=%s --no-renames --stat -p %s %s", c.colorArg(), sha, filterPathArg)
 }
 
+// LineRangeLogCmdStr shows how each commit changed the lines we're following.
+// `git log -L` can only find where the lines were in an older commit by
+// following them back from the ref where we chose them, so we get the whole
+// history in one go rather than walking it again for each commit we look at
+func (c *GitCommand) LineRangeLogCmdStr(refName string, lineRange string, filterPath string) string {
+	return fmt.Sprintf("git log %s --color=%s --pretty=medium --no-abbrev-commit -L %s", refName, c.colorArg(), c.OSCommand.Quote(lineRange+":"+filterPath))
+}
+
+// SplitLineRangeLog splits the output of the LineRangeLogCmdStr command into
+// each commit's part, keyed by the commit's sha
+func SplitLineRangeLog(output string) map[string]string {
+	diffs := map[string]string{}
+	sha := ""
+	lines := []string{}
+	for _, line := range strings.Split(output, "\n") {
+		// diff lines start with a space, + or -, and the commit message is
+		// indented, so only the header of a commit can start with 'commit'
+		if strings.Contains(line, "commit ") {
+			if plainLine := utils.Decolorise(line); strings.HasPrefix(plainLine, "commit ") {
+				if sha != "" {
+					diffs[sha] = strings.Join(lines, "\n")
+				}
+				sha = strings.Fields(plainLine)[1]
+				lines = []string{}
+			}
+		}
+		lines = append(lines, line)
+	}
+	if sha != "" {
+		diffs[sha] = strings.Join(lines, "\n")
+	}
+
+	return diffs
+}
+
 // Revert reverts the selected commit by sha
 func (c *GitCommand) Revert(sha string) error {
 	return c.RunCommand("git revert %s", sha)
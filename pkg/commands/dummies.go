@@ -21,5 +21,7 @@ func NewDummyGitCommandWithOSCommand(osCommand *oscommands.OSCommand) *GitComman
 		Config:            config.NewDummyAppConfig(),
 		getGitConfigValue: func(string) (string, error) { return "", nil },
 		removeFile:        func(string) error { return nil },
+
+		onSuccessfulContinue: new(func() error),
 	}
 }
@@ -208,9 +208,8 @@ func (c *GitCommand) WorktreeFileDiffCmdStr(node models.IFile, plain bool, cache
 }
 
 func (c *GitCommand) ApplyPatch(patch string, flags ...string) error {
-	filepath := filepath.Join(c.Config.GetUserConfigDir(), utils.GetCurrentRepoName(), time.Now().Format("Jan _2 15.04.05.000000000")+".patch")
-	c.Log.Infof("saving temporary patch to %s", filepath)
-	if err := c.OSCommand.CreateFileWithContent(filepath, patch); err != nil {
+	filepath, err := c.saveTemporaryPatch(patch)
+	if err != nil {
 		return err
 	}
 
@@ -222,6 +221,16 @@ func (c *GitCommand) ApplyPatch(patch string, flags ...string) error {
 	return c.RunCommand("git apply %s %s", flagStr, c.OSCommand.Quote(filepath))
 }
 
+func (c *GitCommand) saveTemporaryPatch(patch string) (string, error) {
+	filepath := filepath.Join(c.Config.GetUserConfigDir(), utils.GetCurrentRepoName(), time.Now().Format("Jan _2 15.04.05.000000000")+".patch")
+	c.Log.Infof("saving temporary patch to %s", filepath)
+	if err := c.OSCommand.CreateFileWithContent(filepath, patch); err != nil {
+		return "", err
+	}
+
+	return filepath, nil
+}
+
 // ShowFileDiff get the diff of specified from and to. Typically this will be used for a single commit so it'll be 123abc^..123abc
 // but when we're in diff mode it could be any 'from' to any 'to'. The reverse flag is also here thanks to diff mode.
 func (c *GitCommand) ShowFileDiff(from string, to string, reverse bool, fileName string, plain bool) (string, error) {
@@ -341,3 +350,45 @@ func (c *GitCommand) EditFile(filename string) (*exec.Cmd, error) {
 
 	return c.OSCommand.PrepareSubProcess(splitCmd[0], splitCmd[1:]...), nil
 }
+
+// OpenMergeToolCmd runs git mergetool on the conflicted file, using the given
+// tool if any rather than whichever merge.tool is configured. The user has
+// already asked for the tool, so we skip git's prompt before launching it
+func (c *GitCommand) OpenMergeToolCmd(tool string, fileName string) *exec.Cmd {
+	args := []string{"mergetool", "--no-prompt"}
+	if tool != "" {
+		args = append(args, "--tool="+tool)
+	}
+	args = append(args, "--", fileName)
+
+	return c.OSCommand.PrepareSubProcess("git", args...)
+}
+
+// ResolveConflictWithSide resolves the file's merge conflicts by taking the
+// whole file from one side, 'ours' or 'theirs', and staging the result. If that
+// side deleted the file (or never had it) then resolving means deleting it.
+// Note that mid-rebase 'ours' is the branch being rebased onto
+func (c *GitCommand) ResolveConflictWithSide(file *models.File, side string) error {
+	if !file.HasMergeConflicts {
+		return nil
+	}
+
+	quotedFileName := c.OSCommand.Quote(file.Name)
+
+	if file.MissingOnConflictSide(side) {
+		return c.RunCommand("git rm --quiet -- %s", quotedFileName)
+	}
+
+	if err := c.RunCommand("git checkout --%s -- %s", side, quotedFileName); err != nil {
+		return err
+	}
+
+	return c.RunCommand("git add -- %s", quotedFileName)
+}
+
+// ResolveDirConflictsWithSide resolves each conflicted file within the directory
+func (c *GitCommand) ResolveDirConflictsWithSide(node *filetree.FileNode, side string) error {
+	return node.ForEachFile(func(file *models.File) error {
+		return c.ResolveConflictWithSide(file, side)
+	})
+}
@@ -27,16 +27,19 @@ const CurrentBranchNameRegex = `(?m)^\*.*?([^ ]*?)\)?$`
 
 // GitCommand is our main git interface
 type GitCommand struct {
-	Log                  *logrus.Entry
-	OSCommand            *oscommands.OSCommand
-	Repo                 *gogit.Repository
-	Tr                   *i18n.TranslationSet
-	Config               config.AppConfigurer
-	getGitConfigValue    func(string) (string, error)
-	removeFile           func(string) error
-	DotGitDir            string
-	onSuccessfulContinue func() error
-	PatchManager         *patch.PatchManager
+	Log               *logrus.Entry
+	OSCommand         *oscommands.OSCommand
+	Repo              *gogit.Repository
+	Tr                *i18n.TranslationSet
+	Config            config.AppConfigurer
+	getGitConfigValue func(string) (string, error)
+	removeFile        func(string) error
+	DotGitDir         string
+	PatchManager      *patch.PatchManager
+
+	// this is behind a pointer so that it's shared with copies made by
+	// WithOSCommand, given a rebase begun by one copy may be continued by another
+	onSuccessfulContinue *func() error
 
 	// Push to current determines whether the user has configured to push to the remote branch of the same name as the current or not
 	PushToCurrent bool
@@ -78,13 +81,23 @@ func NewGitCommand(log *logrus.Entry, osCommand *oscommands.OSCommand, tr *i18n.
 		removeFile:        os.RemoveAll,
 		DotGitDir:         dotGitDir,
 		PushToCurrent:     pushToCurrent,
+
+		onSuccessfulContinue: new(func() error),
 	}
 
-	gitCommand.PatchManager = patch.NewPatchManager(log, gitCommand.ApplyPatch, gitCommand.ShowFileDiff)
+	gitCommand.PatchManager = patch.NewPatchManager(log, gitCommand.ShowFileDiff)
 
 	return gitCommand, nil
 }
 
+// WithOSCommand returns a copy of the GitCommand that runs its commands through
+// the given OSCommand, e.g. one with its own BeforeExecuteCmd hook
+func (c *GitCommand) WithOSCommand(osCommand *oscommands.OSCommand) *GitCommand {
+	newGitCommand := *c
+	newGitCommand.OSCommand = osCommand
+	return &newGitCommand
+}
+
 func navigateToRepoRootDirectory(stat func(string) (os.FileInfo, error), chdir func(string) error) error {
 	gitDir := env.GetGitDirEnv()
 	if gitDir != "" {
@@ -3,7 +3,16 @@ package commands
 // Conflict : A git conflict with a start middle and end corresponding to line
 // numbers in the file where the conflict bars appear
 type Conflict struct {
-	Start  int
-	Middle int
-	End    int
+	Start int
+	// Ancestor is where the '|||||||' bar starts the base version's section when
+	// merge.conflictStyle is diff3 or zdiff3. It can never be the first line, so
+	// zero means there's no base section
+	Ancestor int
+	Middle   int
+	End      int
+}
+
+// HasAncestor tells us whether the conflict shows the base version
+func (c Conflict) HasAncestor() bool {
+	return c.Ancestor > 0
 }
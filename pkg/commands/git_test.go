@@ -242,17 +242,21 @@ func TestGitCommandGetStashEntries(t *testing.T) {
 		{
 			"Several stash entries found",
 			func(string, ...string) *exec.Cmd {
-				return secureexec.Command("echo", "WIP on add-pkg-commands-test: 55c6af2 increase parallel build\nWIP on master: bb86a3f update github template")
+				return secureexec.Command("echo", "1617141427|WIP on add-pkg-commands-test: 55c6af2 increase parallel build\n1617141400|On master: my message")
 			},
 			func(entries []*models.StashEntry) {
 				expected := []*models.StashEntry{
 					{
-						Index: 0,
-						Name:  "WIP on add-pkg-commands-test: 55c6af2 increase parallel build",
+						Index:         0,
+						Name:          "WIP on add-pkg-commands-test: 55c6af2 increase parallel build",
+						Branch:        "add-pkg-commands-test",
+						UnixTimestamp: 1617141427,
 					},
 					{
-						Index: 1,
-						Name:  "WIP on master: bb86a3f update github template",
+						Index:         1,
+						Name:          "On master: my message",
+						Branch:        "master",
+						UnixTimestamp: 1617141400,
 					},
 				}
 
@@ -410,6 +414,81 @@ func TestGitCommandStashSave(t *testing.T) {
 	assert.NoError(t, gitCmd.StashSave("A stash message"))
 }
 
+// TestGitCommandStashPush is a function.
+func TestGitCommandStashPush(t *testing.T) {
+	type scenario struct {
+		testName     string
+		opts         StashPushOpts
+		expectedArgs []string
+	}
+
+	scenarios := []scenario{
+		{
+			"everything",
+			StashPushOpts{},
+			[]string{"stash", "push"},
+		},
+		{
+			"some paths with a message",
+			StashPushOpts{Message: "A stash message", Paths: []string{"dir", "file.txt"}},
+			[]string{"stash", "push", "-m", "A stash message", "--", "dir", "file.txt"},
+		},
+		{
+			"including untracked files",
+			StashPushOpts{IncludeUntracked: true},
+			[]string{"stash", "push", "--include-untracked"},
+		},
+		{
+			"keeping the index",
+			StashPushOpts{KeepIndex: true, Message: "message"},
+			[]string{"stash", "push", "--keep-index", "-m", "message"},
+		},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.testName, func(t *testing.T) {
+			gitCmd := NewDummyGitCommand()
+			gitCmd.OSCommand.Command = func(cmd string, args ...string) *exec.Cmd {
+				assert.EqualValues(t, "git", cmd)
+				assert.EqualValues(t, s.expectedArgs, args)
+
+				return secureexec.Command("echo")
+			}
+
+			assert.NoError(t, gitCmd.StashPush(s.opts))
+		})
+	}
+}
+
+// TestGitCommandStashRename is a function.
+func TestGitCommandStashRename(t *testing.T) {
+	gitCmd := NewDummyGitCommand()
+	gitCmd.OSCommand.Command = test.CreateMockCommand(t, []*test.CommandSwapper{
+		{
+			Expect:  `git stash list --pretty=%H|%gs`,
+			Replace: `printf "aaa|On master: first\\nbbb|On master: second\\nccc|On master: third"`,
+		},
+		{
+			Expect:  `git stash store -m "New name" bbb`,
+			Replace: "echo",
+		},
+		{
+			Expect:  `git stash store -m "On master: first" aaa`,
+			Replace: "echo",
+		},
+		{
+			Expect:  "git stash drop stash@{2}",
+			Replace: "echo",
+		},
+		{
+			Expect:  "git stash drop stash@{2}",
+			Replace: "echo",
+		},
+	})
+
+	assert.NoError(t, gitCmd.StashRename(1, "New name"))
+}
+
 // TestGitCommandCommitAmend is a function.
 func TestGitCommandCommitAmend(t *testing.T) {
 	gitCmd := NewDummyGitCommand()
@@ -932,6 +1011,126 @@ func TestGitCommandStageFile(t *testing.T) {
 	assert.NoError(t, gitCmd.StageFile("test.txt"))
 }
 
+// TestGitCommandOpenMergeToolCmd is a function.
+func TestGitCommandOpenMergeToolCmd(t *testing.T) {
+	type scenario struct {
+		testName     string
+		tool         string
+		expectedArgs []string
+	}
+
+	scenarios := []scenario{
+		{
+			"configured tool",
+			"vimdiff",
+			[]string{"mergetool", "--no-prompt", "--tool=vimdiff", "--", "test.txt"},
+		},
+		{
+			"git's merge.tool",
+			"",
+			[]string{"mergetool", "--no-prompt", "--", "test.txt"},
+		},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.testName, func(t *testing.T) {
+			gitCmd := NewDummyGitCommand()
+			gitCmd.OSCommand.Command = func(cmd string, args ...string) *exec.Cmd {
+				assert.EqualValues(t, "git", cmd)
+				assert.EqualValues(t, s.expectedArgs, args)
+
+				return secureexec.Command("echo")
+			}
+
+			assert.NotNil(t, gitCmd.OpenMergeToolCmd(s.tool, "test.txt"))
+		})
+	}
+}
+
+// TestGitCommandResolveConflictWithSide is a function.
+func TestGitCommandResolveConflictWithSide(t *testing.T) {
+	type scenario struct {
+		testName     string
+		shortStatus  string
+		side         string
+		expectedCmds [][]string
+	}
+
+	scenarios := []scenario{
+		{
+			"both modified, taking ours",
+			"UU",
+			"ours",
+			[][]string{{"checkout", "--ours", "--", "test.txt"}, {"add", "--", "test.txt"}},
+		},
+		{
+			"both added, taking theirs",
+			"AA",
+			"theirs",
+			[][]string{{"checkout", "--theirs", "--", "test.txt"}, {"add", "--", "test.txt"}},
+		},
+		{
+			"deleted by us, taking ours",
+			"DU",
+			"ours",
+			[][]string{{"rm", "--quiet", "--", "test.txt"}},
+		},
+		{
+			"deleted by us, taking theirs",
+			"DU",
+			"theirs",
+			[][]string{{"checkout", "--theirs", "--", "test.txt"}, {"add", "--", "test.txt"}},
+		},
+		{
+			"deleted by them, taking ours",
+			"UD",
+			"ours",
+			[][]string{{"checkout", "--ours", "--", "test.txt"}, {"add", "--", "test.txt"}},
+		},
+		{
+			"deleted by them, taking theirs",
+			"UD",
+			"theirs",
+			[][]string{{"rm", "--quiet", "--", "test.txt"}},
+		},
+		{
+			"added by us, taking theirs",
+			"AU",
+			"theirs",
+			[][]string{{"rm", "--quiet", "--", "test.txt"}},
+		},
+		{
+			"added by them, taking ours",
+			"UA",
+			"ours",
+			[][]string{{"rm", "--quiet", "--", "test.txt"}},
+		},
+		{
+			"added by them, taking theirs",
+			"UA",
+			"theirs",
+			[][]string{{"checkout", "--theirs", "--", "test.txt"}, {"add", "--", "test.txt"}},
+		},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.testName, func(t *testing.T) {
+			cmdsCalled := [][]string{}
+			gitCmd := NewDummyGitCommand()
+			gitCmd.OSCommand.Command = func(cmd string, args ...string) *exec.Cmd {
+				assert.EqualValues(t, "git", cmd)
+				cmdsCalled = append(cmdsCalled, args)
+
+				return secureexec.Command("echo")
+			}
+
+			file := &models.File{Name: "test.txt", ShortStatus: s.shortStatus, HasMergeConflicts: true}
+			assert.NoError(t, gitCmd.ResolveConflictWithSide(file, s.side))
+			assert.EqualValues(t, s.expectedCmds, cmdsCalled)
+		})
+	}
+}
+
 // TestGitCommandUnstageFile is a function.
 func TestGitCommandUnstageFile(t *testing.T) {
 	type scenario struct {
@@ -1371,6 +1570,84 @@ func TestGitCommandDiff(t *testing.T) {
 	}
 }
 
+// TestGitCommandTrackedRemoteName is a function.
+func TestGitCommandTrackedRemoteName(t *testing.T) {
+	type scenario struct {
+		testName string
+		remotes  map[string]string
+		expected string
+	}
+
+	scenarios := []scenario{
+		{"the branch tracks a remote", map[string]string{"branch.feature.remote": "upstream"}, "upstream"},
+		{"the branch tracks a local branch", map[string]string{"branch.feature.remote": "."}, "origin"},
+		{"the branch doesn't track anything", map[string]string{}, "origin"},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.testName, func(t *testing.T) {
+			gitCmd := NewDummyGitCommand()
+			gitCmd.OSCommand.Command = func(cmd string, args ...string) *exec.Cmd {
+				assert.EqualValues(t, []string{"symbolic-ref", "--short", "HEAD"}, args)
+				return secureexec.Command("echo", "feature")
+			}
+			gitCmd.getGitConfigValue = func(key string) (string, error) {
+				return s.remotes[key], nil
+			}
+
+			assert.EqualValues(t, s.expected, gitCmd.TrackedRemoteName())
+		})
+	}
+}
+
+// TestGitCommandCheckRemoteBranchExists is a function.
+func TestGitCommandCheckRemoteBranchExists(t *testing.T) {
+	type scenario struct {
+		testName   string
+		remoteName string
+		command    func(string, ...string) *exec.Cmd
+		expected   bool
+	}
+
+	scenarios := []scenario{
+		{
+			"the branch is on origin",
+			"origin",
+			func(cmd string, args ...string) *exec.Cmd {
+				assert.EqualValues(t, []string{"show-ref", "--verify", "--", "refs/remotes/origin/feature"}, args)
+				return secureexec.Command("echo")
+			},
+			true,
+		},
+		{
+			"the branch is on another remote",
+			"upstream",
+			func(cmd string, args ...string) *exec.Cmd {
+				assert.EqualValues(t, []string{"show-ref", "--verify", "--", "refs/remotes/upstream/feature"}, args)
+				return secureexec.Command("echo")
+			},
+			true,
+		},
+		{
+			"the branch isn't on the remote",
+			"upstream",
+			func(cmd string, args ...string) *exec.Cmd {
+				return secureexec.Command("exit", "1")
+			},
+			false,
+		},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.testName, func(t *testing.T) {
+			gitCmd := NewDummyGitCommand()
+			gitCmd.OSCommand.Command = s.command
+
+			assert.EqualValues(t, s.expected, gitCmd.CheckRemoteBranchExists(s.remoteName, &models.Branch{Name: "feature"}))
+		})
+	}
+}
+
 // TestGitCommandCurrentBranchName is a function.
 func TestGitCommandCurrentBranchName(t *testing.T) {
 	type scenario struct {
@@ -1564,6 +1841,107 @@ func TestGitCommandRebaseBranch(t *testing.T) {
 	}
 }
 
+// TestGitCommandRebaseBranchWithOptions is a function.
+func TestGitCommandRebaseBranchWithOptions(t *testing.T) {
+	type scenario struct {
+		testName string
+		opts     RebaseBranchOptions
+		expected string
+	}
+
+	scenarios := []scenario{
+		{
+			"plain rebase",
+			RebaseBranchOptions{},
+			"git rebase --interactive --autostash --keep-empty master",
+		},
+		{
+			"rebase onto",
+			RebaseBranchOptions{Upstream: "abc123"},
+			"git rebase --interactive --autostash --keep-empty --onto master abc123",
+		},
+		{
+			"rebase merges onto",
+			RebaseBranchOptions{Upstream: "abc123", RebaseMerges: true},
+			"git rebase --interactive --autostash --keep-empty --rebase-merges --onto master abc123",
+		},
+	}
+
+	gitCmd := NewDummyGitCommand()
+
+	for _, s := range scenarios {
+		t.Run(s.testName, func(t *testing.T) {
+			gitCmd.OSCommand.Command = test.CreateMockCommand(t, []*test.CommandSwapper{
+				{
+					Expect:  s.expected,
+					Replace: "echo",
+				},
+			})
+			assert.NoError(t, gitCmd.RebaseBranchWithOptions("master", s.opts))
+		})
+	}
+}
+
+// TestGitCommandGenerateRangeRebaseTodo is a function.
+func TestGitCommandGenerateRangeRebaseTodo(t *testing.T) {
+	type scenario struct {
+		testName   string
+		startIndex int
+		endIndex   int
+		action     string
+		test       func(string, string, error)
+	}
+
+	commits := []*models.Commit{
+		{Sha: "d", Name: "fourth"},
+		{Sha: "c", Name: "third"},
+		{Sha: "b", Name: "second"},
+		{Sha: "a", Name: "first"},
+	}
+
+	scenarios := []scenario{
+		{
+			"dropping a range",
+			0,
+			1,
+			"drop",
+			func(todo string, sha string, err error) {
+				assert.NoError(t, err)
+				assert.EqualValues(t, "b", sha)
+				assert.EqualValues(t, "drop c third\ndrop d fourth\n", todo)
+			},
+		},
+		{
+			"squashing a range into the commit below it",
+			0,
+			1,
+			"squash",
+			func(todo string, sha string, err error) {
+				assert.NoError(t, err)
+				assert.EqualValues(t, "a", sha)
+				assert.EqualValues(t, "pick b second\nsquash c third\nsquash d fourth\n", todo)
+			},
+		},
+		{
+			"fixing up a range with nothing below it to rebase onto",
+			1,
+			2,
+			"fixup",
+			func(todo string, sha string, err error) {
+				assert.Error(t, err)
+			},
+		},
+	}
+
+	gitCmd := NewDummyGitCommand()
+
+	for _, s := range scenarios {
+		t.Run(s.testName, func(t *testing.T) {
+			s.test(gitCmd.GenerateRangeRebaseTodo(commits, s.startIndex, s.endIndex, s.action))
+		})
+	}
+}
+
 // TestGitCommandCheckoutFile is a function.
 func TestGitCommandCheckoutFile(t *testing.T) {
 	type scenario struct {
@@ -2140,3 +2518,34 @@ func TestEditFile(t *testing.T) {
 		s.test(gitCmd.EditFile(s.filename))
 	}
 }
+
+// TestSplitLineRangeLog is a function.
+func TestSplitLineRangeLog(t *testing.T) {
+	type scenario struct {
+		testName string
+		output   string
+		expected map[string]string
+	}
+
+	scenarios := []scenario{
+		{
+			"no commits",
+			"",
+			map[string]string{},
+		},
+		{
+			"several commits",
+			"\x1b[33mcommit 77df907fd02f1dd8a62082af09bdf9e00ea8c8c5\x1b[m\nAuthor: a <a@a>\n\n    mention commit 1f8f4057\n\n@@ -8,1 +8,1 @@\n-8\n+eight\n\n\x1b[33mcommit 1f8f405793d0591a77ae8ed24f8f36d88a6d2ac6\x1b[m\nAuthor: a <a@a>\n\n@@ -0,0 +8,1 @@\n+8",
+			map[string]string{
+				"77df907fd02f1dd8a62082af09bdf9e00ea8c8c5": "\x1b[33mcommit 77df907fd02f1dd8a62082af09bdf9e00ea8c8c5\x1b[m\nAuthor: a <a@a>\n\n    mention commit 1f8f4057\n\n@@ -8,1 +8,1 @@\n-8\n+eight\n",
+				"1f8f405793d0591a77ae8ed24f8f36d88a6d2ac6": "\x1b[33mcommit 1f8f405793d0591a77ae8ed24f8f36d88a6d2ac6\x1b[m\nAuthor: a <a@a>\n\n@@ -0,0 +8,1 @@\n+8",
+			},
+		},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.testName, func(t *testing.T) {
+			assert.EqualValues(t, s.expected, SplitLineRangeLog(s.output))
+		})
+	}
+}
@@ -0,0 +1,167 @@
+package hosting
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/jesseduffield/lazygit/pkg/commands/models"
+)
+
+type bitbucketClient struct {
+	api *apiClient
+	// Bitbucket calls this the workspace
+	owner      string
+	repository string
+}
+
+type bitbucketRepo struct {
+	FullName string `json:"full_name"`
+	Links    struct {
+		HTML struct {
+			Href string `json:"href"`
+		} `json:"html"`
+	} `json:"links"`
+}
+
+type bitbucketPullRequest struct {
+	ID          int    `json:"id"`
+	Title       string `json:"title"`
+	Description string `json:"description"`
+	State       string `json:"state"`
+	Draft       bool   `json:"draft"`
+	Author      struct {
+		DisplayName string `json:"display_name"`
+	} `json:"author"`
+	Links struct {
+		HTML struct {
+			Href string `json:"href"`
+		} `json:"html"`
+	} `json:"links"`
+	Source struct {
+		Branch struct {
+			Name string `json:"name"`
+		} `json:"branch"`
+		Commit struct {
+			Hash string `json:"hash"`
+		} `json:"commit"`
+		Repository *bitbucketRepo `json:"repository"`
+	} `json:"source"`
+	Destination struct {
+		Branch struct {
+			Name string `json:"name"`
+		} `json:"branch"`
+		Repository *bitbucketRepo `json:"repository"`
+	} `json:"destination"`
+}
+
+func (c *bitbucketClient) repoPath() string {
+	return fmt.Sprintf("/repositories/%s/%s", c.owner, c.repository)
+}
+
+func (c *bitbucketClient) PullRequests() ([]*models.PullRequest, error) {
+	var response struct {
+		Values []bitbucketPullRequest `json:"values"`
+	}
+	if err := c.api.get(c.repoPath()+"/pullrequests?state=OPEN&state=MERGED&state=DECLINED&sort=-updated_on&pagelen=50", &response); err != nil {
+		return nil, err
+	}
+
+	pullRequests := make([]*models.PullRequest, len(response.Values))
+	for i, pr := range response.Values {
+		pullRequests[i] = pr.toModel()
+	}
+
+	return pullRequests, nil
+}
+
+func (pr bitbucketPullRequest) toModel() *models.PullRequest {
+	state := "closed"
+	switch pr.State {
+	case "OPEN":
+		state = "open"
+	case "MERGED":
+		state = "merged"
+	}
+
+	result := &models.PullRequest{
+		Number:     pr.ID,
+		Title:      pr.Title,
+		Body:       pr.Description,
+		Author:     pr.Author.DisplayName,
+		State:      state,
+		Draft:      pr.Draft,
+		URL:        pr.Links.HTML.Href,
+		HeadBranch: pr.Source.Branch.Name,
+		HeadSha:    pr.Source.Commit.Hash,
+		BaseBranch: pr.Destination.Branch.Name,
+		// Bitbucket has no ref for a pull request's head, so we go to the branch
+		// itself, which for forks means going to the fork
+		HeadRef: "refs/heads/" + pr.Source.Branch.Name,
+	}
+
+	source, destination := pr.Source.Repository, pr.Destination.Repository
+	if source == nil || destination == nil || source.FullName != destination.FullName {
+		result.IsFork = true
+		if source != nil {
+			result.HeadRepoURL = strings.TrimSuffix(source.Links.HTML.Href, "/") + ".git"
+		}
+	}
+
+	return result
+}
+
+func (c *bitbucketClient) CIStatus(sha string) (string, error) {
+	var response struct {
+		Values []struct {
+			State string `json:"state"`
+		} `json:"values"`
+	}
+	if err := c.api.get(fmt.Sprintf("%s/commit/%s/statuses", c.repoPath(), sha), &response); err != nil {
+		return "", err
+	}
+
+	statuses := make([]string, len(response.Values))
+	for i, status := range response.Values {
+		switch status.State {
+		case "SUCCESSFUL":
+			statuses[i] = "success"
+		case "INPROGRESS":
+			statuses[i] = "pending"
+		default:
+			statuses[i] = "failure"
+		}
+	}
+
+	return combineCIStatuses(statuses), nil
+}
+
+func (c *bitbucketClient) CreatePullRequest(opts CreatePullRequestOpts) (*models.PullRequest, error) {
+	if len(opts.Labels) > 0 {
+		return nil, fmt.Errorf("Bitbucket pull requests don't have labels")
+	}
+
+	reviewers := make([]map[string]string, len(opts.Reviewers))
+	for i, uuid := range opts.Reviewers {
+		reviewers[i] = map[string]string{"uuid": uuid}
+	}
+
+	body := map[string]interface{}{
+		"title":       opts.Title,
+		"description": opts.Body,
+		"draft":       opts.Draft,
+		"source": map[string]interface{}{
+			"branch": map[string]string{"name": opts.HeadBranch},
+		},
+		"destination": map[string]interface{}{
+			"branch": map[string]string{"name": opts.BaseBranch},
+		},
+		"reviewers": reviewers,
+	}
+
+	var response bitbucketPullRequest
+	if err := c.api.post(c.repoPath()+"/pullrequests", body, &response); err != nil {
+		return nil, err
+	}
+
+	return response.toModel(), nil
+}
@@ -0,0 +1,165 @@
+package hosting
+
+import (
+	"fmt"
+
+	"github.com/jesseduffield/lazygit/pkg/commands/models"
+)
+
+type githubClient struct {
+	api        *apiClient
+	owner      string
+	repository string
+}
+
+type githubRepo struct {
+	FullName string `json:"full_name"`
+}
+
+type githubPullRequest struct {
+	Number   int     `json:"number"`
+	Title    string  `json:"title"`
+	Body     string  `json:"body"`
+	State    string  `json:"state"`
+	Draft    bool    `json:"draft"`
+	MergedAt *string `json:"merged_at"`
+	HTMLURL  string  `json:"html_url"`
+	User     struct {
+		Login string `json:"login"`
+	} `json:"user"`
+	Head struct {
+		Ref  string      `json:"ref"`
+		Sha  string      `json:"sha"`
+		Repo *githubRepo `json:"repo"`
+	} `json:"head"`
+	Base struct {
+		Ref  string      `json:"ref"`
+		Repo *githubRepo `json:"repo"`
+	} `json:"base"`
+}
+
+func (c *githubClient) repoPath() string {
+	return fmt.Sprintf("/repos/%s/%s", c.owner, c.repository)
+}
+
+func (c *githubClient) PullRequests() ([]*models.PullRequest, error) {
+	var response []githubPullRequest
+	if err := c.api.get(c.repoPath()+"/pulls?state=all&sort=updated&direction=desc&per_page=100", &response); err != nil {
+		return nil, err
+	}
+
+	pullRequests := make([]*models.PullRequest, len(response))
+	for i, pr := range response {
+		pullRequests[i] = pr.toModel()
+	}
+
+	return pullRequests, nil
+}
+
+func (pr githubPullRequest) toModel() *models.PullRequest {
+	state := pr.State
+	if pr.MergedAt != nil {
+		state = "merged"
+	}
+
+	// the head repo is null when the fork has since been deleted
+	isFork := pr.Head.Repo == nil || pr.Base.Repo == nil || pr.Head.Repo.FullName != pr.Base.Repo.FullName
+
+	return &models.PullRequest{
+		Number:     pr.Number,
+		Title:      pr.Title,
+		Body:       pr.Body,
+		Author:     pr.User.Login,
+		State:      state,
+		Draft:      pr.Draft,
+		URL:        pr.HTMLURL,
+		HeadBranch: pr.Head.Ref,
+		HeadSha:    pr.Head.Sha,
+		BaseBranch: pr.Base.Ref,
+		HeadRef:    fmt.Sprintf("refs/pull/%d/head", pr.Number),
+		IsFork:     isFork,
+	}
+}
+
+// CIStatus considers both commit statuses and check runs, given that GitHub
+// Actions only reports the latter
+func (c *githubClient) CIStatus(sha string) (string, error) {
+	var combined struct {
+		State      string `json:"state"`
+		TotalCount int    `json:"total_count"`
+	}
+	if err := c.api.get(fmt.Sprintf("%s/commits/%s/status", c.repoPath(), sha), &combined); err != nil {
+		return "", err
+	}
+
+	var checks struct {
+		CheckRuns []struct {
+			Status     string `json:"status"`
+			Conclusion string `json:"conclusion"`
+		} `json:"check_runs"`
+	}
+	if err := c.api.get(fmt.Sprintf("%s/commits/%s/check-runs", c.repoPath(), sha), &checks); err != nil {
+		return "", err
+	}
+
+	statuses := []string{}
+	// the combined state is 'pending' when there are no statuses at all
+	if combined.TotalCount > 0 {
+		switch combined.State {
+		case "success":
+			statuses = append(statuses, "success")
+		case "pending":
+			statuses = append(statuses, "pending")
+		default:
+			statuses = append(statuses, "failure")
+		}
+	}
+
+	for _, run := range checks.CheckRuns {
+		if run.Status != "completed" {
+			statuses = append(statuses, "pending")
+			continue
+		}
+
+		switch run.Conclusion {
+		case "success", "neutral", "skipped":
+			statuses = append(statuses, "success")
+		default:
+			statuses = append(statuses, "failure")
+		}
+	}
+
+	return combineCIStatuses(statuses), nil
+}
+
+func (c *githubClient) CreatePullRequest(opts CreatePullRequestOpts) (*models.PullRequest, error) {
+	body := map[string]interface{}{
+		"title": opts.Title,
+		"body":  opts.Body,
+		"head":  opts.HeadBranch,
+		"base":  opts.BaseBranch,
+		"draft": opts.Draft,
+	}
+	var response githubPullRequest
+	if err := c.api.post(c.repoPath()+"/pulls", body, &response); err != nil {
+		return nil, err
+	}
+	pr := response.toModel()
+
+	if len(opts.Reviewers) > 0 {
+		path := fmt.Sprintf("%s/pulls/%d/requested_reviewers", c.repoPath(), pr.Number)
+		if err := c.api.post(path, map[string]interface{}{"reviewers": opts.Reviewers}, nil); err != nil {
+			return pr, err
+		}
+	}
+
+	// labels belong to the issue that every pull request is underneath
+	if len(opts.Labels) > 0 {
+		path := fmt.Sprintf("%s/issues/%d/labels", c.repoPath(), pr.Number)
+		if err := c.api.post(path, map[string]interface{}{"labels": opts.Labels}, nil); err != nil {
+			return pr, err
+		}
+	}
+
+	return pr, nil
+}
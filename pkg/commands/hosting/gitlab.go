@@ -0,0 +1,146 @@
+package hosting
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/jesseduffield/lazygit/pkg/commands/models"
+)
+
+type gitlabClient struct {
+	api *apiClient
+	// e.g. 'group/subgroup/repo'
+	project string
+}
+
+type gitlabMergeRequest struct {
+	IID             int    `json:"iid"`
+	Title           string `json:"title"`
+	Description     string `json:"description"`
+	State           string `json:"state"`
+	Draft           bool   `json:"draft"`
+	WorkInProgress  bool   `json:"work_in_progress"`
+	WebURL          string `json:"web_url"`
+	SourceBranch    string `json:"source_branch"`
+	TargetBranch    string `json:"target_branch"`
+	Sha             string `json:"sha"`
+	SourceProjectID int    `json:"source_project_id"`
+	TargetProjectID int    `json:"target_project_id"`
+	Author          struct {
+		Username string `json:"username"`
+	} `json:"author"`
+}
+
+// projectPath is the project's path url-encoded, slashes and all, which is how
+// GitLab lets you refer to a project without knowing its id
+func (c *gitlabClient) projectPath() string {
+	return "/projects/" + strings.Replace(url.PathEscape(c.project), "/", "%2F", -1)
+}
+
+func (c *gitlabClient) PullRequests() ([]*models.PullRequest, error) {
+	var response []gitlabMergeRequest
+	if err := c.api.get(c.projectPath()+"/merge_requests?state=all&order_by=updated_at&sort=desc&per_page=100", &response); err != nil {
+		return nil, err
+	}
+
+	pullRequests := make([]*models.PullRequest, len(response))
+	for i, mr := range response {
+		pullRequests[i] = mr.toModel()
+	}
+
+	return pullRequests, nil
+}
+
+func (mr gitlabMergeRequest) toModel() *models.PullRequest {
+	state := mr.State
+	switch state {
+	case "opened":
+		state = "open"
+	case "locked":
+		state = "closed"
+	}
+
+	return &models.PullRequest{
+		Number:     mr.IID,
+		Title:      mr.Title,
+		Body:       mr.Description,
+		Author:     mr.Author.Username,
+		State:      state,
+		Draft:      mr.Draft || mr.WorkInProgress,
+		URL:        mr.WebURL,
+		HeadBranch: mr.SourceBranch,
+		HeadSha:    mr.Sha,
+		BaseBranch: mr.TargetBranch,
+		HeadRef:    fmt.Sprintf("refs/merge-requests/%d/head", mr.IID),
+		IsFork:     mr.SourceProjectID != mr.TargetProjectID,
+	}
+}
+
+// CIStatus goes by the most recent pipeline run against the commit
+func (c *gitlabClient) CIStatus(sha string) (string, error) {
+	var pipelines []struct {
+		Status string `json:"status"`
+	}
+	if err := c.api.get(fmt.Sprintf("%s/pipelines?sha=%s&per_page=1", c.projectPath(), sha), &pipelines); err != nil {
+		return "", err
+	}
+
+	if len(pipelines) == 0 {
+		return "", nil
+	}
+
+	switch pipelines[0].Status {
+	case "success", "skipped":
+		return "success", nil
+	case "failed", "canceled":
+		return "failure", nil
+	case "manual":
+		return "", nil
+	default:
+		return "pending", nil
+	}
+}
+
+func (c *gitlabClient) CreatePullRequest(opts CreatePullRequestOpts) (*models.PullRequest, error) {
+	// GitLab wants reviewers by id, so we look those up first rather than ending
+	// up with a merge request that's missing them
+	reviewerIDs := make([]int, len(opts.Reviewers))
+	for i, username := range opts.Reviewers {
+		var users []struct {
+			ID int `json:"id"`
+		}
+		if err := c.api.get("/users?username="+url.QueryEscape(username), &users); err != nil {
+			return nil, err
+		}
+		if len(users) == 0 {
+			return nil, fmt.Errorf("no GitLab user named '%s'", username)
+		}
+		reviewerIDs[i] = users[0].ID
+	}
+
+	title := opts.Title
+	if opts.Draft {
+		title = "Draft: " + title
+	}
+
+	body := map[string]interface{}{
+		"title":         title,
+		"description":   opts.Body,
+		"source_branch": opts.HeadBranch,
+		"target_branch": opts.BaseBranch,
+	}
+	if len(reviewerIDs) > 0 {
+		body["reviewer_ids"] = reviewerIDs
+	}
+	if len(opts.Labels) > 0 {
+		body["labels"] = strings.Join(opts.Labels, ",")
+	}
+
+	var response gitlabMergeRequest
+	if err := c.api.post(c.projectPath()+"/merge_requests", body, &response); err != nil {
+		return nil, err
+	}
+
+	return response.toModel(), nil
+}
@@ -0,0 +1,189 @@
+// Package hosting talks to the REST APIs of the services repos are hosted on,
+// so that we can show things like the pull request of each branch
+package hosting
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/jesseduffield/lazygit/pkg/commands/models"
+)
+
+// Client is the API of a hosting service, scoped to one repo
+type Client interface {
+	// PullRequests returns the repo's most recently updated pull requests,
+	// whatever their state
+	PullRequests() ([]*models.PullRequest, error)
+
+	// CIStatus returns the combined status of the checks run against the commit.
+	// See models.PullRequest for the possible values
+	CIStatus(sha string) (string, error)
+
+	// CreatePullRequest opens a pull request and then requests the reviewers and
+	// adds the labels, where those take separate calls. If one of those later
+	// calls fails we still return the pull request, so that the caller can tell
+	// it was created
+	CreatePullRequest(opts CreatePullRequestOpts) (*models.PullRequest, error)
+}
+
+type CreatePullRequestOpts struct {
+	Title      string
+	Body       string
+	HeadBranch string
+	BaseBranch string
+	Draft      bool
+	// usernames, except on Bitbucket where they must be account UUIDs
+	Reviewers []string
+	Labels    []string
+}
+
+type ClientOpts struct {
+	// one of "github", "gitlab" or "bitbucket"
+	ServiceType string
+	// e.g. 'https://api.github.com'
+	APIURL string
+	Token  string
+	// only needed when authenticating with Bitbucket app passwords
+	Username   string
+	Owner      string
+	Repository string
+}
+
+// NewClient returns a client for the given service's API
+func NewClient(opts ClientOpts) (Client, error) {
+	api := &apiClient{
+		baseURL:    strings.TrimSuffix(opts.APIURL, "/"),
+		httpClient: &http.Client{Timeout: 15 * time.Second},
+	}
+
+	switch opts.ServiceType {
+	case "github":
+		api.authenticate = func(req *http.Request) {
+			req.Header.Set("Authorization", "token "+opts.Token)
+			req.Header.Set("Accept", "application/vnd.github.v3+json")
+		}
+		return &githubClient{api: api, owner: opts.Owner, repository: opts.Repository}, nil
+	case "gitlab":
+		api.authenticate = func(req *http.Request) {
+			req.Header.Set("PRIVATE-TOKEN", opts.Token)
+		}
+		return &gitlabClient{api: api, project: opts.Owner + "/" + opts.Repository}, nil
+	case "bitbucket":
+		api.authenticate = func(req *http.Request) {
+			if opts.Username != "" {
+				req.SetBasicAuth(opts.Username, opts.Token)
+			} else {
+				req.Header.Set("Authorization", "Bearer "+opts.Token)
+			}
+		}
+		return &bitbucketClient{api: api, owner: opts.Owner, repository: opts.Repository}, nil
+	}
+
+	return nil, fmt.Errorf("no API support for service type '%s'", opts.ServiceType)
+}
+
+type apiClient struct {
+	baseURL      string
+	httpClient   *http.Client
+	authenticate func(*http.Request)
+}
+
+func (c *apiClient) get(path string, result interface{}) error {
+	return c.request("GET", path, nil, result)
+}
+
+func (c *apiClient) post(path string, body interface{}, result interface{}) error {
+	return c.request("POST", path, body, result)
+}
+
+// request sends the body, if any, as JSON and decodes the JSON response into
+// result
+func (c *apiClient) request(method string, path string, body interface{}, result interface{}) error {
+	var reader io.Reader
+	if body != nil {
+		encoded, err := json.Marshal(body)
+		if err != nil {
+			return err
+		}
+		reader = bytes.NewReader(encoded)
+	}
+
+	req, err := http.NewRequest(method, c.baseURL+path, reader)
+	if err != nil {
+		return err
+	}
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	c.authenticate(req)
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	content, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("%s %s: %s%s", method, req.URL.Path, resp.Status, errorMessage(content))
+	}
+
+	if result == nil {
+		return nil
+	}
+
+	return json.Unmarshal(content, result)
+}
+
+// errorMessage digs the message out of an error response. GitHub and GitLab put
+// it under 'message' while Bitbucket puts it under 'error.message'
+func errorMessage(content []byte) string {
+	var response struct {
+		Message interface{} `json:"message"`
+		Error   struct {
+			Message string `json:"message"`
+		} `json:"error"`
+	}
+	if err := json.Unmarshal(content, &response); err != nil {
+		return ""
+	}
+
+	if response.Message != nil {
+		return fmt.Sprintf(" (%v)", response.Message)
+	}
+	if response.Error.Message != "" {
+		return fmt.Sprintf(" (%s)", response.Error.Message)
+	}
+
+	return ""
+}
+
+// combineCIStatuses boils the statuses of a commit's checks down to one: any
+// failure fails the lot, otherwise anything still running makes it pending
+func combineCIStatuses(statuses []string) string {
+	result := ""
+	for _, status := range statuses {
+		switch status {
+		case "failure":
+			return "failure"
+		case "pending":
+			result = "pending"
+		case "success":
+			if result == "" {
+				result = "success"
+			}
+		}
+	}
+
+	return result
+}
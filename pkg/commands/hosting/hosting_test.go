@@ -0,0 +1,285 @@
+package hosting
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/jesseduffield/lazygit/pkg/commands/models"
+	"github.com/stretchr/testify/assert"
+)
+
+// newMockServer serves the given JSON responses by request URI, failing the test
+// on any request that isn't authenticated as expected
+func newMockServer(t *testing.T, checkAuth func(*http.Request) bool, responses map[string]string) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !checkAuth(r) {
+			t.Errorf("unauthenticated request to %s", r.RequestURI)
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+
+		response, ok := responses[r.RequestURI]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			fmt.Fprint(w, `{"message": "Not Found"}`)
+			return
+		}
+
+		fmt.Fprint(w, response)
+	}))
+}
+
+// TestClients is a function.
+func TestClients(t *testing.T) {
+	type scenario struct {
+		testName     string
+		serviceType  string
+		username     string
+		checkAuth    func(*http.Request) bool
+		responses    map[string]string
+		pullRequests []*models.PullRequest
+		ciStatus     string
+	}
+
+	scenarios := []scenario{
+		{
+			testName:    "github",
+			serviceType: "github",
+			checkAuth: func(r *http.Request) bool {
+				return r.Header.Get("Authorization") == "token secret"
+			},
+			responses: map[string]string{
+				"/repos/owner/repo/pulls?state=all&sort=updated&direction=desc&per_page=100": `[
+					{"number": 2, "title": "Fork", "state": "open", "draft": true, "merged_at": null, "html_url": "https://github.com/owner/repo/pull/2",
+					 "user": {"login": "jane"}, "head": {"ref": "master", "sha": "bbb", "repo": {"full_name": "jane/repo"}}, "base": {"ref": "master", "repo": {"full_name": "owner/repo"}}},
+					{"number": 1, "title": "Feature", "state": "closed", "merged_at": "2021-01-01T00:00:00Z", "html_url": "https://github.com/owner/repo/pull/1",
+					 "user": {"login": "john"}, "head": {"ref": "feature", "sha": "aaa", "repo": {"full_name": "owner/repo"}}, "base": {"ref": "master", "repo": {"full_name": "owner/repo"}}}
+				]`,
+				"/repos/owner/repo/commits/bbb/status":     `{"state": "pending", "total_count": 0}`,
+				"/repos/owner/repo/commits/bbb/check-runs": `{"check_runs": [{"status": "completed", "conclusion": "success"}, {"status": "completed", "conclusion": "failure"}]}`,
+			},
+			pullRequests: []*models.PullRequest{
+				{Number: 2, Title: "Fork", Author: "jane", State: "open", Draft: true, URL: "https://github.com/owner/repo/pull/2", HeadBranch: "master", HeadSha: "bbb", BaseBranch: "master", HeadRef: "refs/pull/2/head", IsFork: true},
+				{Number: 1, Title: "Feature", Author: "john", State: "merged", URL: "https://github.com/owner/repo/pull/1", HeadBranch: "feature", HeadSha: "aaa", BaseBranch: "master", HeadRef: "refs/pull/1/head"},
+			},
+			ciStatus: "failure",
+		},
+		{
+			testName:    "gitlab",
+			serviceType: "gitlab",
+			checkAuth: func(r *http.Request) bool {
+				return r.Header.Get("PRIVATE-TOKEN") == "secret"
+			},
+			responses: map[string]string{
+				"/projects/owner%2Frepo/merge_requests?state=all&order_by=updated_at&sort=desc&per_page=100": `[
+					{"iid": 7, "title": "Feature", "description": "Does things", "state": "opened", "work_in_progress": true, "web_url": "https://gitlab.com/owner/repo/-/merge_requests/7",
+					 "source_branch": "feature", "target_branch": "main", "sha": "bbb", "source_project_id": 1, "target_project_id": 1, "author": {"username": "john"}}
+				]`,
+				"/projects/owner%2Frepo/pipelines?sha=bbb&per_page=1": `[{"status": "running"}]`,
+			},
+			pullRequests: []*models.PullRequest{
+				{Number: 7, Title: "Feature", Body: "Does things", Author: "john", State: "open", Draft: true, URL: "https://gitlab.com/owner/repo/-/merge_requests/7", HeadBranch: "feature", HeadSha: "bbb", BaseBranch: "main", HeadRef: "refs/merge-requests/7/head"},
+			},
+			ciStatus: "pending",
+		},
+		{
+			testName:    "bitbucket",
+			serviceType: "bitbucket",
+			username:    "john",
+			checkAuth: func(r *http.Request) bool {
+				username, password, ok := r.BasicAuth()
+				return ok && username == "john" && password == "secret"
+			},
+			responses: map[string]string{
+				"/repositories/owner/repo/pullrequests?state=OPEN&state=MERGED&state=DECLINED&sort=-updated_on&pagelen=50": `{"values": [
+					{"id": 3, "title": "Fork", "state": "DECLINED", "author": {"display_name": "Jane"}, "links": {"html": {"href": "https://bitbucket.org/owner/repo/pull-requests/3"}},
+					 "source": {"branch": {"name": "fix"}, "commit": {"hash": "bbb"}, "repository": {"full_name": "jane/repo", "links": {"html": {"href": "https://bitbucket.org/jane/repo"}}}},
+					 "destination": {"branch": {"name": "master"}, "repository": {"full_name": "owner/repo"}}}
+				]}`,
+				"/repositories/owner/repo/commit/bbb/statuses": `{"values": [{"state": "SUCCESSFUL"}]}`,
+			},
+			pullRequests: []*models.PullRequest{
+				{Number: 3, Title: "Fork", Author: "Jane", State: "closed", URL: "https://bitbucket.org/owner/repo/pull-requests/3", HeadBranch: "fix", HeadSha: "bbb", BaseBranch: "master", HeadRef: "refs/heads/fix", HeadRepoURL: "https://bitbucket.org/jane/repo.git", IsFork: true},
+			},
+			ciStatus: "success",
+		},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.testName, func(t *testing.T) {
+			server := newMockServer(t, s.checkAuth, s.responses)
+			defer server.Close()
+
+			client, err := NewClient(ClientOpts{
+				ServiceType: s.serviceType,
+				APIURL:      server.URL + "/",
+				Token:       "secret",
+				Username:    s.username,
+				Owner:       "owner",
+				Repository:  "repo",
+			})
+			assert.NoError(t, err)
+
+			pullRequests, err := client.PullRequests()
+			assert.NoError(t, err)
+			assert.EqualValues(t, s.pullRequests, pullRequests)
+
+			ciStatus, err := client.CIStatus("bbb")
+			assert.NoError(t, err)
+			assert.EqualValues(t, s.ciStatus, ciStatus)
+
+			_, err = client.CIStatus("unknown")
+			assert.Error(t, err)
+		})
+	}
+}
+
+// TestCreatePullRequest is a function.
+func TestCreatePullRequest(t *testing.T) {
+	type scenario struct {
+		testName    string
+		serviceType string
+		opts        CreatePullRequestOpts
+		// responses are keyed by method and request URI
+		responses map[string]string
+		// expectedBodies are the JSON bodies we expect to be posted, by request URI
+		expectedBodies map[string]string
+		expectedNumber int
+		expectedError  string
+	}
+
+	opts := CreatePullRequestOpts{
+		Title:      "Feature",
+		Body:       "Does things",
+		HeadBranch: "feature",
+		BaseBranch: "master",
+		Draft:      true,
+		Reviewers:  []string{"jane"},
+		Labels:     []string{"bug", "ui"},
+	}
+
+	scenarios := []scenario{
+		{
+			testName:    "github",
+			serviceType: "github",
+			opts:        opts,
+			responses: map[string]string{
+				"POST /repos/owner/repo/pulls":                       `{"number": 5, "state": "open", "head": {"ref": "feature"}, "base": {"ref": "master"}}`,
+				"POST /repos/owner/repo/pulls/5/requested_reviewers": `{}`,
+				"POST /repos/owner/repo/issues/5/labels":             `[]`,
+			},
+			expectedBodies: map[string]string{
+				"/repos/owner/repo/pulls":                       `{"base": "master", "body": "Does things", "draft": true, "head": "feature", "title": "Feature"}`,
+				"/repos/owner/repo/pulls/5/requested_reviewers": `{"reviewers": ["jane"]}`,
+				"/repos/owner/repo/issues/5/labels":             `{"labels": ["bug", "ui"]}`,
+			},
+			expectedNumber: 5,
+		},
+		{
+			testName:    "github with reviewer that can't be requested",
+			serviceType: "github",
+			opts:        opts,
+			responses: map[string]string{
+				"POST /repos/owner/repo/pulls": `{"number": 5, "state": "open", "head": {"ref": "feature"}, "base": {"ref": "master"}}`,
+			},
+			expectedNumber: 5,
+			expectedError:  "POST /repos/owner/repo/pulls/5/requested_reviewers: 404 Not Found (Not Found)",
+		},
+		{
+			testName:    "gitlab",
+			serviceType: "gitlab",
+			opts:        opts,
+			responses: map[string]string{
+				"GET /users?username=jane":                   `[{"id": 42}]`,
+				"POST /projects/owner%2Frepo/merge_requests": `{"iid": 6, "state": "opened"}`,
+			},
+			expectedBodies: map[string]string{
+				"/projects/owner%2Frepo/merge_requests": `{"description": "Does things", "labels": "bug,ui", "reviewer_ids": [42], "source_branch": "feature", "target_branch": "master", "title": "Draft: Feature"}`,
+			},
+			expectedNumber: 6,
+		},
+		{
+			testName:    "gitlab with unknown reviewer",
+			serviceType: "gitlab",
+			opts:        opts,
+			responses: map[string]string{
+				"GET /users?username=jane": `[]`,
+			},
+			expectedError: "no GitLab user named 'jane'",
+		},
+		{
+			testName:    "bitbucket",
+			serviceType: "bitbucket",
+			opts:        CreatePullRequestOpts{Title: "Feature", HeadBranch: "feature", BaseBranch: "master", Reviewers: []string{"{abc}"}},
+			responses: map[string]string{
+				"POST /repositories/owner/repo/pullrequests": `{"id": 7, "state": "OPEN"}`,
+			},
+			expectedBodies: map[string]string{
+				"/repositories/owner/repo/pullrequests": `{"description": "", "destination": {"branch": {"name": "master"}}, "draft": false, "reviewers": [{"uuid": "{abc}"}], "source": {"branch": {"name": "feature"}}, "title": "Feature"}`,
+			},
+			expectedNumber: 7,
+		},
+		{
+			testName:      "bitbucket with labels",
+			serviceType:   "bitbucket",
+			opts:          opts,
+			expectedError: "Bitbucket pull requests don't have labels",
+		},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.testName, func(t *testing.T) {
+			bodies := map[string]string{}
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.Method == "POST" {
+					body, _ := ioutil.ReadAll(r.Body)
+					bodies[r.RequestURI] = string(body)
+				}
+
+				response, ok := s.responses[r.Method+" "+r.RequestURI]
+				if !ok {
+					w.WriteHeader(http.StatusNotFound)
+					fmt.Fprint(w, `{"message": "Not Found"}`)
+					return
+				}
+
+				fmt.Fprint(w, response)
+			}))
+			defer server.Close()
+
+			client, err := NewClient(ClientOpts{
+				ServiceType: s.serviceType,
+				APIURL:      server.URL,
+				Token:       "secret",
+				Owner:       "owner",
+				Repository:  "repo",
+			})
+			assert.NoError(t, err)
+
+			pr, err := client.CreatePullRequest(s.opts)
+			if s.expectedError != "" {
+				assert.EqualError(t, err, s.expectedError)
+			} else {
+				assert.NoError(t, err)
+			}
+
+			if s.expectedNumber == 0 {
+				assert.Nil(t, pr)
+			} else {
+				assert.EqualValues(t, s.expectedNumber, pr.Number)
+				assert.True(t, pr.IsOpen())
+			}
+
+			for uri, expectedBody := range s.expectedBodies {
+				var expected, actual interface{}
+				assert.NoError(t, json.Unmarshal([]byte(expectedBody), &expected))
+				assert.NoError(t, json.Unmarshal([]byte(bodies[uri]), &actual), uri)
+				assert.EqualValues(t, expected, actual, uri)
+			}
+		})
+	}
+}
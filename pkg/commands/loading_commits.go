@@ -49,7 +49,7 @@ func NewCommitListBuilder(log *logrus.Entry, gitCommand *GitCommand, osCommand *
 // extractCommitFromLine takes a line from a git log and extracts the sha, message, date, and tag if present
 // then puts them into a commit object
 // example input:
-// 8ad01fe32fcc20f07bc6693f87aa4977c327f1e1|10 hours ago|Jesse Duffield| (HEAD -> master, tag: v0.15.2)|refresh commits when adding a tag
+// 8ad01fe32fcc20f07bc6693f87aa4977c327f1e1|10 hours ago|Jesse Duffield| (HEAD -> master, tag: v0.15.2)|0f4d3c2b1a0f9e8d7c6b5a4f3e2d1c0b9a8f7e6d|refresh commits when adding a tag
 func (c *CommitListBuilder) extractCommitFromLine(line string) *models.Commit {
 	split := strings.Split(line, SEPARATION_CHAR)
 
@@ -73,8 +73,8 @@ func (c *CommitListBuilder) extractCommitFromLine(line string) *models.Commit {
 	unitTimestampInt, _ := strconv.Atoi(unixTimestamp)
 
 	// Any commit with multiple parents is a merge commit.
-	// If there's a space then it means there must be more than one parent hash
-	isMerge := strings.Contains(parentHashes, " ")
+	parents := strings.Fields(parentHashes)
+	isMerge := len(parents) > 1
 
 	return &models.Commit{
 		Sha:           sha,
@@ -84,12 +84,14 @@ func (c *CommitListBuilder) extractCommitFromLine(line string) *models.Commit {
 		UnixTimestamp: int64(unitTimestampInt),
 		Author:        author,
 		IsMerge:       isMerge,
+		Parents:       parents,
 	}
 }
 
 type GetCommitsOptions struct {
 	Limit                bool
 	FilterPath           string
+	FilterLineRange      string // e.g. "10,20" to only follow those lines of FilterPath
 	IncludeRebaseCommits bool
 	RefName              string // e.g. "HEAD" or "my_branch"
 }
@@ -167,6 +169,18 @@ func (c *CommitListBuilder) GetCommits(opts GetCommitsOptions) ([]*models.Commit
 		return nil, err
 	}
 
+	if opts.FilterPath != "" {
+		// git log --follow doesn't rewrite parents to skip the commits that didn't
+		// touch the path, so as far as we're concerned each commit's parent is
+		// simply the next one down
+		for i, commit := range commits {
+			commit.Parents = nil
+			if i+1 < len(commits) {
+				commit.Parents = []string{commits[i+1].Sha}
+			}
+		}
+	}
+
 	if rebaseMode != "" {
 		currentCommit := commits[len(rebasingCommits)]
 		blue := color.New(color.FgYellow)
@@ -246,7 +260,7 @@ func (c *CommitListBuilder) getNormalRebasingCommits() ([]*models.Commit, error)
 
 // getInteractiveRebasingCommits takes our git-rebase-todo and our git-rebase-todo.backup files
 // and extracts out the sha and names of commits that we still have to go
-// in the rebase:
+// in the rebase, along with any other lines like 'exec' and 'break':
 func (c *CommitListBuilder) getInteractiveRebasingCommits() ([]*models.Commit, error) {
 	bytesContent, err := ioutil.ReadFile(filepath.Join(c.GitCommand.DotGitDir, "rebase-merge/git-rebase-todo"))
 	if err != nil {
@@ -255,25 +269,7 @@ func (c *CommitListBuilder) getInteractiveRebasingCommits() ([]*models.Commit, e
 		return nil, nil
 	}
 
-	commits := []*models.Commit{}
-	lines := strings.Split(string(bytesContent), "\n")
-	for _, line := range lines {
-		if line == "" || line == "noop" {
-			return commits, nil
-		}
-		if strings.HasPrefix(line, "#") {
-			continue
-		}
-		splitLine := strings.Split(line, " ")
-		commits = append([]*models.Commit{{
-			Sha:    splitLine[1],
-			Name:   strings.Join(splitLine[2:], " "),
-			Status: "rebasing",
-			Action: splitLine[0],
-		}}, commits...)
-	}
-
-	return commits, nil
+	return ParseRebaseTodo(string(bytesContent)), nil
 }
 
 // assuming the file starts like this:
@@ -360,14 +356,26 @@ func (c *CommitListBuilder) getLogCmd(opts GetCommitsOptions) *exec.Cmd {
 	}
 
 	filterFlag := ""
-	if opts.FilterPath != "" {
+	if opts.FilterLineRange != "" {
+		// -L gives us the diff of each commit by default, which we don't want here
+		filterFlag = fmt.Sprintf(" -s -L %s", c.OSCommand.Quote(opts.FilterLineRange+":"+opts.FilterPath))
+	} else if opts.FilterPath != "" {
 		filterFlag = fmt.Sprintf(" --follow -- %s", c.OSCommand.Quote(opts.FilterPath))
 	}
 
+	// --topo-order ensures we never see a parent before all of its children,
+	// which we rely on when drawing the commit graph. It's slower than the
+	// default order on big repos so we only ask for it when we need it
+	topoOrderFlag := ""
+	if c.GitCommand.Config.GetUserConfig().Gui.ShowCommitGraph {
+		topoOrderFlag = " --topo-order"
+	}
+
 	return c.OSCommand.ExecutableFromString(
 		fmt.Sprintf(
-			"git log %s --oneline --pretty=format:\"%%H%s%%at%s%%aN%s%%d%s%%p%s%%s\" %s --abbrev=%d --date=unix %s",
+			"git log %s%s --oneline --pretty=format:\"%%H%s%%at%s%%aN%s%%d%s%%P%s%%s\" %s --abbrev=%d --date=unix %s",
 			opts.RefName,
+			topoOrderFlag,
 			SEPARATION_CHAR,
 			SEPARATION_CHAR,
 			SEPARATION_CHAR,
@@ -112,3 +112,104 @@ func TestCommitListBuilderGetMergeBase(t *testing.T) {
 		})
 	}
 }
+
+// TestCommitListBuilderExtractCommitFromLine is a function.
+func TestCommitListBuilderExtractCommitFromLine(t *testing.T) {
+	type scenario struct {
+		testName        string
+		line            string
+		expectedParents []string
+		expectedIsMerge bool
+	}
+
+	scenarios := []scenario{
+		{
+			"root commit",
+			"8ad01fe32fcc20f07bc6693f87aa4977c327f1e1|1613338425|Jesse Duffield| (HEAD -> master)||initial commit",
+			[]string{},
+			false,
+		},
+		{
+			"regular commit",
+			"8ad01fe32fcc20f07bc6693f87aa4977c327f1e1|1613338425|Jesse Duffield||0f4d3c2b1a0f9e8d7c6b5a4f3e2d1c0b9a8f7e6d|fix bug",
+			[]string{"0f4d3c2b1a0f9e8d7c6b5a4f3e2d1c0b9a8f7e6d"},
+			false,
+		},
+		{
+			"merge commit",
+			"8ad01fe32fcc20f07bc6693f87aa4977c327f1e1|1613338425|Jesse Duffield||0f4d3c2b1a0f9e8d7c6b5a4f3e2d1c0b9a8f7e6d 944b16720f5ecdff43d993f0b76d74cc2c71d026|Merge branch 'feature'",
+			[]string{"0f4d3c2b1a0f9e8d7c6b5a4f3e2d1c0b9a8f7e6d", "944b16720f5ecdff43d993f0b76d74cc2c71d026"},
+			true,
+		},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.testName, func(t *testing.T) {
+			c := NewDummyCommitListBuilder()
+			commit := c.extractCommitFromLine(s.line)
+			assert.EqualValues(t, s.expectedParents, commit.Parents)
+			assert.EqualValues(t, s.expectedIsMerge, commit.IsMerge)
+		})
+	}
+}
+
+// TestCommitListBuilderGetLogCmdFilterArgs is a function.
+func TestCommitListBuilderGetLogCmdFilterArgs(t *testing.T) {
+	type scenario struct {
+		testName     string
+		opts         GetCommitsOptions
+		expectedTail []string
+	}
+
+	scenarios := []scenario{
+		{
+			"filtering by path",
+			GetCommitsOptions{RefName: "HEAD", FilterPath: "pkg/my file.go"},
+			[]string{"--follow", "--", "pkg/my file.go"},
+		},
+		{
+			"following a range of lines",
+			GetCommitsOptions{RefName: "HEAD", FilterPath: "pkg/my file.go", FilterLineRange: "10,20"},
+			[]string{"-s", "-L", "10,20:pkg/my file.go"},
+		},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.testName, func(t *testing.T) {
+			c := NewDummyCommitListBuilder()
+			args := c.getLogCmd(s.opts).Args
+			assert.EqualValues(t, s.expectedTail, args[len(args)-len(s.expectedTail):])
+		})
+	}
+}
+
+// TestCommitListBuilderGetLogCmdTopoOrder is a function.
+func TestCommitListBuilderGetLogCmdTopoOrder(t *testing.T) {
+	type scenario struct {
+		testName        string
+		showCommitGraph bool
+		expected        bool
+	}
+
+	scenarios := []scenario{
+		{
+			"showing the commit graph",
+			true,
+			true,
+		},
+		{
+			"not showing the commit graph",
+			false,
+			false,
+		},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.testName, func(t *testing.T) {
+			c := NewDummyCommitListBuilder()
+			c.GitCommand.Config.GetUserConfig().Gui.ShowCommitGraph = s.showCommitGraph
+			args := c.getLogCmd(GetCommitsOptions{RefName: "HEAD"}).Args
+			assert.EqualValues(t, s.expected, utils.IncludesString(args, "--topo-order"))
+		})
+	}
+}
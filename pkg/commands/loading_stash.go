@@ -10,7 +10,7 @@ import (
 )
 
 func (c *GitCommand) getUnfilteredStashEntries() []*models.StashEntry {
-	unescaped := "git stash list --pretty='%gs'"
+	unescaped := "git stash list --pretty='%ct|%gs'"
 	rawString, _ := c.OSCommand.RunCommandWithOutput(unescaped)
 	stashEntries := []*models.StashEntry{}
 	for i, line := range utils.SplitLines(rawString) {
@@ -25,7 +25,8 @@ func (c *GitCommand) GetStashEntries(filterPath string) []*models.StashEntry {
 		return c.getUnfilteredStashEntries()
 	}
 
-	rawString, err := c.RunCommandWithOutput("git stash list --name-only")
+	unescaped := "git stash list --name-only --pretty='%gd|%ct|%gs'"
+	rawString, err := c.OSCommand.RunCommandWithOutput(unescaped)
 	if err != nil {
 		return c.getUnfilteredStashEntries()
 	}
@@ -33,7 +34,7 @@ func (c *GitCommand) GetStashEntries(filterPath string) []*models.StashEntry {
 	var currentStashEntry *models.StashEntry
 	lines := utils.SplitLines(rawString)
 	isAStash := func(line string) bool { return strings.HasPrefix(line, "stash@{") }
-	re := regexp.MustCompile(`stash@\{(\d+)\}`)
+	re := regexp.MustCompile(`^stash@\{(\d+)\}\|(.*)`)
 
 outer:
 	for i := 0; i < len(lines); i++ {
@@ -45,7 +46,7 @@ outer:
 		if err != nil {
 			return c.getUnfilteredStashEntries()
 		}
-		currentStashEntry = stashEntryFromLine(lines[i], idx)
+		currentStashEntry = stashEntryFromLine(match[2], idx)
 		for i+1 < len(lines) && !isAStash(lines[i+1]) {
 			i++
 			if lines[i] == filterPath {
@@ -57,9 +58,32 @@ outer:
 	return stashEntries
 }
 
+// stashMessageRegex picks out the branch from the messages git gives stash
+// entries, e.g. 'WIP on master: 55c6af2 increase parallel build', or 'On
+// master: my message' when the user gave one
+var stashMessageRegex = regexp.MustCompile(`^(?:WIP on|On) ([^:]+): `)
+
+// stashEntryFromLine takes a line like '1617141427|On master: my message'
 func stashEntryFromLine(line string, index int) *models.StashEntry {
-	return &models.StashEntry{
+	entry := &models.StashEntry{
 		Name:  line,
 		Index: index,
 	}
+
+	split := strings.SplitN(line, "|", 2)
+	if len(split) != 2 {
+		return entry
+	}
+	unixTimestamp, err := strconv.ParseInt(split[0], 10, 64)
+	if err != nil {
+		return entry
+	}
+	entry.UnixTimestamp = unixTimestamp
+	entry.Name = split[1]
+
+	if match := stashMessageRegex.FindStringSubmatch(entry.Name); match != nil {
+		entry.Branch = match[1]
+	}
+
+	return entry
 }
@@ -21,25 +21,28 @@ func convertToInt(s string) int {
 }
 
 func (c *GitCommand) GetTags() ([]*models.Tag, error) {
-	// get remote branches
-	remoteBranchesStr, err := c.OSCommand.RunCommandWithOutput(`git tag --list`)
+	// %(*objectname) gives us the commit an annotated tag points to, and is blank
+	// for lightweight tags, which point straight at their commit
+	tagsStr, err := c.OSCommand.RunCommandWithOutput(
+		`git for-each-ref --format="%%(refname:strip=2)%s%%(objecttype)%s%%(objectname)%s%%(*objectname)%s%%(taggername)%s%%(creatordate:unix)%s%%(if)%%(contents:signature)%%(then)signed%%(end)%s%%(contents:subject)" refs/tags`,
+		SEPARATION_CHAR, SEPARATION_CHAR, SEPARATION_CHAR, SEPARATION_CHAR, SEPARATION_CHAR, SEPARATION_CHAR, SEPARATION_CHAR,
+	)
 	if err != nil {
 		return nil, err
 	}
 
-	content := utils.TrimTrailingNewline(remoteBranchesStr)
+	content := utils.TrimTrailingNewline(tagsStr)
 	if content == "" {
 		return nil, nil
 	}
 
 	split := strings.Split(content, "\n")
 
-	// first step is to get our remotes from go-git
-	tags := make([]*models.Tag, len(split))
-	for i, tagName := range split {
-
-		tags[i] = &models.Tag{
-			Name: tagName,
+	tags := make([]*models.Tag, 0, len(split))
+	for _, line := range split {
+		tag := parseTagLine(line)
+		if tag != nil {
+			tags = append(tags, tag)
 		}
 	}
 
@@ -86,3 +89,33 @@ func (c *GitCommand) GetTags() ([]*models.Tag, error) {
 
 	return tags, nil
 }
+
+// parseTagLine parses a line of our for-each-ref output, e.g.
+// v1.0.0|tag|1a0a32b86acaa4247176b5a981eb59c7494a65cd|b21bce66deb73430bdcd0b2bf3ecd712b4276afb|Jesse Duffield|1613338425|signed|release v1.0.0
+// v0.9.0|commit|f2c73d56d9a1e280598eab14f1284e3ea4f33a0d|||1613338425||fix bug
+func parseTagLine(line string) *models.Tag {
+	split := strings.Split(line, SEPARATION_CHAR)
+	if len(split) < 8 {
+		return nil
+	}
+
+	annotated := split[1] == "tag"
+	targetSha := split[2]
+	message := ""
+	if annotated {
+		targetSha = split[3]
+		message = strings.Join(split[7:], SEPARATION_CHAR)
+	}
+
+	unixTimestamp, _ := strconv.Atoi(split[5])
+
+	return &models.Tag{
+		Name:          split[0],
+		Annotated:     annotated,
+		Signed:        split[6] == "signed",
+		Tagger:        split[4],
+		UnixTimestamp: int64(unixTimestamp),
+		TargetSha:     targetSha,
+		Message:       message,
+	}
+}
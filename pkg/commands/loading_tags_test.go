@@ -0,0 +1,73 @@
+package commands
+
+import (
+	"testing"
+
+	"github.com/jesseduffield/lazygit/pkg/commands/models"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestParseTagLine is a function.
+func TestParseTagLine(t *testing.T) {
+	type scenario struct {
+		testName string
+		line     string
+		expected *models.Tag
+	}
+
+	scenarios := []scenario{
+		{
+			"lightweight tag",
+			"v0.9.0|commit|f2c73d56d9a1e280598eab14f1284e3ea4f33a0d|||1613338425||fix bug",
+			&models.Tag{
+				Name:          "v0.9.0",
+				UnixTimestamp: 1613338425,
+				TargetSha:     "f2c73d56d9a1e280598eab14f1284e3ea4f33a0d",
+			},
+		},
+		{
+			"annotated tag with a separator in its message",
+			"v1.0.0|tag|1a0a32b86acaa4247176b5a981eb59c7494a65cd|b21bce66deb73430bdcd0b2bf3ecd712b4276afb|Jesse Duffield|1613338425||release | one",
+			&models.Tag{
+				Name:          "v1.0.0",
+				Annotated:     true,
+				Tagger:        "Jesse Duffield",
+				UnixTimestamp: 1613338425,
+				TargetSha:     "b21bce66deb73430bdcd0b2bf3ecd712b4276afb",
+				Message:       "release | one",
+			},
+		},
+		{
+			"signed tag",
+			"v1.1.0|tag|1a0a32b86acaa4247176b5a981eb59c7494a65cd|b21bce66deb73430bdcd0b2bf3ecd712b4276afb|Jesse Duffield|1613338425|signed|release",
+			&models.Tag{
+				Name:          "v1.1.0",
+				Annotated:     true,
+				Signed:        true,
+				Tagger:        "Jesse Duffield",
+				UnixTimestamp: 1613338425,
+				TargetSha:     "b21bce66deb73430bdcd0b2bf3ecd712b4276afb",
+				Message:       "release",
+			},
+		},
+		{
+			"malformed line",
+			"v1.1.0",
+			nil,
+		},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.testName, func(t *testing.T) {
+			assert.EqualValues(t, s.expected, parseTagLine(s.line))
+		})
+	}
+}
+
+// TestGitCommandCreateAnnotatedTagCmd is a function.
+func TestGitCommandCreateAnnotatedTagCmd(t *testing.T) {
+	gitCmd := NewDummyGitCommand()
+
+	assert.EqualValues(t, []string{"git", "tag", "--annotate", "v1.0.0"}, gitCmd.CreateAnnotatedTagCmd("v1.0.0", "", false).Args)
+	assert.EqualValues(t, []string{"git", "tag", "--sign", "v1.0.0", "b21bce66"}, gitCmd.CreateAnnotatedTagCmd("v1.0.0", "b21bce66", true).Args)
+}
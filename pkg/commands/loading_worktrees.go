@@ -0,0 +1,98 @@
+package commands
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/jesseduffield/lazygit/pkg/commands/models"
+	"github.com/jesseduffield/lazygit/pkg/utils"
+)
+
+// GetWorktrees returns the worktrees of the repo, with the main worktree first
+func (c *GitCommand) GetWorktrees() ([]*models.Worktree, error) {
+	output, err := c.OSCommand.RunCommandWithOutput("git worktree list --porcelain")
+	if err != nil {
+		return nil, err
+	}
+
+	currentPath, err := os.Getwd()
+	if err != nil {
+		return nil, err
+	}
+
+	worktrees := parseWorktrees(output)
+	for _, worktree := range worktrees {
+		worktree.Current = samePath(worktree.Path, currentPath)
+	}
+
+	return worktrees, nil
+}
+
+// parseWorktrees parses the output of `git worktree list --porcelain`, which
+// contains one block of lines per worktree, e.g.
+//
+// worktree /path/to/repo
+// HEAD 944b16720f5ecdff43d993f0b76d74cc2c71d026
+// branch refs/heads/master
+//
+// worktree /path/to/other
+// HEAD 944b16720f5ecdff43d993f0b76d74cc2c71d026
+// detached
+// locked
+func parseWorktrees(output string) []*models.Worktree {
+	worktrees := []*models.Worktree{}
+	var current *models.Worktree
+
+	for _, line := range utils.SplitLines(output) {
+		split := strings.SplitN(line, " ", 2)
+		key := split[0]
+		value := ""
+		if len(split) > 1 {
+			value = split[1]
+		}
+
+		if key == "worktree" {
+			current = &models.Worktree{
+				Path: value,
+				Main: len(worktrees) == 0,
+			}
+			worktrees = append(worktrees, current)
+			continue
+		}
+
+		if current == nil {
+			continue
+		}
+
+		switch key {
+		case "HEAD":
+			current.Head = value
+		case "branch":
+			current.Branch = strings.TrimPrefix(value, "refs/heads/")
+		case "bare":
+			current.Bare = true
+		case "detached":
+			current.Detached = true
+		case "locked":
+			current.Locked = true
+		case "prunable":
+			current.Prunable = true
+		}
+	}
+
+	return worktrees
+}
+
+func samePath(a string, b string) bool {
+	resolvedA, err := filepath.EvalSymlinks(a)
+	if err != nil {
+		resolvedA = a
+	}
+	resolvedB, err := filepath.EvalSymlinks(b)
+	if err != nil {
+		resolvedB = b
+	}
+
+	return filepath.Clean(resolvedA) == filepath.Clean(resolvedB)
+}
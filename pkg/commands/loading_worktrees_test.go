@@ -0,0 +1,184 @@
+package commands
+
+import (
+	"os/exec"
+	"testing"
+
+	"github.com/jesseduffield/lazygit/pkg/commands/models"
+	"github.com/jesseduffield/lazygit/pkg/test"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestParseWorktrees is a function.
+func TestParseWorktrees(t *testing.T) {
+	type scenario struct {
+		testName string
+		output   string
+		expected []*models.Worktree
+	}
+
+	scenarios := []scenario{
+		{
+			"no output",
+			"",
+			[]*models.Worktree{},
+		},
+		{
+			"main worktree and linked worktrees",
+			`worktree /repo
+HEAD 944b16720f5ecdff43d993f0b76d74cc2c71d026
+branch refs/heads/master
+
+worktree /repo-hotfix
+HEAD 6d1f2b4c3a0b9e8d7c6b5a4f3e2d1c0b9a8f7e6d
+branch refs/heads/feature/hotfix
+locked
+
+worktree /repo-review
+HEAD 944b16720f5ecdff43d993f0b76d74cc2c71d026
+detached
+prunable gitdir file points to non-existent location
+`,
+			[]*models.Worktree{
+				{
+					Path:   "/repo",
+					Head:   "944b16720f5ecdff43d993f0b76d74cc2c71d026",
+					Branch: "master",
+					Main:   true,
+				},
+				{
+					Path:   "/repo-hotfix",
+					Head:   "6d1f2b4c3a0b9e8d7c6b5a4f3e2d1c0b9a8f7e6d",
+					Branch: "feature/hotfix",
+					Locked: true,
+				},
+				{
+					Path:     "/repo-review",
+					Head:     "944b16720f5ecdff43d993f0b76d74cc2c71d026",
+					Detached: true,
+					Prunable: true,
+				},
+			},
+		},
+		{
+			"bare main worktree",
+			`worktree /repo.git
+bare
+
+worktree /repo-main
+HEAD 944b16720f5ecdff43d993f0b76d74cc2c71d026
+branch refs/heads/main
+`,
+			[]*models.Worktree{
+				{
+					Path: "/repo.git",
+					Bare: true,
+					Main: true,
+				},
+				{
+					Path:   "/repo-main",
+					Head:   "944b16720f5ecdff43d993f0b76d74cc2c71d026",
+					Branch: "main",
+				},
+			},
+		},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.testName, func(t *testing.T) {
+			assert.EqualValues(t, s.expected, parseWorktrees(s.output))
+		})
+	}
+}
+
+// TestGitCommandNewWorktree is a function.
+func TestGitCommandNewWorktree(t *testing.T) {
+	type scenario struct {
+		testName string
+		opts     NewWorktreeOptions
+		command  func(string, ...string) *exec.Cmd
+	}
+
+	scenarios := []scenario{
+		{
+			"checkout existing branch",
+			NewWorktreeOptions{Path: "../repo-hotfix", Base: "hotfix"},
+			test.CreateMockCommand(t, []*test.CommandSwapper{
+				{
+					Expect:  `git worktree add "../repo-hotfix" hotfix`,
+					Replace: "echo",
+				},
+			}),
+		},
+		{
+			"new branch off HEAD",
+			NewWorktreeOptions{Path: "../repo-review", Branch: "review"},
+			test.CreateMockCommand(t, []*test.CommandSwapper{
+				{
+					Expect:  `git worktree add -b review "../repo-review"`,
+					Replace: "echo",
+				},
+			}),
+		},
+		{
+			"detached commit",
+			NewWorktreeOptions{Path: "../repo-old", Base: "944b1672", Detach: true},
+			test.CreateMockCommand(t, []*test.CommandSwapper{
+				{
+					Expect:  `git worktree add --detach "../repo-old" 944b1672`,
+					Replace: "echo",
+				},
+			}),
+		},
+	}
+
+	gitCmd := NewDummyGitCommand()
+
+	for _, s := range scenarios {
+		t.Run(s.testName, func(t *testing.T) {
+			gitCmd.OSCommand.Command = s.command
+			assert.NoError(t, gitCmd.NewWorktree(s.opts))
+		})
+	}
+}
+
+// TestGitCommandRemoveWorktree is a function.
+func TestGitCommandRemoveWorktree(t *testing.T) {
+	type scenario struct {
+		testName string
+		force    bool
+		command  func(string, ...string) *exec.Cmd
+	}
+
+	scenarios := []scenario{
+		{
+			"not forced",
+			false,
+			test.CreateMockCommand(t, []*test.CommandSwapper{
+				{
+					Expect:  `git worktree remove "../repo-hotfix"`,
+					Replace: "echo",
+				},
+			}),
+		},
+		{
+			"forced",
+			true,
+			test.CreateMockCommand(t, []*test.CommandSwapper{
+				{
+					Expect:  `git worktree remove --force "../repo-hotfix"`,
+					Replace: "echo",
+				},
+			}),
+		},
+	}
+
+	gitCmd := NewDummyGitCommand()
+
+	for _, s := range scenarios {
+		t.Run(s.testName, func(t *testing.T) {
+			gitCmd.OSCommand.Command = s.command
+			assert.NoError(t, gitCmd.RemoveWorktree("../repo-hotfix", s.force))
+		})
+	}
+}
@@ -0,0 +1,98 @@
+package models
+
+type BisectStatus int
+
+const (
+	BisectStatusOld BisectStatus = iota
+	BisectStatusNew
+	BisectStatusSkipped
+)
+
+// BisectInfo describes the state of an in-progress `git bisect`
+type BisectInfo struct {
+	Started bool
+
+	// the ref we were on when we started bisecting, which is where
+	// `git bisect reset` will take us back to
+	StartRef string
+
+	// the terms used for marking commits, 'bad' and 'good' by default
+	NewTerm string
+	OldTerm string
+
+	// map of commit sha to how that commit has been marked
+	Statuses map[string]BisectStatus
+
+	// the commit git has checked out for us to test next
+	CurrentSha string
+
+	// set once git has narrowed things down to a single commit
+	FirstNewSha string
+
+	// number of commits left in the running, and roughly how many more
+	// steps it will take to find the culprit
+	Candidates int
+	Steps      int
+}
+
+func NewBisectInfo() *BisectInfo {
+	return &BisectInfo{
+		NewTerm:  "bad",
+		OldTerm:  "good",
+		Statuses: map[string]BisectStatus{},
+	}
+}
+
+// Bisecting tells us whether we've marked both a new and an old commit, meaning
+// git is actually narrowing things down rather than waiting on us
+func (b *BisectInfo) Bisecting() bool {
+	if !b.Started {
+		return false
+	}
+
+	foundNew := false
+	foundOld := false
+	for _, status := range b.Statuses {
+		switch status {
+		case BisectStatusNew:
+			foundNew = true
+		case BisectStatusOld:
+			foundOld = true
+		}
+	}
+
+	return foundNew && foundOld
+}
+
+func (b *BisectInfo) Done() bool {
+	return b.FirstNewSha != ""
+}
+
+// Term returns the term the given commit was marked with, if any
+func (b *BisectInfo) Term(sha string) (string, bool) {
+	status, ok := b.Statuses[sha]
+	if !ok {
+		return "", false
+	}
+
+	switch status {
+	case BisectStatusNew:
+		return b.NewTerm, true
+	case BisectStatusOld:
+		return b.OldTerm, true
+	default:
+		return "skip", true
+	}
+}
+
+// Shas returns the shas of all commits marked with the given status
+func (b *BisectInfo) Shas(status BisectStatus) []string {
+	shas := []string{}
+	for sha, s := range b.Statuses {
+		if s == status {
+			shas = append(shas, sha)
+		}
+	}
+
+	return shas
+}
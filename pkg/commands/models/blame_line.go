@@ -0,0 +1,35 @@
+package models
+
+import "strings"
+
+// BlameLine : A line of a file along with the commit that last changed it
+type BlameLine struct {
+	Sha           string
+	Author        string
+	UnixTimestamp int64
+	Summary       string
+	LineNumber    int
+	Content       string
+
+	// the line's number in the file as of Sha
+	OriginalLineNumber int
+
+	// the commit before Sha that touched the file, and the file's name at that
+	// commit, so that we can blame the line's previous version. Empty if Sha
+	// introduced the file
+	PreviousSha      string
+	PreviousFilename string
+}
+
+func (b *BlameLine) ShortSha() string {
+	if len(b.Sha) < 8 {
+		return b.Sha
+	}
+	return b.Sha[:8]
+}
+
+// Committed tells us whether the line has made it into a commit, as opposed to
+// being a change in the working tree
+func (b *BlameLine) Committed() bool {
+	return strings.Trim(b.Sha, "0") != ""
+}
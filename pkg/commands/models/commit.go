@@ -7,7 +7,7 @@ type Commit struct {
 	Sha           string
 	Name          string
 	Status        string // one of "unpushed", "pushed", "merged", "rebasing" or "selected"
-	Action        string // one of "", "pick", "edit", "squash", "reword", "drop", "fixup"
+	Action        string // one of "", "pick", "edit", "squash", "reword", "drop", "fixup", or for todo lines without a commit, "exec", "break", "label", "reset", "merge" etc
 	Tags          []string
 	ExtraInfo     string // something like 'HEAD -> master, tag: v0.15.2'
 	Author        string
@@ -15,6 +15,9 @@ type Commit struct {
 
 	// IsMerge tells us whether we're dealing with a merge commit i.e. a commit with two parents
 	IsMerge bool
+
+	// full shas of the commit's parents, first parent first
+	Parents []string
 }
 
 func (c *Commit) ShortSha() string {
@@ -33,5 +36,10 @@ func (c *Commit) ID() string {
 }
 
 func (c *Commit) Description() string {
+	// todo lines like 'exec' and 'break' have no commit
+	if c.Sha == "" {
+		return fmt.Sprintf("%s %s", c.Action, c.Name)
+	}
+
 	return fmt.Sprintf("%s %s", c.Sha[:7], c.Name)
 }
@@ -49,6 +49,17 @@ func (f *File) Matches(f2 *File) bool {
 	return utils.StringArraysOverlap(f.Names(), f2.Names())
 }
 
+// MissingOnConflictSide tells us whether a conflicted file is absent on the
+// given side, 'ours' or 'theirs', going by its short status: e.g. 'DU' is
+// deleted by us, and 'AU' is added by us, meaning they don't have it
+func (f *File) MissingOnConflictSide(side string) bool {
+	if side == "ours" {
+		return utils.IncludesString([]string{"DD", "DU", "UA"}, f.ShortStatus)
+	}
+
+	return utils.IncludesString([]string{"DD", "UD", "AU"}, f.ShortStatus)
+}
+
 func (f *File) ID() string {
 	return f.Name
 }
@@ -0,0 +1,57 @@
+package models
+
+import "fmt"
+
+// PullRequest : A pull request on a hosting service like GitHub (or merge
+// request, as GitLab calls them)
+type PullRequest struct {
+	Number int
+	Title  string
+	Body   string
+	Author string
+	// one of "open", "merged" or "closed"
+	State string
+	Draft bool
+	URL   string
+
+	HeadBranch string
+	HeadSha    string
+	BaseBranch string
+
+	// HeadRef is what we fetch to get the pull request's commits, e.g.
+	// 'refs/pull/12/head' on GitHub
+	HeadRef string
+	// HeadRepoURL is where to fetch HeadRef from, when the hosting service only
+	// has it on the repo the pull request came from. Blank means our own remote
+	HeadRepoURL string
+	// IsFork tells us the head branch lives in someone else's repo, so we can't
+	// assume a branch of the same name in ours has anything to do with it
+	IsFork bool
+
+	// one of "", "pending", "success", "failure" or "unknown". Blank means we
+	// don't know of any checks having run, whereas unknown means we couldn't
+	// find out
+	CIStatus string
+}
+
+func (p *PullRequest) IsOpen() bool {
+	return p.State == "open"
+}
+
+// LocalBranchName is the branch we check the pull request out into
+func (p *PullRequest) LocalBranchName() string {
+	if p.IsFork {
+		return fmt.Sprintf("pr/%d", p.Number)
+	}
+
+	return p.HeadBranch
+}
+
+// ID is the pull request's URL, being unique across forks unlike its number
+func (p *PullRequest) ID() string {
+	return p.URL
+}
+
+func (p *PullRequest) Description() string {
+	return fmt.Sprintf("#%d %s", p.Number, p.Title)
+}
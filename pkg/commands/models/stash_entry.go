@@ -1,17 +1,38 @@
 package models
 
-import "fmt"
+import (
+	"fmt"
+	"strings"
+)
 
 // StashEntry : A git stash entry
 type StashEntry struct {
 	Index int
 	Name  string
+	// the branch we stashed from, if git recorded it in the name
+	Branch        string
+	UnixTimestamp int64
 }
 
 func (s *StashEntry) RefName() string {
 	return fmt.Sprintf("stash@{%d}", s.Index)
 }
 
+// Message is the name minus the part git adds about where we stashed from,
+// which is what the user would have typed when stashing
+func (s *StashEntry) Message() string {
+	if s.Branch == "" {
+		return s.Name
+	}
+
+	prefix := fmt.Sprintf("On %s: ", s.Branch)
+	if strings.HasPrefix(s.Name, prefix) {
+		return strings.TrimPrefix(s.Name, prefix)
+	}
+
+	return strings.TrimPrefix(s.Name, "WIP on "+s.Branch+": ")
+}
+
 func (s *StashEntry) ID() string {
 	return s.RefName()
 }
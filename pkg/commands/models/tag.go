@@ -3,6 +3,18 @@ package models
 // Tag : A git tag
 type Tag struct {
 	Name string
+
+	// Annotated tags are tag objects with a tagger and a message of their own,
+	// as opposed to lightweight tags which are just refs pointing at a commit
+	Annotated bool
+	Signed    bool
+	Tagger    string
+	// for lightweight tags this is the date of the commit we point to
+	UnixTimestamp int64
+	// the sha of the commit the tag ultimately points to
+	TargetSha string
+	// subject line of the tag's message, for annotated tags
+	Message string
 }
 
 func (t *Tag) RefName() string {
@@ -13,6 +25,13 @@ func (t *Tag) ID() string {
 	return t.RefName()
 }
 
+func (t *Tag) ShortTargetSha() string {
+	if len(t.TargetSha) < 8 {
+		return t.TargetSha
+	}
+	return t.TargetSha[:8]
+}
+
 func (t *Tag) Description() string {
 	return "tag " + t.Name
 }
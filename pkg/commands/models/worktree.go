@@ -0,0 +1,47 @@
+package models
+
+import "path/filepath"
+
+// Worktree : A git worktree
+type Worktree struct {
+	Path string
+	Head string
+	// Branch is blank when the worktree has a detached HEAD
+	Branch   string
+	Bare     bool
+	Detached bool
+	Locked   bool
+	Prunable bool
+
+	// Main tells us whether this is the main worktree i.e. the one that owns the .git directory
+	Main bool
+
+	// Current tells us whether this is the worktree lazygit currently has open
+	Current bool
+}
+
+func (w *Worktree) Name() string {
+	return filepath.Base(w.Path)
+}
+
+func (w *Worktree) ShortHead() string {
+	if len(w.Head) < 8 {
+		return w.Head
+	}
+	return w.Head[:8]
+}
+
+func (w *Worktree) RefName() string {
+	if w.Branch != "" {
+		return w.Branch
+	}
+	return w.Head
+}
+
+func (w *Worktree) ID() string {
+	return w.Path
+}
+
+func (w *Worktree) Description() string {
+	return w.Path
+}
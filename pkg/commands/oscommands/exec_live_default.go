@@ -26,9 +26,11 @@ func RunCommandWithOutputLiveWrapper(c *OSCommand, command string, output func(s
 	var stderr bytes.Buffer
 	cmd.Stderr = &stderr
 
+	c.BeforeExecuteCmd(cmd)
 	ptmx, err := pty.Start(cmd)
 
 	if err != nil {
+		c.AfterExecuteCmd(cmd, err)
 		return err
 	}
 
@@ -43,6 +45,7 @@ func RunCommandWithOutputLiveWrapper(c *OSCommand, command string, output func(s
 
 	err = cmd.Wait()
 	ptmx.Close()
+	c.AfterExecuteCmd(cmd, err)
 	if err != nil {
 		return errors.New(stderr.String())
 	}
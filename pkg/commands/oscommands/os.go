@@ -2,6 +2,7 @@ package oscommands
 
 import (
 	"bufio"
+	"bytes"
 	"fmt"
 	"io/ioutil"
 	"os"
@@ -39,6 +40,7 @@ type OSCommand struct {
 	Config           config.AppConfigurer
 	Command          func(string, ...string) *exec.Cmd
 	BeforeExecuteCmd func(*exec.Cmd)
+	AfterExecuteCmd  func(*exec.Cmd, error)
 	Getenv           func(string) string
 }
 
@@ -50,6 +52,7 @@ func NewOSCommand(log *logrus.Entry, config config.AppConfigurer) *OSCommand {
 		Config:           config,
 		Command:          secureexec.Command,
 		BeforeExecuteCmd: func(*exec.Cmd) {},
+		AfterExecuteCmd:  func(*exec.Cmd, error) {},
 		Getenv:           os.Getenv,
 	}
 }
@@ -64,6 +67,27 @@ func (c *OSCommand) SetBeforeExecuteCmd(cmd func(*exec.Cmd)) {
 	c.BeforeExecuteCmd = cmd
 }
 
+// SetAfterExecuteCmd sets a function to be called once a command has finished,
+// along with the error it returned, if any
+func (c *OSCommand) SetAfterExecuteCmd(cmd func(*exec.Cmd, error)) {
+	c.AfterExecuteCmd = cmd
+}
+
+// Clone returns a copy of the OSCommand, so that we can give the copy its own
+// hooks without affecting anybody else using the original
+func (c *OSCommand) Clone() *OSCommand {
+	newOSCommand := *c
+	return &newOSCommand
+}
+
+// combinedOutput runs the command, calling our before and after hooks around it
+func (c *OSCommand) combinedOutput(cmd *exec.Cmd) ([]byte, error) {
+	c.BeforeExecuteCmd(cmd)
+	output, err := cmd.CombinedOutput()
+	c.AfterExecuteCmd(cmd, err)
+	return output, err
+}
+
 type RunCommandOptions struct {
 	EnvVars []string
 }
@@ -75,7 +99,7 @@ func (c *OSCommand) RunCommandWithOutputWithOptions(command string, options RunC
 	cmd.Env = append(cmd.Env, "GIT_TERMINAL_PROMPT=0") // prevents git from prompting us for input which would freeze the program
 	cmd.Env = append(cmd.Env, options.EnvVars...)
 
-	return sanitisedCommandOutput(cmd.CombinedOutput())
+	return sanitisedCommandOutput(c.combinedOutput(cmd))
 }
 
 func (c *OSCommand) RunCommandWithOptions(command string, options RunCommandOptions) error {
@@ -96,7 +120,7 @@ func (c *OSCommand) RunCommandWithOutput(formatString string, formatArgs ...inte
 	}
 	c.Log.WithField("command", command).Info("RunCommand")
 	cmd := c.ExecutableFromString(command)
-	output, err := sanitisedCommandOutput(cmd.CombinedOutput())
+	output, err := sanitisedCommandOutput(c.combinedOutput(cmd))
 	if err != nil {
 		c.Log.WithField("command", command).Error(output)
 	}
@@ -108,7 +132,7 @@ func (c *OSCommand) CatFile(filename string) (string, error) {
 	cmdStr := strings.Join(arr, " ")
 	c.Log.WithField("command", cmdStr).Info("Cat")
 	cmd := c.Command(arr[0], arr[1:]...)
-	output, err := sanitisedCommandOutput(cmd.CombinedOutput())
+	output, err := sanitisedCommandOutput(c.combinedOutput(cmd))
 	if err != nil {
 		c.Log.WithField("command", cmdStr).Error(output)
 	}
@@ -117,8 +141,7 @@ func (c *OSCommand) CatFile(filename string) (string, error) {
 
 // RunExecutableWithOutput runs an executable file and returns its output
 func (c *OSCommand) RunExecutableWithOutput(cmd *exec.Cmd) (string, error) {
-	c.BeforeExecuteCmd(cmd)
-	return sanitisedCommandOutput(cmd.CombinedOutput())
+	return sanitisedCommandOutput(c.combinedOutput(cmd))
 }
 
 // RunExecutable runs an executable file and returns an error if there was one
@@ -190,12 +213,42 @@ func (c *OSCommand) RunCommand(formatString string, formatArgs ...interface{}) e
 // RunShellCommand runs shell commands i.e. 'sh -c <command>'. Good for when you
 // need access to the shell
 func (c *OSCommand) RunShellCommand(command string) error {
+	_, err := c.RunShellCommandWithOutput(command)
+
+	return err
+}
+
+// RunShellCommandWithOutput is like RunShellCommand but returns the output too
+func (c *OSCommand) RunShellCommandWithOutput(command string) (string, error) {
+	c.Log.WithField("command", command).Info("RunShellCommand")
+
+	cmd := c.Command(c.Platform.Shell, c.Platform.ShellArg, command)
+	return sanitisedCommandOutput(c.combinedOutput(cmd))
+}
+
+// RunShellCommandWithStdout is like RunShellCommandWithOutput but only returns
+// what the command writes to stdout, for when we're going to make something of
+// the output. What it writes to stderr, such as a warning, is left out, unless
+// the command fails, in which case it's the error
+func (c *OSCommand) RunShellCommandWithStdout(command string) (string, error) {
 	c.Log.WithField("command", command).Info("RunShellCommand")
 
 	cmd := c.Command(c.Platform.Shell, c.Platform.ShellArg, command)
-	_, err := sanitisedCommandOutput(cmd.CombinedOutput())
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
 
-	return err
+	c.BeforeExecuteCmd(cmd)
+	stdout, err := cmd.Output()
+	c.AfterExecuteCmd(cmd, err)
+
+	if err != nil {
+		if stderrString := strings.TrimSpace(stderr.String()); stderrString != "" {
+			return "", errors.New(stderrString)
+		}
+		return "", utils.WrapError(err)
+	}
+
+	return string(stdout), nil
 }
 
 // FileType tells us if the file is a file, directory or other
@@ -348,8 +401,7 @@ func (c *OSCommand) FileExists(path string) (bool, error) {
 // this is useful if you need to give your command some environment variables
 // before running it
 func (c *OSCommand) RunPreparedCommand(cmd *exec.Cmd) error {
-	c.BeforeExecuteCmd(cmd)
-	out, err := cmd.CombinedOutput()
+	out, err := c.combinedOutput(cmd)
 	outString := string(out)
 	c.Log.Info(outString)
 	if err != nil {
@@ -404,6 +456,7 @@ func (c *OSCommand) PipeCommands(commandStrings ...string) error {
 				c.Log.Error(err)
 			}
 
+			c.BeforeExecuteCmd(currentCmd)
 			if err := currentCmd.Start(); err != nil {
 				c.Log.Error(err)
 			}
@@ -414,9 +467,11 @@ func (c *OSCommand) PipeCommands(commandStrings ...string) error {
 				}
 			}
 
-			if err := currentCmd.Wait(); err != nil {
+			err = currentCmd.Wait()
+			if err != nil {
 				c.Log.Error(err)
 			}
+			c.AfterExecuteCmd(currentCmd, err)
 
 			wg.Done()
 		})
@@ -3,7 +3,9 @@
 package oscommands
 
 import (
+	"os/exec"
 	"runtime"
+	"syscall"
 )
 
 func getPlatform() *Platform {
@@ -17,3 +19,18 @@ func getPlatform() *Platform {
 		OpenLinkCommand: "open {{link}}",
 	}
 }
+
+// PrepareForKillWithChildren starts the command in its own process group, so
+// that KillWithChildren can get whatever the command starts too
+func PrepareForKillWithChildren(cmd *exec.Cmd) {
+	cmd.SysProcAttr = &syscall.SysProcAttr{Setpgid: true}
+}
+
+// KillWithChildren kills the process group of a command prepared with
+// PrepareForKillWithChildren
+func KillWithChildren(cmd *exec.Cmd) error {
+	if cmd.Process == nil {
+		return nil
+	}
+	return syscall.Kill(-cmd.Process.Pid, syscall.SIGKILL)
+}
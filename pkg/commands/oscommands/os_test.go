@@ -38,6 +38,47 @@ func TestOSCommandRunCommandWithOutput(t *testing.T) {
 	}
 }
 
+// TestOSCommandRunShellCommandWithStdout is a function.
+func TestOSCommandRunShellCommandWithStdout(t *testing.T) {
+	type scenario struct {
+		command string
+		test    func(string, error)
+	}
+
+	scenarios := []scenario{
+		{
+			"echo 123",
+			func(output string, err error) {
+				assert.NoError(t, err)
+				assert.EqualValues(t, "123\n", output)
+			},
+		},
+		{
+			"echo 123; echo warning >&2",
+			func(output string, err error) {
+				assert.NoError(t, err)
+				assert.EqualValues(t, "123\n", output)
+			},
+		},
+		{
+			"echo 123; echo failure >&2; exit 1",
+			func(output string, err error) {
+				assert.EqualError(t, err, "failure")
+			},
+		},
+		{
+			"echo 123; exit 1",
+			func(output string, err error) {
+				assert.EqualError(t, err, "exit status 1")
+			},
+		},
+	}
+
+	for _, s := range scenarios {
+		s.test(NewDummyOSCommand().RunShellCommandWithStdout(s.command))
+	}
+}
+
 // TestOSCommandRunCommand is a function.
 func TestOSCommandRunCommand(t *testing.T) {
 	type scenario struct {
@@ -59,6 +100,47 @@ func TestOSCommandRunCommand(t *testing.T) {
 	}
 }
 
+// TestOSCommandExecuteCmdHooks is a function.
+func TestOSCommandExecuteCmdHooks(t *testing.T) {
+	type scenario struct {
+		command      string
+		expectedArgs []string
+		expectErr    bool
+	}
+
+	scenarios := []scenario{
+		{
+			"echo hello",
+			[]string{"echo", "hello"},
+			false,
+		},
+		{
+			"rmdir unexisting-folder",
+			[]string{"rmdir", "unexisting-folder"},
+			true,
+		},
+	}
+
+	for _, s := range scenarios {
+		osCommand := NewDummyOSCommand()
+		var beforeArgs, afterArgs []string
+		var afterErr error
+		osCommand.SetBeforeExecuteCmd(func(cmd *exec.Cmd) {
+			beforeArgs = cmd.Args
+		})
+		osCommand.SetAfterExecuteCmd(func(cmd *exec.Cmd, err error) {
+			afterArgs = cmd.Args
+			afterErr = err
+		})
+
+		_ = osCommand.RunCommand(s.command)
+
+		assert.EqualValues(t, s.expectedArgs, beforeArgs)
+		assert.EqualValues(t, s.expectedArgs, afterArgs)
+		assert.Equal(t, s.expectErr, afterErr != nil)
+	}
+}
+
 // TestOSCommandOpenFile is a function.
 func TestOSCommandOpenFile(t *testing.T) {
 	type scenario struct {
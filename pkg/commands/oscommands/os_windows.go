@@ -1,5 +1,7 @@
 package oscommands
 
+import "os/exec"
+
 func getPlatform() *Platform {
 	return &Platform{
 		OS:           "windows",
@@ -9,3 +11,11 @@ func getPlatform() *Platform {
 		EscapedQuote: `\"`,
 	}
 }
+
+// PrepareForKillWithChildren does nothing on windows, where we can only kill
+// the command itself
+func PrepareForKillWithChildren(cmd *exec.Cmd) {}
+
+func KillWithChildren(cmd *exec.Cmd) error {
+	return Kill(cmd)
+}
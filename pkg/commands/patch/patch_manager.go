@@ -43,17 +43,15 @@ type PatchManager struct {
 	// fileInfoMap starts empty but you add files to it as you go along
 	fileInfoMap map[string]*fileInfo
 	Log         *logrus.Entry
-	ApplyPatch  applyPatchFunc
 
 	// LoadFileDiff loads the diff of a file, for a given to (typically a commit SHA)
 	LoadFileDiff loadFileDiffFunc
 }
 
 // NewPatchManager returns a new PatchManager
-func NewPatchManager(log *logrus.Entry, applyPatch applyPatchFunc, loadFileDiff loadFileDiffFunc) *PatchManager {
+func NewPatchManager(log *logrus.Entry, loadFileDiff loadFileDiffFunc) *PatchManager {
 	return &PatchManager{
 		Log:          log,
-		ApplyPatch:   applyPatch,
 		LoadFileDiff: loadFileDiff,
 	}
 }
@@ -243,7 +241,9 @@ func (p *PatchManager) GetFileIncLineIndices(filename string) ([]int, error) {
 	return info.includedLineIndices, nil
 }
 
-func (p *PatchManager) ApplyPatches(reverse bool) error {
+// ApplyPatches applies the patch of each file with the given function, which
+// lets the caller pick what the git commands are run through
+func (p *PatchManager) ApplyPatches(applyPatch applyPatchFunc, reverse bool) error {
 	// for whole patches we'll apply the patch in reverse
 	// but for part patches we'll apply a reverse patch forwards
 	for filename, info := range p.fileInfoMap {
@@ -268,7 +268,7 @@ func (p *PatchManager) ApplyPatches(reverse bool) error {
 			if patch == "" {
 				continue
 			}
-			if err = p.ApplyPatch(patch, applyFlags...); err != nil {
+			if err = applyPatch(patch, applyFlags...); err != nil {
 				continue
 			}
 			break
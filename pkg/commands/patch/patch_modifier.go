@@ -145,6 +145,65 @@ func (hunk *PatchHunk) LineNumberOfLine(idx int) int {
 	return hunk.newStart + offset
 }
 
+// OldLineNumberOfLine is the equivalent of LineNumberOfLine for the old version
+// of the file
+func (hunk *PatchHunk) OldLineNumberOfLine(idx int) int {
+	lines := hunk.bodyLines[0 : idx-hunk.FirstLineIdx-1]
+
+	offset := nLinesWithPrefix(lines, []string{"-", " "})
+
+	return hunk.oldStart + offset
+}
+
+// OldLineNumber maps a line number in the new version of the file to the old
+// version, given the diff's hunks. Lines which the diff added map to the line
+// they were added before
+func OldLineNumber(hunks []*PatchHunk, newLineNumber int) int {
+	offset := 0
+	for _, hunk := range hunks {
+		lines := []string{}
+		for _, line := range hunk.bodyLines {
+			if line != "" {
+				lines = append(lines, line)
+			}
+		}
+
+		// a hunk with nothing on one side gives the line before it as its start
+		oldLineNumber, lineNumber := hunk.oldStart, hunk.newStart
+		if nLinesWithPrefix(lines, []string{"-", " "}) == 0 {
+			oldLineNumber++
+		}
+		if nLinesWithPrefix(lines, []string{"+", " "}) == 0 {
+			lineNumber++
+		}
+
+		if newLineNumber < lineNumber {
+			break
+		}
+
+		for _, line := range lines {
+			switch line[:1] {
+			case " ":
+				if lineNumber == newLineNumber {
+					return oldLineNumber
+				}
+				oldLineNumber++
+				lineNumber++
+			case "-":
+				oldLineNumber++
+			case "+":
+				if lineNumber == newLineNumber {
+					return oldLineNumber
+				}
+				lineNumber++
+			}
+		}
+		offset = oldLineNumber - lineNumber
+	}
+
+	return newLineNumber + offset
+}
+
 func nLinesWithPrefix(lines []string, chars []string) int {
 	result := 0
 	for _, line := range lines {
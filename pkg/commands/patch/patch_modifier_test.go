@@ -546,3 +546,122 @@ func TestLineNumberOfLine(t *testing.T) {
 		})
 	}
 }
+
+func TestOldLineNumberOfLine(t *testing.T) {
+	type scenario struct {
+		testName string
+		hunk     *PatchHunk
+		idx      int
+		expected int
+	}
+
+	scenarios := []scenario{
+		{
+			testName: "deleted line",
+			hunk:     newHunk(strings.SplitAfter(exampleHunk, "\n"), 10),
+			idx:      12,
+			expected: 2,
+		},
+		{
+			testName: "added line",
+			hunk:     newHunk(strings.SplitAfter(exampleHunk, "\n"), 10),
+			idx:      13,
+			expected: 3,
+		},
+		{
+			testName: "context line after the change",
+			hunk:     newHunk(strings.SplitAfter(exampleHunk, "\n"), 10),
+			idx:      14,
+			expected: 3,
+		},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.testName, func(t *testing.T) {
+			result := s.hunk.OldLineNumberOfLine(s.idx)
+			if !assert.Equal(t, s.expected, result) {
+				fmt.Println(result)
+			}
+		})
+	}
+}
+
+func TestOldLineNumber(t *testing.T) {
+	type scenario struct {
+		testName      string
+		diffText      string
+		newLineNumber int
+		expected      int
+	}
+
+	diffText := `diff --git a/filename b/filename
+index dcd3485..1ba5540 100644
+--- a/filename
++++ b/filename
+@@ -2,3 +2,4 @@ apple
+ grape
+-orange
++mango
++kiwi
+ pear
+@@ -10,2 +10,0 @@ banana
+-lemon
+-lime
+@@ -20,0 +20,1 @@ cherry
++plum
+`
+
+	scenarios := []scenario{
+		{
+			testName:      "line before the first hunk",
+			diffText:      diffText,
+			newLineNumber: 1,
+			expected:      1,
+		},
+		{
+			testName:      "context line",
+			diffText:      diffText,
+			newLineNumber: 5,
+			expected:      4,
+		},
+		{
+			testName:      "added line",
+			diffText:      diffText,
+			newLineNumber: 3,
+			expected:      4,
+		},
+		{
+			testName:      "line between hunks",
+			diffText:      diffText,
+			newLineNumber: 7,
+			expected:      6,
+		},
+		{
+			testName:      "line after deleted lines",
+			diffText:      diffText,
+			newLineNumber: 11,
+			expected:      12,
+		},
+		{
+			testName:      "line added by a hunk with no old lines",
+			diffText:      diffText,
+			newLineNumber: 20,
+			expected:      21,
+		},
+		{
+			testName:      "line after the last hunk",
+			diffText:      diffText,
+			newLineNumber: 30,
+			expected:      30,
+		},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.testName, func(t *testing.T) {
+			result := OldLineNumber(GetHunksFromDiff(s.diffText), s.newLineNumber)
+			if !assert.Equal(t, s.expected, result) {
+				fmt.Println(result)
+			}
+		})
+	}
+}
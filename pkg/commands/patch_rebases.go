@@ -15,7 +15,7 @@ func (c *GitCommand) DeletePatchesFromCommit(commits []*models.Commit, commitInd
 	}
 
 	// apply each patch in reverse
-	if err := p.ApplyPatches(true); err != nil {
+	if err := p.ApplyPatches(c.ApplyPatch, true); err != nil {
 		if err := c.GenericMergeOrRebaseAction("rebase", "abort"); err != nil {
 			return err
 		}
@@ -27,7 +27,7 @@ func (c *GitCommand) DeletePatchesFromCommit(commits []*models.Commit, commitInd
 		return err
 	}
 
-	c.onSuccessfulContinue = func() error {
+	*c.onSuccessfulContinue = func() error {
 		c.PatchManager.Reset()
 		return nil
 	}
@@ -43,7 +43,7 @@ func (c *GitCommand) MovePatchToSelectedCommit(commits []*models.Commit, sourceC
 		}
 
 		// apply each patch forward
-		if err := p.ApplyPatches(false); err != nil {
+		if err := p.ApplyPatches(c.ApplyPatch, false); err != nil {
 			if err := c.GenericMergeOrRebaseAction("rebase", "abort"); err != nil {
 				return err
 			}
@@ -55,7 +55,7 @@ func (c *GitCommand) MovePatchToSelectedCommit(commits []*models.Commit, sourceC
 			return err
 		}
 
-		c.onSuccessfulContinue = func() error {
+		*c.onSuccessfulContinue = func() error {
 			c.PatchManager.Reset()
 			return nil
 		}
@@ -95,7 +95,7 @@ func (c *GitCommand) MovePatchToSelectedCommit(commits []*models.Commit, sourceC
 	}
 
 	// apply each patch in reverse
-	if err := p.ApplyPatches(true); err != nil {
+	if err := p.ApplyPatches(c.ApplyPatch, true); err != nil {
 		if err := c.GenericMergeOrRebaseAction("rebase", "abort"); err != nil {
 			return err
 		}
@@ -107,14 +107,14 @@ func (c *GitCommand) MovePatchToSelectedCommit(commits []*models.Commit, sourceC
 		return err
 	}
 
-	if c.onSuccessfulContinue != nil {
+	if *c.onSuccessfulContinue != nil {
 		return errors.New("You are midway through another rebase operation. Please abort to start again")
 	}
 
-	c.onSuccessfulContinue = func() error {
+	*c.onSuccessfulContinue = func() error {
 		// now we should be up to the destination, so let's apply forward these patches to that.
 		// ideally we would ensure we're on the right commit but I'm not sure if that check is necessary
-		if err := p.ApplyPatches(false); err != nil {
+		if err := p.ApplyPatches(c.ApplyPatch, false); err != nil {
 			if err := c.GenericMergeOrRebaseAction("rebase", "abort"); err != nil {
 				return err
 			}
@@ -126,7 +126,7 @@ func (c *GitCommand) MovePatchToSelectedCommit(commits []*models.Commit, sourceC
 			return err
 		}
 
-		c.onSuccessfulContinue = func() error {
+		*c.onSuccessfulContinue = func() error {
 			c.PatchManager.Reset()
 			return nil
 		}
@@ -148,7 +148,7 @@ func (c *GitCommand) PullPatchIntoIndex(commits []*models.Commit, commitIdx int,
 		return err
 	}
 
-	if err := p.ApplyPatches(true); err != nil {
+	if err := p.ApplyPatches(c.ApplyPatch, true); err != nil {
 		if c.WorkingTreeState() == REBASE_MODE_REBASING {
 			if err := c.GenericMergeOrRebaseAction("rebase", "abort"); err != nil {
 				return err
@@ -162,13 +162,13 @@ func (c *GitCommand) PullPatchIntoIndex(commits []*models.Commit, commitIdx int,
 		return err
 	}
 
-	if c.onSuccessfulContinue != nil {
+	if *c.onSuccessfulContinue != nil {
 		return errors.New("You are midway through another rebase operation. Please abort to start again")
 	}
 
-	c.onSuccessfulContinue = func() error {
+	*c.onSuccessfulContinue = func() error {
 		// add patches to index
-		if err := p.ApplyPatches(false); err != nil {
+		if err := p.ApplyPatches(c.ApplyPatch, false); err != nil {
 			if c.WorkingTreeState() == REBASE_MODE_REBASING {
 				if err := c.GenericMergeOrRebaseAction("rebase", "abort"); err != nil {
 					return err
@@ -195,7 +195,7 @@ func (c *GitCommand) PullPatchIntoNewCommit(commits []*models.Commit, commitIdx
 		return err
 	}
 
-	if err := p.ApplyPatches(true); err != nil {
+	if err := p.ApplyPatches(c.ApplyPatch, true); err != nil {
 		if err := c.GenericMergeOrRebaseAction("rebase", "abort"); err != nil {
 			return err
 		}
@@ -208,7 +208,7 @@ func (c *GitCommand) PullPatchIntoNewCommit(commits []*models.Commit, commitIdx
 	}
 
 	// add patches to index
-	if err := p.ApplyPatches(false); err != nil {
+	if err := p.ApplyPatches(c.ApplyPatch, false); err != nil {
 		if err := c.GenericMergeOrRebaseAction("rebase", "abort"); err != nil {
 			return err
 		}
@@ -222,7 +222,7 @@ func (c *GitCommand) PullPatchIntoNewCommit(commits []*models.Commit, commitIdx
 		return err
 	}
 
-	if c.onSuccessfulContinue != nil {
+	if *c.onSuccessfulContinue != nil {
 		return errors.New("You are midway through another rebase operation. Please abort to start again")
 	}
 
@@ -5,14 +5,28 @@ import (
 	"strings"
 
 	"github.com/go-errors/errors"
+	"github.com/jesseduffield/lazygit/pkg/commands/hosting"
 	"github.com/jesseduffield/lazygit/pkg/commands/models"
 	"github.com/jesseduffield/lazygit/pkg/config"
+	"github.com/jesseduffield/lazygit/pkg/utils"
 )
 
 // Service is a service that repository is on (Github, Bitbucket, ...)
 type Service struct {
 	Name           string
 	PullRequestURL string
+	// Type is one of github, bitbucket or gitlab
+	Type string
+	// SiteDomain is where the service's web interface and API live
+	SiteDomain string
+}
+
+// hostingTokenEnvVars are where we look for an API token when the user hasn't
+// put one in their config
+var hostingTokenEnvVars = map[string]string{
+	"github":    "GITHUB_TOKEN",
+	"gitlab":    "GITLAB_TOKEN",
+	"bitbucket": "BITBUCKET_TOKEN",
 }
 
 // PullRequest opens a link in browser to create new pull request
@@ -50,9 +64,29 @@ func NewService(typeName string, repositoryDomain string, siteDomain string) *Se
 		}
 	}
 
+	if service != nil {
+		service.Type = typeName
+		service.SiteDomain = siteDomain
+	}
+
 	return service
 }
 
+func (s *Service) defaultAPIURL() string {
+	switch s.Type {
+	case "github":
+		if s.SiteDomain == "github.com" {
+			return "https://api.github.com"
+		}
+		// GitHub Enterprise
+		return fmt.Sprintf("https://%s/api/v3", s.SiteDomain)
+	case "gitlab":
+		return fmt.Sprintf("https://%s/api/v4", s.SiteDomain)
+	default:
+		return "https://api.bitbucket.org/2.0"
+	}
+}
+
 func getServices(config config.AppConfigurer) []*Service {
 	services := []*Service{
 		NewService("github", "github.com", "github.com"),
@@ -110,32 +144,154 @@ func (pr *PullRequest) CopyURL(branch *models.Branch) error {
 }
 
 func (pr *PullRequest) getPullRequestURL(branch *models.Branch) (string, error) {
-	branchExistsOnRemote := pr.GitCommand.CheckRemoteBranchExists(branch)
+	// the link is to the repo at origin's url
+	branchExistsOnRemote := pr.GitCommand.CheckRemoteBranchExists("origin", branch)
 
 	if !branchExistsOnRemote {
 		return "", errors.New(pr.GitCommand.Tr.NoBranchOnRemote)
 	}
 
 	repoURL := pr.GitCommand.GetRemoteURL()
-	var gitService *Service
+	gitService := pr.getService(repoURL)
+	if gitService == nil {
+		return "", errors.New(pr.GitCommand.Tr.UnsupportedGitService)
+	}
+
+	repoInfo := getRepoInfoFromURL(repoURL)
+	pullRequestURL := fmt.Sprintf(
+		gitService.PullRequestURL, repoInfo.Owner, repoInfo.Repository, branch.Name,
+	)
+
+	return pullRequestURL, nil
+}
 
+func (pr *PullRequest) getService(repoURL string) *Service {
 	for _, service := range pr.GitServices {
 		if strings.Contains(repoURL, service.Name) {
-			gitService = service
-			break
+			return service
 		}
 	}
 
+	return nil
+}
+
+// HostingClient returns a client for the API of the service the repo's remote is
+// hosted on
+func (pr *PullRequest) HostingClient() (hosting.Client, error) {
+	// this has to be the remote we fetch pull requests from
+	repoURL := pr.GitCommand.GetConfigValue("remote." + pr.GitCommand.TrackedRemoteName() + ".url")
+	gitService := pr.getService(repoURL)
 	if gitService == nil {
-		return "", errors.New(pr.GitCommand.Tr.UnsupportedGitService)
+		return nil, errors.New(pr.GitCommand.Tr.UnsupportedGitService)
+	}
+
+	serviceConfig := pr.GitCommand.Config.GetUserConfig().HostingServices[gitService.Name]
+
+	token := serviceConfig.Token
+	if token == "" {
+		token = pr.GitCommand.OSCommand.Getenv(hostingTokenEnvVars[gitService.Type])
+	}
+	if token == "" {
+		return nil, errors.New(utils.ResolvePlaceholderString(
+			pr.GitCommand.Tr.NoHostingServiceToken,
+			map[string]string{
+				"domain": gitService.Name,
+				"envVar": hostingTokenEnvVars[gitService.Type],
+			},
+		))
+	}
+
+	apiURL := serviceConfig.APIURL
+	if apiURL == "" {
+		apiURL = gitService.defaultAPIURL()
 	}
 
 	repoInfo := getRepoInfoFromURL(repoURL)
-	pullRequestURL := fmt.Sprintf(
-		gitService.PullRequestURL, repoInfo.Owner, repoInfo.Repository, branch.Name,
-	)
 
-	return pullRequestURL, nil
+	return hosting.NewClient(hosting.ClientOpts{
+		ServiceType: gitService.Type,
+		APIURL:      apiURL,
+		Token:       token,
+		Username:    serviceConfig.Username,
+		Owner:       repoInfo.Owner,
+		Repository:  repoInfo.Repository,
+	})
+}
+
+// FetchPullRequest fetches the pull request's head so that it can be checked out
+// as pr.LocalBranchName(). Unless the pull request comes from a fork, that just
+// means fetching the branch, leaving git to set up tracking when we check it out
+func (c *GitCommand) FetchPullRequest(pr *models.PullRequest, remoteName string, promptUserForCredential func(string) string) error {
+	if !pr.IsFork {
+		return c.Fetch(FetchOptions{
+			PromptUserForCredential: promptUserForCredential,
+			RemoteName:              remoteName,
+			BranchName:              pr.HeadBranch,
+		})
+	}
+
+	source := remoteName
+	if pr.HeadRepoURL != "" {
+		source = c.OSCommand.Quote(pr.HeadRepoURL)
+	}
+	command := fmt.Sprintf("git fetch %s %s:%s", source, pr.HeadRef, pr.LocalBranchName())
+
+	return c.OSCommand.DetectUnamePass(command, promptUserForCredential)
+}
+
+// GetPullRequestContent suggests a title and body for a pull request of the
+// branch's commits that aren't on baseRef. A single commit's message is used as
+// is, whereas several commits get a title from the branch name and their
+// subjects listed in the body, oldest first
+func (c *GitCommand) GetPullRequestContent(baseRef string, branchName string) (string, string, error) {
+	output, err := c.OSCommand.RunCommandWithOutput("git rev-list --reverse %s..%s", baseRef, branchName)
+	if err != nil {
+		return "", "", err
+	}
+
+	shas := utils.SplitLines(output)
+	if len(shas) == 1 {
+		message, err := c.GetCommitMessage(shas[0])
+		if err != nil {
+			return "", "", err
+		}
+		title, body := splitCommitMessage(message)
+		return title, body, nil
+	}
+
+	subjects := make([]string, len(shas))
+	for i, sha := range shas {
+		message, err := c.GetCommitMessage(sha)
+		if err != nil {
+			return "", "", err
+		}
+		subject, _ := splitCommitMessage(message)
+		subjects[i] = "- " + subject
+	}
+
+	return titleFromBranchName(branchName), strings.Join(subjects, "\n"), nil
+}
+
+// splitCommitMessage splits a message into its subject line and the body that
+// follows the blank line
+func splitCommitMessage(message string) (string, string) {
+	parts := strings.SplitN(message, "\n", 2)
+	if len(parts) == 1 {
+		return parts[0], ""
+	}
+
+	return parts[0], strings.TrimSpace(parts[1])
+}
+
+// titleFromBranchName turns e.g. 'feature/add-login_page' into 'Add login page'
+func titleFromBranchName(branchName string) string {
+	name := branchName[strings.LastIndex(branchName, "/")+1:]
+	name = strings.NewReplacer("-", " ", "_", " ").Replace(name)
+	if name == "" {
+		return ""
+	}
+
+	return strings.ToUpper(name[:1]) + name[1:]
 }
 
 func getRepoInfoFromURL(url string) *RepoInformation {
@@ -1,12 +1,18 @@
 package commands
 
 import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
 	"os/exec"
 	"strings"
 	"testing"
 
+	"github.com/jesseduffield/lazygit/pkg/commands/hosting"
 	"github.com/jesseduffield/lazygit/pkg/commands/models"
+	"github.com/jesseduffield/lazygit/pkg/config"
 	"github.com/jesseduffield/lazygit/pkg/secureexec"
+	"github.com/jesseduffield/lazygit/pkg/test"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -171,3 +177,136 @@ func TestCreatePullRequest(t *testing.T) {
 		})
 	}
 }
+
+// TestHostingClient is a function.
+func TestHostingClient(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/repos/peter/calculator/pulls" || r.Header.Get("Authorization") != "token secret" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		fmt.Fprint(w, "[]")
+	}))
+	defer server.Close()
+
+	type scenario struct {
+		testName        string
+		remoteUrl       string
+		hostingServices map[string]config.HostingServiceConfig
+		env             map[string]string
+		test            func(hosting.Client, error)
+	}
+
+	scenarios := []scenario{
+		{
+			testName:  "Takes the token from the config",
+			remoteUrl: "git@github.com:peter/calculator.git",
+			hostingServices: map[string]config.HostingServiceConfig{
+				"github.com": {Token: "secret", APIURL: server.URL},
+			},
+			test: func(client hosting.Client, err error) {
+				assert.NoError(t, err)
+				_, err = client.PullRequests()
+				assert.NoError(t, err)
+			},
+		},
+		{
+			testName:  "Takes the token from the environment",
+			remoteUrl: "git@github.com:peter/calculator.git",
+			hostingServices: map[string]config.HostingServiceConfig{
+				"github.com": {APIURL: server.URL},
+			},
+			env: map[string]string{"GITHUB_TOKEN": "secret"},
+			test: func(client hosting.Client, err error) {
+				assert.NoError(t, err)
+				_, err = client.PullRequests()
+				assert.NoError(t, err)
+			},
+		},
+		{
+			testName:  "Throws an error if there is no token",
+			remoteUrl: "git@gitlab.com:peter/calculator.git",
+			env:       map[string]string{"GITHUB_TOKEN": "secret"},
+			test: func(client hosting.Client, err error) {
+				assert.EqualError(t, err, "No API token for gitlab.com. Set one under hostingServices in your config or in $GITLAB_TOKEN")
+			},
+		},
+		{
+			testName:  "Throws an error if git service is unsupported",
+			remoteUrl: "git@something.com:peter/calculator.git",
+			test: func(client hosting.Client, err error) {
+				assert.Error(t, err)
+			},
+		},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.testName, func(t *testing.T) {
+			gitCommand := NewDummyGitCommand()
+			gitCommand.Config.GetUserConfig().HostingServices = s.hostingServices
+			gitCommand.OSCommand.Getenv = func(name string) string {
+				return s.env[name]
+			}
+			gitCommand.getGitConfigValue = func(path string) (string, error) {
+				return s.remoteUrl, nil
+			}
+			s.test(NewPullRequest(gitCommand).HostingClient())
+		})
+	}
+}
+
+// TestGetPullRequestContent is a function.
+func TestGetPullRequestContent(t *testing.T) {
+	type scenario struct {
+		testName      string
+		branchName    string
+		command       func(string, ...string) *exec.Cmd
+		expectedTitle string
+		expectedBody  string
+	}
+
+	scenarios := []scenario{
+		{
+			"single commit",
+			"feature",
+			test.CreateMockCommand(t, []*test.CommandSwapper{
+				{Expect: "git rev-list --reverse origin/master..feature", Replace: "echo aaa"},
+				{Expect: "git rev-list --format=%B --max-count=1 aaa", Replace: `printf "commit aaa\\nAdd feature\\n\\nIt does things\\n"`},
+			}),
+			"Add feature",
+			"It does things",
+		},
+		{
+			"several commits",
+			"feature/add-login_page",
+			test.CreateMockCommand(t, []*test.CommandSwapper{
+				{Expect: "git rev-list --reverse origin/master..feature/add-login_page", Replace: `printf "aaa\\nbbb\\n"`},
+				{Expect: "git rev-list --format=%B --max-count=1 aaa", Replace: `printf "commit aaa\\nAdd form\\n\\nWith fields\\n"`},
+				{Expect: "git rev-list --format=%B --max-count=1 bbb", Replace: `printf "commit bbb\\nAdd route\\n"`},
+			}),
+			"Add login page",
+			"- Add form\n- Add route",
+		},
+		{
+			"no commits",
+			"feature",
+			test.CreateMockCommand(t, []*test.CommandSwapper{
+				{Expect: "git rev-list --reverse origin/master..feature", Replace: "echo"},
+			}),
+			"Feature",
+			"",
+		},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.testName, func(t *testing.T) {
+			gitCmd := NewDummyGitCommand()
+			gitCmd.OSCommand.Command = s.command
+
+			title, body, err := gitCmd.GetPullRequestContent("origin/master", s.branchName)
+			assert.NoError(t, err)
+			assert.EqualValues(t, s.expectedTitle, title)
+			assert.EqualValues(t, s.expectedBody, body)
+		})
+	}
+}
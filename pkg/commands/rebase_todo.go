@@ -0,0 +1,222 @@
+package commands
+
+import (
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+
+	"github.com/go-errors/errors"
+	"github.com/jesseduffield/lazygit/pkg/commands/models"
+)
+
+// git lets you abbreviate the actions in a todo, and will do so itself if you
+// have rebase.abbreviateCommands set
+var todoActionAbbreviations = map[string]string{
+	"p": "pick",
+	"r": "reword",
+	"e": "edit",
+	"s": "squash",
+	"f": "fixup",
+	"x": "exec",
+	"b": "break",
+	"d": "drop",
+	"l": "label",
+	"t": "reset",
+	"m": "merge",
+	"u": "update-ref",
+}
+
+// these are the actions which are followed by a commit. The rest (exec, break,
+// label, reset, merge etc) we keep the arguments of as they are
+var commitTodoActions = map[string]bool{
+	"pick":   true,
+	"reword": true,
+	"edit":   true,
+	"squash": true,
+	"fixup":  true,
+	"drop":   true,
+}
+
+// ParseRebaseTodo parses the content of a git-rebase-todo file. Like the commits
+// panel, the result has the most recent commit first, which is the reverse of
+// the order in the file
+func ParseRebaseTodo(content string) []*models.Commit {
+	todos := []*models.Commit{}
+	for _, line := range strings.Split(content, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || line == "noop" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		todos = append([]*models.Commit{parseTodoLine(line)}, todos...)
+	}
+
+	return todos
+}
+
+func parseTodoLine(line string) *models.Commit {
+	action, rest := splitTodoWord(line)
+	if fullAction, ok := todoActionAbbreviations[action]; ok {
+		action = fullAction
+	}
+
+	if !commitTodoActions[action] {
+		return &models.Commit{
+			Name:   rest,
+			Status: "rebasing",
+			Action: action,
+		}
+	}
+
+	// fixup can be given -C or -c to take the message of the fixup commit
+	if action == "fixup" && strings.HasPrefix(rest, "-") {
+		var flag string
+		flag, rest = splitTodoWord(rest)
+		action += " " + flag
+	}
+
+	sha, name := splitTodoWord(rest)
+
+	return &models.Commit{
+		Sha:    sha,
+		Name:   name,
+		Status: "rebasing",
+		Action: action,
+	}
+}
+
+func splitTodoWord(str string) (string, string) {
+	split := strings.SplitN(str, " ", 2)
+	if len(split) == 1 {
+		return split[0], ""
+	}
+
+	return split[0], split[1]
+}
+
+// FormatRebaseTodo returns the content of a git-rebase-todo file for the given
+// todos, which are expected to have the most recent commit first
+func FormatRebaseTodo(todos []*models.Commit) string {
+	var builder strings.Builder
+	for i := len(todos) - 1; i >= 0; i-- {
+		todo := todos[i]
+
+		words := []string{todo.Action}
+		if todo.Sha != "" {
+			words = append(words, todo.Sha)
+		}
+		if todo.Name != "" {
+			words = append(words, todo.Name)
+		}
+
+		builder.WriteString(strings.Join(words, " ") + "\n")
+	}
+
+	return builder.String()
+}
+
+// SetTodoAction changes what we do with the commit at the given index. Lines
+// like 'exec' and 'break' have no commit so can't be given an action
+func SetTodoAction(todos []*models.Commit, index int, action string) error {
+	if index < 0 || index >= len(todos) {
+		return errors.New("index outside of range of todos")
+	}
+
+	if todos[index].Sha == "" {
+		return fmt.Errorf("cannot %s a '%s' line", action, todos[index].Action)
+	}
+
+	todos[index].Action = action
+
+	return nil
+}
+
+// MoveTodoDown swaps the todo at the given index with the one after it, meaning
+// it'll be done one step earlier
+func MoveTodoDown(todos []*models.Commit, index int) error {
+	if index < 0 || index+1 >= len(todos) {
+		return errors.New("index outside of range of todos")
+	}
+
+	todos[index], todos[index+1] = todos[index+1], todos[index]
+
+	return nil
+}
+
+// InsertTodo adds the todo at the given index, meaning it will be done right
+// after whatever was at that index
+func InsertTodo(todos []*models.Commit, index int, todo *models.Commit) ([]*models.Commit, error) {
+	if index < 0 || index > len(todos) {
+		return nil, errors.New("index outside of range of todos")
+	}
+
+	result := make([]*models.Commit, 0, len(todos)+1)
+	result = append(result, todos[:index]...)
+	result = append(result, todo)
+	result = append(result, todos[index:]...)
+
+	return result, nil
+}
+
+// RemoveTodo removes a line which doesn't refer to a commit, like 'exec' or
+// 'break'. Commits can't be removed: they should be dropped instead
+func RemoveTodo(todos []*models.Commit, index int) ([]*models.Commit, error) {
+	if index < 0 || index >= len(todos) {
+		return nil, errors.New("index outside of range of todos")
+	}
+
+	if todos[index].Sha != "" {
+		return nil, errors.New("cannot remove a commit from the todo, drop it instead")
+	}
+
+	result := make([]*models.Commit, 0, len(todos)-1)
+	result = append(result, todos[:index]...)
+	result = append(result, todos[index+1:]...)
+
+	return result, nil
+}
+
+// NewExecTodo returns a todo which runs the given shell command
+func NewExecTodo(command string) *models.Commit {
+	return &models.Commit{Name: command, Status: "rebasing", Action: "exec"}
+}
+
+// NewBreakTodo returns a todo which pauses the rebase
+func NewBreakTodo() *models.Commit {
+	return &models.Commit{Status: "rebasing", Action: "break"}
+}
+
+// EditRebaseTodos reads the todo of the interactive rebase in progress, passes it
+// to edit, and has git write back whatever edit returns. We go through
+// `git rebase --edit-todo` rather than writing the file ourselves so that git
+// checks the new todo, and so that the edit is a command we can log
+func (c *GitCommand) EditRebaseTodos(edit func(todos []*models.Commit) ([]*models.Commit, error)) error {
+	bytes, err := ioutil.ReadFile(filepath.Join(c.DotGitDir, "rebase-merge/git-rebase-todo"))
+	if err != nil {
+		return err
+	}
+
+	todos, err := edit(ParseRebaseTodo(string(bytes)))
+	if err != nil {
+		return err
+	}
+
+	cmd := c.OSCommand.ExecutableFromString("git rebase --edit-todo")
+	cmd.Env = append(cmd.Env, c.rebaseDemonEnv(FormatRebaseTodo(todos))...)
+	cmd.Env = append(cmd.Env, "GIT_SEQUENCE_EDITOR="+c.OSCommand.GetLazygitPath())
+
+	return c.OSCommand.RunExecutable(cmd)
+}
+
+// RebaseWithTodo runs an interactive rebase onto the given sha, doing whatever
+// the todos say. Use '--root' as the sha to rebase all the way down to the
+// first commit
+func (c *GitCommand) RebaseWithTodo(baseSha string, todos []*models.Commit) error {
+	cmd, err := c.PrepareInteractiveRebaseCommand(baseSha, FormatRebaseTodo(todos), true)
+	if err != nil {
+		return err
+	}
+
+	return c.OSCommand.RunPreparedCommand(cmd)
+}
@@ -0,0 +1,133 @@
+package commands
+
+import (
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"github.com/jesseduffield/lazygit/pkg/commands/models"
+	"github.com/jesseduffield/lazygit/pkg/secureexec"
+	"github.com/jesseduffield/lazygit/pkg/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestParseRebaseTodo is a function.
+func TestParseRebaseTodo(t *testing.T) {
+	type scenario struct {
+		testName string
+		content  string
+		expected []*models.Commit
+	}
+
+	scenarios := []scenario{
+		{
+			"nothing to do",
+			"noop\n\n# Rebase 1234..5678 onto 1234\n",
+			[]*models.Commit{},
+		},
+		{
+			"commits alongside exec and break lines",
+			"pick 1234 first\nexec make test\nf 5678 second\nbreak\n\n# Commands:\n# p, pick <commit> = use commit\n",
+			[]*models.Commit{
+				{Status: "rebasing", Action: "break"},
+				{Sha: "5678", Name: "second", Status: "rebasing", Action: "fixup"},
+				{Name: "make test", Status: "rebasing", Action: "exec"},
+				{Sha: "1234", Name: "first", Status: "rebasing", Action: "pick"},
+			},
+		},
+		{
+			"rebasing merges",
+			"label onto\nreset onto\npick 1234 first\nlabel feature\nreset onto\nmerge -C 5678 feature # Merge branch 'feature'\nfixup -C 9abc amend! first\n",
+			[]*models.Commit{
+				{Sha: "9abc", Name: "amend! first", Status: "rebasing", Action: "fixup -C"},
+				{Name: "-C 5678 feature # Merge branch 'feature'", Status: "rebasing", Action: "merge"},
+				{Name: "onto", Status: "rebasing", Action: "reset"},
+				{Name: "feature", Status: "rebasing", Action: "label"},
+				{Sha: "1234", Name: "first", Status: "rebasing", Action: "pick"},
+				{Name: "onto", Status: "rebasing", Action: "reset"},
+				{Name: "onto", Status: "rebasing", Action: "label"},
+			},
+		},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.testName, func(t *testing.T) {
+			assert.EqualValues(t, s.expected, ParseRebaseTodo(s.content))
+		})
+	}
+}
+
+// TestFormatRebaseTodo is a function.
+func TestFormatRebaseTodo(t *testing.T) {
+	content := "pick 1234 first\nexec make test\nfixup -C 5678 amend! first\nbreak\nmerge -C 9abc feature # Merge branch 'feature'\n"
+
+	assert.EqualValues(t, content, FormatRebaseTodo(ParseRebaseTodo(content)))
+}
+
+// TestEditingTodos is a function.
+func TestEditingTodos(t *testing.T) {
+	todos := ParseRebaseTodo("pick 1234 first\npick 5678 second\n")
+
+	todos, err := InsertTodo(todos, 1, NewExecTodo("make test"))
+	assert.NoError(t, err)
+	todos, err = InsertTodo(todos, 0, NewBreakTodo())
+	assert.NoError(t, err)
+	assert.EqualValues(t, "pick 1234 first\nexec make test\npick 5678 second\nbreak\n", FormatRebaseTodo(todos))
+
+	assert.NoError(t, SetTodoAction(todos, 1, "squash"))
+	assert.Error(t, SetTodoAction(todos, 2, "squash"))
+
+	assert.NoError(t, MoveTodoDown(todos, 1))
+	assert.Error(t, MoveTodoDown(todos, 3))
+	assert.EqualValues(t, "pick 1234 first\nsquash 5678 second\nexec make test\nbreak\n", FormatRebaseTodo(todos))
+
+	_, err = RemoveTodo(todos, 2)
+	assert.Error(t, err)
+	todos, err = RemoveTodo(todos, 1)
+	assert.NoError(t, err)
+	todos, err = RemoveTodo(todos, 0)
+	assert.NoError(t, err)
+	assert.EqualValues(t, "pick 1234 first\nsquash 5678 second\n", FormatRebaseTodo(todos))
+}
+
+// TestGitCommandEditRebaseTodos is a function.
+func TestGitCommandEditRebaseTodos(t *testing.T) {
+	dotGitDir, err := ioutil.TempDir("", "lazygit-todo")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dotGitDir)
+
+	if err := os.MkdirAll(filepath.Join(dotGitDir, "rebase-merge"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	todo := "pick 1234567 first\npick 89abcde second\n"
+	if err := ioutil.WriteFile(filepath.Join(dotGitDir, "rebase-merge/git-rebase-todo"), []byte(todo), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	gitCmd := NewDummyGitCommand()
+	gitCmd.DotGitDir = dotGitDir
+	var env []string
+	gitCmd.OSCommand.Command = func(cmd string, args ...string) *exec.Cmd {
+		assert.EqualValues(t, "git", cmd)
+		assert.EqualValues(t, []string{"rebase", "--edit-todo"}, args)
+
+		return secureexec.Command("echo")
+	}
+	var logged []string
+	gitCmd.OSCommand.SetBeforeExecuteCmd(func(cmd *exec.Cmd) {
+		logged = cmd.Args
+		env = cmd.Env
+	})
+
+	assert.NoError(t, gitCmd.EditRebaseTodos(func(todos []*models.Commit) ([]*models.Commit, error) {
+		return todos, MoveTodoDown(todos, 0)
+	}))
+
+	assert.EqualValues(t, []string{"echo"}, logged)
+	assert.True(t, utils.IncludesString(env, "LAZYGIT_REBASE_TODO=pick 89abcde second\npick 1234567 first\n"))
+	assert.True(t, utils.IncludesString(env, "LAZYGIT_CLIENT_COMMAND=INTERACTIVE_REBASE"))
+}
@@ -2,10 +2,8 @@ package commands
 
 import (
 	"fmt"
-	"io/ioutil"
 	"os"
 	"os/exec"
-	"path/filepath"
 	"strings"
 
 	"github.com/go-errors/errors"
@@ -44,7 +42,13 @@ func (c *GitCommand) MoveCommitDown(commits []*models.Commit, index int) error {
 }
 
 func (c *GitCommand) InteractiveRebase(commits []*models.Commit, index int, action string) error {
-	todo, sha, err := c.GenerateGenericRebaseTodo(commits, index, action)
+	return c.InteractiveRebaseRange(commits, index, index, action)
+}
+
+// InteractiveRebaseRange applies the action to each commit from startIndex to
+// endIndex inclusive, in a single rebase
+func (c *GitCommand) InteractiveRebaseRange(commits []*models.Commit, startIndex int, endIndex int, action string) error {
+	todo, sha, err := c.GenerateRangeRebaseTodo(commits, startIndex, endIndex, action)
 	if err != nil {
 		return err
 	}
@@ -63,11 +67,6 @@ func (c *GitCommand) InteractiveRebase(commits []*models.Commit, index int, acti
 func (c *GitCommand) PrepareInteractiveRebaseCommand(baseSha string, todo string, overrideEditor bool) (*exec.Cmd, error) {
 	ex := c.OSCommand.GetLazygitPath()
 
-	debug := "FALSE"
-	if c.OSCommand.Config.GetDebug() {
-		debug = "TRUE"
-	}
-
 	cmdStr := fmt.Sprintf("git rebase --interactive --autostash --keep-empty %s", baseSha)
 	c.Log.WithField("command", cmdStr).Info("RunCommand")
 	splitCmd := str.ToArgv(cmdStr)
@@ -80,15 +79,8 @@ func (c *GitCommand) PrepareInteractiveRebaseCommand(baseSha string, todo string
 	}
 
 	cmd.Env = os.Environ()
-	cmd.Env = append(
-		cmd.Env,
-		"LAZYGIT_CLIENT_COMMAND=INTERACTIVE_REBASE",
-		"LAZYGIT_REBASE_TODO="+todo,
-		"DEBUG="+debug,
-		"LANG=en_US.UTF-8",   // Force using EN as language
-		"LC_ALL=en_US.UTF-8", // Force using EN as language
-		"GIT_SEQUENCE_EDITOR="+gitSequenceEditor,
-	)
+	cmd.Env = append(cmd.Env, c.rebaseDemonEnv(todo)...)
+	cmd.Env = append(cmd.Env, "GIT_SEQUENCE_EDITOR="+gitSequenceEditor)
 
 	if overrideEditor {
 		cmd.Env = append(cmd.Env, "GIT_EDITOR="+ex)
@@ -97,8 +89,33 @@ func (c *GitCommand) PrepareInteractiveRebaseCommand(baseSha string, todo string
 	return cmd, nil
 }
 
+// rebaseDemonEnv is the environment for a git command which will call lazygit
+// as its editor, so that lazygit writes the given todo into git's todo file
+func (c *GitCommand) rebaseDemonEnv(todo string) []string {
+	debug := "FALSE"
+	if c.OSCommand.Config.GetDebug() {
+		debug = "TRUE"
+	}
+
+	return []string{
+		"LAZYGIT_CLIENT_COMMAND=INTERACTIVE_REBASE",
+		"LAZYGIT_REBASE_TODO=" + todo,
+		"DEBUG=" + debug,
+		"LANG=en_US.UTF-8",   // Force using EN as language
+		"LC_ALL=en_US.UTF-8", // Force using EN as language
+	}
+}
+
 func (c *GitCommand) GenerateGenericRebaseTodo(commits []*models.Commit, actionIndex int, action string) (string, string, error) {
-	baseIndex := actionIndex + 1
+	return c.GenerateRangeRebaseTodo(commits, actionIndex, actionIndex, action)
+}
+
+// GenerateRangeRebaseTodo returns the todo for a rebase which applies the action
+// to the commits from startIndex to endIndex inclusive, along with the sha to
+// rebase onto. When squashing or fixing up, the whole range gets folded into the
+// commit below it
+func (c *GitCommand) GenerateRangeRebaseTodo(commits []*models.Commit, startIndex int, endIndex int, action string) (string, string, error) {
+	baseIndex := endIndex + 1
 
 	if len(commits) <= baseIndex {
 		return "", "", errors.New(c.Tr.CannotRebaseOntoFirstCommit)
@@ -115,7 +132,7 @@ func (c *GitCommand) GenerateGenericRebaseTodo(commits []*models.Commit, actionI
 	todo := ""
 	for i, commit := range commits[0:baseIndex] {
 		var commitAction string
-		if i == actionIndex {
+		if i >= startIndex && i <= endIndex {
 			commitAction = action
 		} else if commit.IsMerge {
 			// your typical interactive rebase will actually drop merge commits by default. Damn git CLI, you scary!
@@ -142,53 +159,16 @@ func (c *GitCommand) AmendTo(sha string) error {
 
 // EditRebaseTodo sets the action at a given index in the git-rebase-todo file
 func (c *GitCommand) EditRebaseTodo(index int, action string) error {
-	fileName := filepath.Join(c.DotGitDir, "rebase-merge/git-rebase-todo")
-	bytes, err := ioutil.ReadFile(fileName)
-	if err != nil {
-		return err
-	}
-
-	content := strings.Split(string(bytes), "\n")
-	commitCount := c.getTodoCommitCount(content)
-
-	// we have the most recent commit at the bottom whereas the todo file has
-	// it at the bottom, so we need to subtract our index from the commit count
-	contentIndex := commitCount - 1 - index
-	splitLine := strings.Split(content[contentIndex], " ")
-	content[contentIndex] = action + " " + strings.Join(splitLine[1:], " ")
-	result := strings.Join(content, "\n")
-
-	return ioutil.WriteFile(fileName, []byte(result), 0644)
-}
-
-func (c *GitCommand) getTodoCommitCount(content []string) int {
-	// count lines that are not blank and are not comments
-	commitCount := 0
-	for _, line := range content {
-		if line != "" && !strings.HasPrefix(line, "#") {
-			commitCount++
-		}
-	}
-	return commitCount
+	return c.EditRebaseTodos(func(todos []*models.Commit) ([]*models.Commit, error) {
+		return todos, SetTodoAction(todos, index, action)
+	})
 }
 
 // MoveTodoDown moves a rebase todo item down by one position
 func (c *GitCommand) MoveTodoDown(index int) error {
-	fileName := filepath.Join(c.DotGitDir, "rebase-merge/git-rebase-todo")
-	bytes, err := ioutil.ReadFile(fileName)
-	if err != nil {
-		return err
-	}
-
-	content := strings.Split(string(bytes), "\n")
-	commitCount := c.getTodoCommitCount(content)
-	contentIndex := commitCount - 1 - index
-
-	rearrangedContent := append(content[0:contentIndex-1], content[contentIndex], content[contentIndex-1])
-	rearrangedContent = append(rearrangedContent, content[contentIndex+1:]...)
-	result := strings.Join(rearrangedContent, "\n")
-
-	return ioutil.WriteFile(fileName, []byte(result), 0644)
+	return c.EditRebaseTodos(func(todos []*models.Commit) ([]*models.Commit, error) {
+		return todos, MoveTodoDown(todos, index)
+	})
 }
 
 // SquashAllAboveFixupCommits squashes all fixup! commits above the given one
@@ -232,9 +212,33 @@ func (c *GitCommand) BeginInteractiveRebaseForCommit(commits []*models.Commit, c
 	return nil
 }
 
+// RebaseBranchOptions tweaks how we rebase the checked out branch
+type RebaseBranchOptions struct {
+	// when set, only the commits after this one get rebased, as in
+	// 'git rebase --onto <target> <upstream>'
+	Upstream string
+	// keep the merge commits being rebased rather than flattening them
+	RebaseMerges bool
+}
+
 // RebaseBranch interactive rebases onto a branch
 func (c *GitCommand) RebaseBranch(branchName string) error {
-	cmd, err := c.PrepareInteractiveRebaseCommand(branchName, "", false)
+	return c.RebaseBranchWithOptions(branchName, RebaseBranchOptions{})
+}
+
+// RebaseBranchWithOptions rebases the checked out branch onto the target, which
+// can be any ref
+func (c *GitCommand) RebaseBranchWithOptions(target string, opts RebaseBranchOptions) error {
+	// whatever we pass as the base goes straight on the end of the rebase command
+	args := target
+	if opts.Upstream != "" {
+		args = fmt.Sprintf("--onto %s %s", target, opts.Upstream)
+	}
+	if opts.RebaseMerges {
+		args = "--rebase-merges " + args
+	}
+
+	cmd, err := c.PrepareInteractiveRebaseCommand(args, "", false)
 	if err != nil {
 		return err
 	}
@@ -262,13 +266,13 @@ func (c *GitCommand) GenericMergeOrRebaseAction(commandType string, command stri
 	// sometimes we need to do a sequence of things in a rebase but the user needs to
 	// fix merge conflicts along the way. When this happens we queue up the next step
 	// so that after the next successful rebase continue we can continue from where we left off
-	if commandType == "rebase" && command == "continue" && c.onSuccessfulContinue != nil {
-		f := c.onSuccessfulContinue
-		c.onSuccessfulContinue = nil
+	if commandType == "rebase" && command == "continue" && *c.onSuccessfulContinue != nil {
+		f := *c.onSuccessfulContinue
+		*c.onSuccessfulContinue = nil
 		return f()
 	}
 	if command == "abort" {
-		c.onSuccessfulContinue = nil
+		*c.onSuccessfulContinue = nil
 	}
 	return nil
 }
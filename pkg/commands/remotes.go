@@ -27,10 +27,11 @@ func (c *GitCommand) DeleteRemoteBranch(remoteName string, branchName string, pr
 	return c.OSCommand.DetectUnamePass(command, promptUserForCredential)
 }
 
-// CheckRemoteBranchExists Returns remote branch
-func (c *GitCommand) CheckRemoteBranchExists(branch *models.Branch) bool {
+// CheckRemoteBranchExists returns whether the branch is on the given remote
+func (c *GitCommand) CheckRemoteBranchExists(remoteName string, branch *models.Branch) bool {
 	_, err := c.OSCommand.RunCommandWithOutput(
-		"git show-ref --verify -- refs/remotes/origin/%s",
+		"git show-ref --verify -- refs/remotes/%s/%s",
+		remoteName,
 		branch.Name,
 	)
 
@@ -41,3 +42,16 @@ func (c *GitCommand) CheckRemoteBranchExists(branch *models.Branch) bool {
 func (c *GitCommand) GetRemoteURL() string {
 	return c.GetConfigValue("remote.origin.url")
 }
+
+// TrackedRemoteName returns the remote the checked out branch tracks, falling
+// back to origin
+func (c *GitCommand) TrackedRemoteName() string {
+	branchName, _, err := c.CurrentBranchName()
+	if err == nil {
+		if remoteName := c.GetConfigValue("branch." + branchName + ".remote"); remoteName != "" && remoteName != "." {
+			return remoteName
+		}
+	}
+
+	return "origin"
+}
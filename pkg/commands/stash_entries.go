@@ -1,6 +1,13 @@
 package commands
 
-import "fmt"
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/go-errors/errors"
+	"github.com/jesseduffield/lazygit/pkg/utils"
+)
 
 // StashDo modify stash
 func (c *GitCommand) StashDo(index int, method string) error {
@@ -13,46 +20,212 @@ func (c *GitCommand) StashSave(message string) error {
 	return c.RunCommand("git stash save %s", c.OSCommand.Quote(message))
 }
 
+type StashPushOpts struct {
+	Message string
+	// if any paths are given, only changes to those paths are stashed
+	Paths            []string
+	IncludeUntracked bool
+	KeepIndex        bool
+}
+
+// StashPush stashes changes via `git stash push`, which unlike `git stash save`
+// can be limited to some paths
+func (c *GitCommand) StashPush(opts StashPushOpts) error {
+	cmdStr := "git stash push"
+	if opts.IncludeUntracked {
+		cmdStr += " --include-untracked"
+	}
+	if opts.KeepIndex {
+		cmdStr += " --keep-index"
+	}
+	if opts.Message != "" {
+		cmdStr += " -m " + c.OSCommand.Quote(opts.Message)
+	}
+	if len(opts.Paths) > 0 {
+		quotedPaths := make([]string, len(opts.Paths))
+		for i, path := range opts.Paths {
+			quotedPaths[i] = c.OSCommand.Quote(path)
+		}
+		cmdStr += " -- " + strings.Join(quotedPaths, " ")
+	}
+
+	return c.OSCommand.RunCommand(cmdStr)
+}
+
+// StashRename gives a stash entry a new message. Git has no command for this,
+// so we store the entries up to and including this one again (with the new
+// message) and then drop the originals, keeping the entries in order. Storing
+// before dropping means that if something goes wrong we end up with duplicates
+// rather than losing anything
+func (c *GitCommand) StashRename(index int, message string) error {
+	cmdStr := `git stash list --pretty="%H|%gs"`
+	output, err := c.RunCommandWithOutput(cmdStr)
+	if err != nil {
+		return err
+	}
+	lines := utils.SplitLines(output)
+	if index >= len(lines) {
+		return errors.New("stash entry not found")
+	}
+
+	for i := index; i >= 0; i-- {
+		split := strings.SplitN(lines[i], "|", 2)
+		if len(split) != 2 {
+			return errors.New("unexpected stash list output: " + lines[i])
+		}
+		sha, entryMessage := split[0], split[1]
+		if i == index {
+			entryMessage = message
+		}
+		if err := c.RunCommand("git stash store -m %s %s", c.OSCommand.Quote(entryMessage), sha); err != nil {
+			return err
+		}
+	}
+
+	for i := 0; i <= index; i++ {
+		if err := c.StashDo(index+1, "drop"); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
 // GetStashEntryDiff stash diff
 func (c *GitCommand) ShowStashEntryCmdStr(index int) string {
 	return fmt.Sprintf("git stash show -p --stat --color=%s stash@{%d}", c.colorArg(), index)
 }
 
-// StashSaveStagedChanges stashes only the currently staged changes. This takes a few steps
-// shoutouts to Joe on https://stackoverflow.com/questions/14759748/stashing-only-staged-changes-in-git-is-it-possible
+// StashSaveStagedChanges stashes only the currently staged changes, leaving
+// any unstaged changes where they are. `git stash push --staged` does this but
+// is too recent for us to rely on, so we do the same thing via StashPatch
 func (c *GitCommand) StashSaveStagedChanges(message string) error {
+	patch, err := c.RunCommandWithOutput("git diff --cached --binary --no-ext-diff --no-color")
+	if err != nil {
+		return err
+	}
+	reversePatch, err := c.RunCommandWithOutput("git diff --cached --binary --no-ext-diff --no-color -R")
+	if err != nil {
+		return err
+	}
 
-	if err := c.RunCommand("git stash --keep-index"); err != nil {
+	return c.StashPatch(patch, reversePatch, message, true)
+}
+
+// StashPatch stashes the changes in the given patch, then removes them by
+// applying the reverse patch to the working tree, and to the index too if
+// cached is true. The patch must apply to the index, or to HEAD if cached is
+// true, as a diff of unstaged or staged changes would. The reverse patch is passed separately given
+// that when the patch only has some of a file's changes, its reverse won't
+// apply to the working tree. This is what `git stash push --patch` does, minus
+// the interactivity: we build the stash's index and working tree commits in a
+// temporary index file, and `git stash store` the result
+func (c *GitCommand) StashPatch(patch string, reversePatch string, message string, cached bool) error {
+	patchPath, err := c.saveTemporaryPatch(patch)
+	if err != nil {
+		return err
+	}
+	reversePatchPath, err := c.saveTemporaryPatch(reversePatch)
+	if err != nil {
 		return err
 	}
+	quotedReversePatchPath := c.OSCommand.Quote(reversePatchPath)
 
-	if err := c.StashSave(message); err != nil {
+	// like git, we refuse to stash the changes if we can't then remove them,
+	// e.g. because there are unstaged changes on top of the staged ones. Unlike
+	// git, we find that out before stashing anything
+	if err := c.RunCommand("git apply --check %s", quotedReversePatchPath); err != nil {
 		return err
 	}
+	if cached {
+		if err := c.RunCommand("git apply --check --cached %s", quotedReversePatchPath); err != nil {
+			return err
+		}
+	}
+
+	indexPath := patchPath + ".index"
+	defer os.Remove(indexPath)
+	runWithIndex := func(cmdStr string) (string, error) {
+		cmd := c.OSCommand.ExecutableFromString(cmdStr)
+		cmd.Env = append(cmd.Env, "GIT_INDEX_FILE="+indexPath)
+		output, err := c.OSCommand.RunExecutableWithOutput(cmd)
+		return strings.TrimSpace(output), err
+	}
 
-	if err := c.RunCommand("git stash apply stash@{1}"); err != nil {
+	// unstaged changes are on top of the index, which is what the stash's
+	// index commit holds too, as with `git stash push --patch`
+	baseTree := "HEAD"
+	if !cached {
+		indexTree, err := c.RunCommandWithOutput("git write-tree")
+		if err != nil {
+			return err
+		}
+		baseTree = strings.TrimSpace(indexTree)
+	}
+	if _, err := runWithIndex("git read-tree " + baseTree); err != nil {
+		return err
+	}
+	baseTree, err = runWithIndex("git write-tree")
+	if err != nil {
+		return err
+	}
+	if _, err := runWithIndex("git apply --cached " + c.OSCommand.Quote(patchPath)); err != nil {
+		return err
+	}
+	tree, err := runWithIndex("git write-tree")
+	if err != nil {
 		return err
 	}
 
-	if err := c.OSCommand.PipeCommands("git stash show -p", "git apply -R"); err != nil {
+	branchName, _, err := c.CurrentBranchName()
+	if err != nil {
+		return err
+	}
+	cmdStr := `git log -1 --format="%h %s"`
+	headSummary, err := c.RunCommandWithOutput(cmdStr)
+	if err != nil {
 		return err
 	}
+	headSummary = strings.TrimSpace(headSummary)
+
+	// these are the messages git itself would use
+	stashMessage := fmt.Sprintf("WIP on %s: %s", branchName, headSummary)
+	if message != "" {
+		stashMessage = fmt.Sprintf("On %s: %s", branchName, message)
+	}
 
-	if err := c.RunCommand("git stash drop stash@{1}"); err != nil {
+	indexTree := baseTree
+	if cached {
+		indexTree = tree
+	}
+	indexCommit, err := c.commitTree(indexTree, fmt.Sprintf("index on %s: %s", branchName, headSummary), "HEAD")
+	if err != nil {
+		return err
+	}
+	stashCommit, err := c.commitTree(tree, stashMessage, "HEAD", indexCommit)
+	if err != nil {
+		return err
+	}
+	if err := c.RunCommand("git stash store -m %s %s", c.OSCommand.Quote(stashMessage), stashCommit); err != nil {
 		return err
 	}
 
-	// if you had staged an untracked file, that will now appear as 'AD' in git status
-	// meaning it's deleted in your working tree but added in your index. Given that it's
-	// now safely stashed, we need to remove it.
-	files := c.GetStatusFiles(GetStatusFileOptions{})
-	for _, file := range files {
-		if file.ShortStatus == "AD" {
-			if err := c.UnStageFile(file.Names(), false); err != nil {
-				return err
-			}
+	if cached {
+		if err := c.RunCommand("git apply --cached %s", quotedReversePatchPath); err != nil {
+			return err
 		}
 	}
 
-	return nil
+	return c.RunCommand("git apply %s", quotedReversePatchPath)
+}
+
+func (c *GitCommand) commitTree(tree string, message string, parents ...string) (string, error) {
+	cmdStr := "git commit-tree " + tree
+	for _, parent := range parents {
+		cmdStr += " -p " + parent
+	}
+	cmdStr += " -m " + c.OSCommand.Quote(message)
+
+	output, err := c.OSCommand.RunCommandWithOutput(cmdStr)
+	return strings.TrimSpace(output), err
 }
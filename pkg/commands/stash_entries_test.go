@@ -0,0 +1,133 @@
+package commands
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os/exec"
+	"strings"
+	"testing"
+
+	"github.com/jesseduffield/lazygit/pkg/secureexec"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestGitCommandStashPatch is a function.
+func TestGitCommandStashPatch(t *testing.T) {
+	type scenario struct {
+		testName         string
+		cached           bool
+		message          string
+		failingCommand   string
+		expectedCommands []string
+		test             func(error)
+	}
+
+	scenarios := []scenario{
+		{
+			"unstaged changes are stashed on top of the index",
+			false,
+			"a message",
+			"",
+			[]string{
+				"git apply --check <reverse patch>",
+				"git write-tree",
+				"git read-tree tree1",
+				"git write-tree",
+				"git apply --cached <patch>",
+				"git write-tree",
+				"git symbolic-ref --short HEAD",
+				"git log -1 --format=%h %s",
+				"git commit-tree tree2 -p HEAD -m index on master: abc1234 initial",
+				"git commit-tree tree3 -p HEAD -p commit1 -m On master: a message",
+				"git stash store -m On master: a message commit2",
+				"git apply <reverse patch>",
+			},
+			func(err error) {
+				assert.NoError(t, err)
+			},
+		},
+		{
+			"staged changes are stashed on top of HEAD and removed from the index too",
+			true,
+			"",
+			"",
+			[]string{
+				"git apply --check <reverse patch>",
+				"git apply --check --cached <reverse patch>",
+				"git read-tree HEAD",
+				"git write-tree",
+				"git apply --cached <patch>",
+				"git write-tree",
+				"git symbolic-ref --short HEAD",
+				"git log -1 --format=%h %s",
+				"git commit-tree tree2 -p HEAD -m index on master: abc1234 initial",
+				"git commit-tree tree2 -p HEAD -p commit1 -m WIP on master: abc1234 initial",
+				"git stash store -m WIP on master: abc1234 initial commit2",
+				"git apply --cached <reverse patch>",
+				"git apply <reverse patch>",
+			},
+			func(err error) {
+				assert.NoError(t, err)
+			},
+		},
+		{
+			"nothing is stashed if the changes can't then be removed",
+			false,
+			"a message",
+			"git apply --check <reverse patch>",
+			[]string{
+				"git apply --check <reverse patch>",
+			},
+			func(err error) {
+				assert.Error(t, err)
+			},
+		},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.testName, func(t *testing.T) {
+			gitCmd := NewDummyGitCommand()
+
+			// the patches are saved to files with timestamped names, which we
+			// swap out for what's in them
+			patchPlaceholders := map[string]string{
+				"some patch":         "<patch>",
+				"some reverse patch": "<reverse patch>",
+			}
+			counts := map[string]int{}
+			commands := []string{}
+			gitCmd.OSCommand.Command = func(cmd string, args ...string) *exec.Cmd {
+				for i, arg := range args {
+					if strings.HasSuffix(arg, ".patch") {
+						content, err := ioutil.ReadFile(arg)
+						assert.NoError(t, err)
+						args[i] = patchPlaceholders[string(content)]
+					}
+				}
+				command := strings.Join(append([]string{cmd}, args...), " ")
+				commands = append(commands, command)
+
+				if command == s.failingCommand {
+					return secureexec.Command("exit", "1")
+				}
+
+				switch {
+				case command == "git write-tree":
+					counts["tree"]++
+					return secureexec.Command("echo", fmt.Sprintf("tree%d", counts["tree"]))
+				case strings.HasPrefix(command, "git commit-tree"):
+					counts["commit"]++
+					return secureexec.Command("echo", fmt.Sprintf("commit%d", counts["commit"]))
+				case command == "git symbolic-ref --short HEAD":
+					return secureexec.Command("echo", "master")
+				case strings.HasPrefix(command, "git log"):
+					return secureexec.Command("echo", "abc1234 initial")
+				}
+				return secureexec.Command("echo")
+			}
+
+			s.test(gitCmd.StashPatch("some patch", "some reverse patch", s.message, s.cached))
+			assert.EqualValues(t, s.expectedCommands, commands)
+		})
+	}
+}
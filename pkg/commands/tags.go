@@ -1,11 +1,31 @@
 package commands
 
-import "fmt"
+import (
+	"fmt"
+	"os/exec"
+)
 
 func (c *GitCommand) CreateLightweightTag(tagName string, commitSha string) error {
 	return c.RunCommand("git tag %s %s", tagName, commitSha)
 }
 
+// CreateAnnotatedTagCmd returns the command for creating an annotated tag, which
+// opens the user's editor for them to write the tag's message. Signed tags are
+// annotated tags with a GPG signature
+func (c *GitCommand) CreateAnnotatedTagCmd(tagName string, commitSha string, signed bool) *exec.Cmd {
+	flag := "--annotate"
+	if signed {
+		flag = "--sign"
+	}
+
+	args := []string{"tag", flag, tagName}
+	if commitSha != "" {
+		args = append(args, commitSha)
+	}
+
+	return c.OSCommand.PrepareSubProcess("git", args...)
+}
+
 func (c *GitCommand) DeleteTag(tagName string) error {
 	return c.RunCommand("git tag -d %s", tagName)
 }
@@ -14,3 +34,9 @@ func (c *GitCommand) PushTag(remoteName string, tagName string, promptUserForCre
 	command := fmt.Sprintf("git push %s %s", remoteName, tagName)
 	return c.OSCommand.DetectUnamePass(command, promptUserForCredential)
 }
+
+// ShowTagCmdStr shows the tag object itself, i.e. the tagger and message,
+// followed by the commit it points to
+func (c *GitCommand) ShowTagCmdStr(tagName string) string {
+	return fmt.Sprintf("git show --color=%s --stat refs/tags/%s", c.colorArg(), tagName)
+}
@@ -0,0 +1,43 @@
+package commands
+
+import "fmt"
+
+type NewWorktreeOptions struct {
+	Path string
+	// Base is the branch or commit to check out in the new worktree. If blank, HEAD is used
+	Base string
+	// Branch is the name of a new branch to create at Base. If blank, Base itself is checked out
+	Branch string
+	// Detach checks out Base with a detached HEAD, which is needed when Base is a commit
+	// or a branch that is already checked out elsewhere
+	Detach bool
+}
+
+func (c *GitCommand) NewWorktree(opts NewWorktreeOptions) error {
+	command := "git worktree add"
+	if opts.Branch != "" {
+		command = fmt.Sprintf("%s -b %s", command, opts.Branch)
+	} else if opts.Detach {
+		command = fmt.Sprintf("%s --detach", command)
+	}
+
+	command = fmt.Sprintf("%s %s", command, c.OSCommand.Quote(opts.Path))
+	if opts.Base != "" {
+		command = fmt.Sprintf("%s %s", command, opts.Base)
+	}
+
+	return c.RunCommand(command)
+}
+
+func (c *GitCommand) RemoveWorktree(path string, force bool) error {
+	forceArg := ""
+	if force {
+		forceArg = "--force "
+	}
+
+	return c.RunCommand("git worktree remove %s%s", forceArg, c.OSCommand.Quote(path))
+}
+
+func (c *GitCommand) PruneWorktrees() error {
+	return c.RunCommand("git worktree prune")
+}
@@ -5,6 +5,7 @@ import (
 	"os"
 	"path/filepath"
 	"strings"
+	"time"
 
 	"github.com/OpenPeeDeeP/xdg"
 	yaml "github.com/jesseduffield/yaml"
@@ -21,8 +22,12 @@ type AppConfig struct {
 	UserConfig     *UserConfig
 	UserConfigDir  string
 	UserConfigPath string
-	AppState       *AppState
-	IsNewRepo      bool
+	// UserConfigFiles are the files the user config was loaded from, in the
+	// order they were applied: the global config.yml, then any repo-local
+	// .lazygit.yml files from the outermost directory inwards
+	UserConfigFiles []string
+	AppState        *AppState
+	IsNewRepo       bool
 }
 
 // AppConfigurer interface allows individual app config structs to inherit Fields
@@ -37,11 +42,15 @@ type AppConfigurer interface {
 	GetUserConfig() *UserConfig
 	GetUserConfigDir() string
 	GetUserConfigPath() string
+	GetUserConfigFiles() []string
+	GetUserConfigModTimes() map[string]time.Time
 	GetAppState() *AppState
 	SaveAppState() error
 	SetIsNewRepo(bool)
 	GetIsNewRepo() bool
-	ReloadUserConfig() error
+	ReloadUserConfig(validators ...Validator) error
+	ValidateUserConfig(validators ...Validator) error
+	CheckUserConfig(validators ...Validator) error
 }
 
 // NewAppConfig makes a new app config
@@ -51,7 +60,12 @@ func NewAppConfig(name, version, commit, date string, buildSource string, debugg
 		return nil, err
 	}
 
-	userConfig, err := loadUserConfigWithDefaults(configDir)
+	configFiles, err := userConfigFiles(configDir)
+	if err != nil {
+		return nil, err
+	}
+
+	userConfig, err := loadUserConfigWithDefaults(configFiles)
 	if err != nil {
 		return nil, err
 	}
@@ -66,17 +80,18 @@ func NewAppConfig(name, version, commit, date string, buildSource string, debugg
 	}
 
 	appConfig := &AppConfig{
-		Name:           "lazygit",
-		Version:        version,
-		Commit:         commit,
-		BuildDate:      date,
-		Debug:          debuggingFlag,
-		BuildSource:    buildSource,
-		UserConfig:     userConfig,
-		UserConfigDir:  configDir,
-		UserConfigPath: filepath.Join(configDir, "config.yml"),
-		AppState:       appState,
-		IsNewRepo:      false,
+		Name:            "lazygit",
+		Version:         version,
+		Commit:          commit,
+		BuildDate:       date,
+		Debug:           debuggingFlag,
+		BuildSource:     buildSource,
+		UserConfig:      userConfig,
+		UserConfigDir:   configDir,
+		UserConfigPath:  filepath.Join(configDir, "config.yml"),
+		UserConfigFiles: configFiles,
+		AppState:        appState,
+		IsNewRepo:       false,
 	}
 
 	return appConfig, nil
@@ -110,35 +125,136 @@ func findOrCreateConfigDir() (string, error) {
 	return folder, nil
 }
 
-func loadUserConfigWithDefaults(configDir string) (*UserConfig, error) {
-	return loadUserConfig(configDir, GetDefaultConfig())
+// userConfigFiles returns the files to load the user config from, in the order
+// they're to be applied. Besides the global config.yml, a repo can have its own
+// .lazygit.yml overriding it, and so can any of the directories above it (say,
+// for a monorepo, or for all the repos of some organisation), so long as the
+// global config trusts them
+func userConfigFiles(configDir string) ([]string, error) {
+	globalConfigFile, err := findOrCreateUserConfigFile(configDir)
+	if err != nil {
+		return nil, err
+	}
+	if globalConfigFile == "" {
+		return []string{}, nil
+	}
+
+	// if the global config can't be loaded we'll find out when we load it for
+	// real, and until then we don't trust anything
+	globalConfig, err := loadUserConfigWithDefaults([]string{globalConfigFile})
+	if err != nil {
+		return []string{globalConfigFile}, nil
+	}
+
+	return append([]string{globalConfigFile}, trustedRepoConfigFiles(globalConfig.TrustedConfigDirs)...), nil
 }
 
-func loadUserConfig(configDir string, base *UserConfig) (*UserConfig, error) {
+// findOrCreateUserConfigFile returns the global config.yml's path, creating it
+// if need be, or an empty string if there's no file and we can't create one
+func findOrCreateUserConfigFile(configDir string) (string, error) {
 	fileName := filepath.Join(configDir, "config.yml")
 
 	if _, err := os.Stat(fileName); err != nil {
-		if os.IsNotExist(err) {
-			file, err := os.Create(fileName)
+		if !os.IsNotExist(err) {
+			return "", err
+		}
+
+		file, err := os.Create(fileName)
+		if err != nil {
+			if strings.Contains(err.Error(), "read-only file system") {
+				return "", nil
+			}
+			return "", err
+		}
+		file.Close()
+	}
+
+	return fileName, nil
+}
+
+// trustedRepoConfigFiles returns those of the repo config files which are in
+// one of the given trusted directories. Anyone who can write to a directory
+// above the repo, or who made the repo, can put a .lazygit.yml there, and the
+// config can have us run commands e.g. as a pager or a custom command
+func trustedRepoConfigFiles(trustedDirs []string) []string {
+	cleanTrustedDirs := []string{}
+	for _, dir := range trustedDirs {
+		if dir == "~" || strings.HasPrefix(dir, "~/") {
+			home, err := os.UserHomeDir()
 			if err != nil {
-				if strings.Contains(err.Error(), "read-only file system") {
-					return base, nil
-				}
-				return nil, err
+				continue
+			}
+			dir = filepath.Join(home, dir[1:])
+		}
+
+		// a relative path would be relative to whichever repo we're in, which
+		// would trust them all
+		if !filepath.IsAbs(dir) {
+			continue
+		}
+		cleanTrustedDirs = append(cleanTrustedDirs, filepath.Clean(dir))
+	}
+
+	configFiles := []string{}
+	for _, fileName := range repoConfigFiles() {
+		for _, trustedDir := range cleanTrustedDirs {
+			if isInDir(filepath.Dir(fileName), trustedDir) {
+				configFiles = append(configFiles, fileName)
+				break
 			}
-			file.Close()
-		} else {
-			return nil, err
 		}
 	}
 
-	content, err := ioutil.ReadFile(fileName)
+	return configFiles
+}
+
+// isInDir tells whether path is dir or somewhere inside it
+func isInDir(path string, dir string) bool {
+	rel, err := filepath.Rel(dir, path)
+	return err == nil && rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator))
+}
+
+// repoConfigFiles returns any .lazygit.yml files in the current directory and
+// its parents, outermost first so that the closest one takes precedence
+func repoConfigFiles() []string {
+	dir, err := os.Getwd()
 	if err != nil {
-		return nil, err
+		return nil
 	}
 
-	if err := yaml.Unmarshal(content, base); err != nil {
-		return nil, err
+	configFiles := []string{}
+	for {
+		fileName := filepath.Join(dir, ".lazygit.yml")
+		if info, err := os.Stat(fileName); err == nil && !info.IsDir() {
+			configFiles = append([]string{fileName}, configFiles...)
+		}
+
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return configFiles
+		}
+		dir = parent
+	}
+}
+
+func loadUserConfigWithDefaults(configFiles []string) (*UserConfig, error) {
+	return loadUserConfig(configFiles, GetDefaultConfig())
+}
+
+// loadUserConfig applies each config file over the base config in turn. Given
+// yaml only sets the fields that are present, each file only overrides what it
+// mentions, down to individual keys of nested structs. Lists like
+// customCommands are replaced wholesale
+func loadUserConfig(configFiles []string, base *UserConfig) (*UserConfig, error) {
+	for _, fileName := range configFiles {
+		content, err := ioutil.ReadFile(fileName)
+		if err != nil {
+			return nil, err
+		}
+
+		if err := yaml.Unmarshal(content, base); err != nil {
+			return nil, yamlErrors(fileName, err)
+		}
 	}
 
 	return base, nil
@@ -195,6 +311,29 @@ func (c *AppConfig) GetUserConfigPath() string {
 	return c.UserConfigPath
 }
 
+// GetUserConfigFiles returns the files the user config was loaded from
+func (c *AppConfig) GetUserConfigFiles() []string {
+	return c.UserConfigFiles
+}
+
+// GetUserConfigModTimes returns the modification times of the files the user
+// config would be loaded from right now, keyed by path, so that we can tell when
+// the config has changed, including when a repo-local config file comes or goes.
+// This is called every second so we only stat the files: we don't create the
+// global file or load it to see which repo config files are trusted
+func (c *AppConfig) GetUserConfigModTimes() map[string]time.Time {
+	modTimes := map[string]time.Time{}
+
+	configFiles := append([]string{filepath.Join(c.UserConfigDir, "config.yml")}, repoConfigFiles()...)
+	for _, fileName := range configFiles {
+		if info, err := os.Stat(fileName); err == nil {
+			modTimes[fileName] = info.ModTime()
+		}
+	}
+
+	return modTimes
+}
+
 // GetAppState returns the app state
 func (c *AppConfig) GetAppState() *AppState {
 	return c.AppState
@@ -204,16 +343,41 @@ func (c *AppConfig) GetUserConfigDir() string {
 	return c.UserConfigDir
 }
 
-func (c *AppConfig) ReloadUserConfig() error {
-	userConfig, err := loadUserConfigWithDefaults(c.UserConfigDir)
+// ReloadUserConfig loads the user config again, picking up any repo-local
+// config files for the current directory. If the new config has values we
+// can't use we keep the one we have
+func (c *AppConfig) ReloadUserConfig(validators ...Validator) error {
+	configFiles, err := userConfigFiles(c.UserConfigDir)
+	if err != nil {
+		return err
+	}
+
+	userConfig, err := loadUserConfigWithDefaults(configFiles)
 	if err != nil {
 		return err
 	}
 
+	if err := CheckUserConfig(configFiles, userConfig, validators...); err != nil {
+		return err
+	}
+
 	c.UserConfig = userConfig
+	c.UserConfigFiles = configFiles
 	return nil
 }
 
+// ValidateUserConfig checks the user config we've loaded for problems, using
+// the given validators for the values only other packages understand
+func (c *AppConfig) ValidateUserConfig(validators ...Validator) error {
+	return ValidateUserConfig(c.UserConfigFiles, c.UserConfig, validators...)
+}
+
+// CheckUserConfig checks the user config we've loaded for values we can't use,
+// using the given validators
+func (c *AppConfig) CheckUserConfig(validators ...Validator) error {
+	return CheckUserConfig(c.UserConfigFiles, c.UserConfig, validators...)
+}
+
 func configFilePath(filename string) (string, error) {
 	folder, err := findOrCreateConfigDir()
 	if err != nil {
@@ -0,0 +1,184 @@
+package config
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestLoadUserConfig is a function.
+func TestLoadUserConfig(t *testing.T) {
+	dir, err := ioutil.TempDir("", "lazygit-config")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	writeFile := func(name string, content string) string {
+		fileName := filepath.Join(dir, name)
+		if err := ioutil.WriteFile(fileName, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+		return fileName
+	}
+
+	global := writeFile("config.yml", `
+git:
+  pull:
+    mode: rebase
+  skipHookPrefix: NOHOOK
+keybinding:
+  universal:
+    quit: Q
+`)
+	outer := writeFile("outer.yml", `
+git:
+  pull:
+    mode: ff-only
+`)
+	inner := writeFile("inner.yml", `
+gui:
+  showFileTree: true
+keybinding:
+  universal:
+    quit: x
+`)
+	broken := writeFile("broken.yml", "git: [")
+	customCommands := writeFile("custom_commands.yml", `
+customCommands:
+- key: 'a'
+  context: 'files'
+  command: echo a
+- context: 'files'
+  command: echo b
+  output: popup
+`)
+
+	type scenario struct {
+		testName    string
+		configFiles []string
+		test        func(*UserConfig, error)
+	}
+
+	scenarios := []scenario{
+		{
+			"no files leaves the defaults",
+			[]string{},
+			func(userConfig *UserConfig, err error) {
+				assert.NoError(t, err)
+				assert.EqualValues(t, GetDefaultConfig(), userConfig)
+			},
+		},
+		{
+			"later files override only the keys they set",
+			[]string{global, outer, inner},
+			func(userConfig *UserConfig, err error) {
+				assert.NoError(t, err)
+				assert.EqualValues(t, "ff-only", userConfig.Git.Pull.Mode)
+				assert.EqualValues(t, "NOHOOK", userConfig.Git.SkipHookPrefix)
+				assert.EqualValues(t, "x", userConfig.Keybinding.Universal.Quit)
+				assert.EqualValues(t, GetDefaultConfig().Keybinding.Universal.Return, userConfig.Keybinding.Universal.Return)
+				assert.True(t, userConfig.Gui.ShowFileTree)
+			},
+		},
+		{
+			"custom commands get their defaults",
+			[]string{customCommands},
+			func(userConfig *UserConfig, err error) {
+				assert.NoError(t, err)
+				assert.Len(t, userConfig.CustomCommands, 2)
+				assert.EqualValues(t, "none", userConfig.CustomCommands[0].Output)
+				assert.EqualValues(t, "popup", userConfig.CustomCommands[1].Output)
+			},
+		},
+		{
+			"an invalid file is reported by name",
+			[]string{global, broken},
+			func(userConfig *UserConfig, err error) {
+				assert.Error(t, err)
+				assert.Contains(t, err.Error(), broken)
+			},
+		},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.testName, func(t *testing.T) {
+			s.test(loadUserConfigWithDefaults(s.configFiles))
+		})
+	}
+}
+
+// TestTrustedRepoConfigFiles is a function.
+func TestTrustedRepoConfigFiles(t *testing.T) {
+	dir, err := ioutil.TempDir("", "lazygit-repos")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	// tempdirs can be behind a symlink, e.g. on macOS, which getwd resolves
+	dir, err = filepath.EvalSymlinks(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	repo := filepath.Join(dir, "work", "repo")
+	if err := os.MkdirAll(repo, 0755); err != nil {
+		t.Fatal(err)
+	}
+	outerFile := filepath.Join(dir, ".lazygit.yml")
+	workFile := filepath.Join(dir, "work", ".lazygit.yml")
+	repoFile := filepath.Join(repo, ".lazygit.yml")
+	for _, fileName := range []string{outerFile, workFile, repoFile} {
+		if err := ioutil.WriteFile(fileName, []byte("gui:\n  showFileTree: true\n"), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	wd, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = os.Chdir(wd) }()
+	if err := os.Chdir(repo); err != nil {
+		t.Fatal(err)
+	}
+
+	type scenario struct {
+		testName    string
+		trustedDirs []string
+		expected    []string
+	}
+
+	scenarios := []scenario{
+		{
+			"nothing is trusted by default",
+			nil,
+			[]string{},
+		},
+		{
+			"a trusted directory covers the directories inside it",
+			[]string{filepath.Join(dir, "work")},
+			[]string{workFile, repoFile},
+		},
+		{
+			"a directory with a similar name isn't trusted",
+			[]string{filepath.Join(dir, "wor"), filepath.Join(dir, "work", "repo2")},
+			[]string{},
+		},
+		{
+			"relative paths are ignored",
+			[]string{"."},
+			[]string{},
+		},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.testName, func(t *testing.T) {
+			assert.EqualValues(t, s.expected, trustedRepoConfigFiles(s.trustedDirs))
+		})
+	}
+}
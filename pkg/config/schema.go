@@ -0,0 +1,72 @@
+package config
+
+import (
+	"encoding/json"
+	"reflect"
+)
+
+// JSONSchema describes the user config as a JSON schema, which editors can use
+// to check and autocomplete config files. It's generated from the UserConfig
+// struct: field names come from yaml tags, allowed values from enum tags, and
+// defaults from the default config
+func JSONSchema() ([]byte, error) {
+	schema := typeSchema(reflect.TypeOf(UserConfig{}), reflect.ValueOf(*GetDefaultConfig()))
+	schema["$schema"] = "http://json-schema.org/draft-07/schema#"
+	schema["title"] = "lazygit user config"
+
+	return json.MarshalIndent(schema, "", "  ")
+}
+
+// typeSchema returns the schema of the given type. defaultValue is the type's
+// value in the default config, if it has one there
+func typeSchema(t reflect.Type, defaultValue reflect.Value) map[string]interface{} {
+	schema := map[string]interface{}{}
+
+	switch t.Kind() {
+	case reflect.Struct:
+		properties := map[string]interface{}{}
+		for i := 0; i < t.NumField(); i++ {
+			field := t.Field(i)
+			if field.PkgPath != "" || field.Tag.Get("yaml") == "-" {
+				continue
+			}
+
+			fieldDefault := reflect.Value{}
+			if defaultValue.IsValid() {
+				fieldDefault = defaultValue.Field(i)
+			}
+			fieldSchema := typeSchema(field.Type, fieldDefault)
+			if enum := enumValues(field); enum != nil {
+				fieldSchema["enum"] = enum
+			}
+
+			properties[yamlFieldName(field)] = fieldSchema
+		}
+		schema["type"] = "object"
+		schema["properties"] = properties
+		schema["additionalProperties"] = false
+		return schema
+	case reflect.Map:
+		schema["type"] = "object"
+		schema["additionalProperties"] = typeSchema(t.Elem(), reflect.Value{})
+		return schema
+	case reflect.Slice:
+		schema["type"] = "array"
+		schema["items"] = typeSchema(t.Elem(), reflect.Value{})
+	case reflect.String:
+		schema["type"] = "string"
+	case reflect.Bool:
+		schema["type"] = "boolean"
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		schema["type"] = "integer"
+	case reflect.Float32, reflect.Float64:
+		schema["type"] = "number"
+	}
+
+	if defaultValue.IsValid() && !(defaultValue.Kind() == reflect.Slice && defaultValue.IsNil()) {
+		schema["default"] = defaultValue.Interface()
+	}
+
+	return schema
+}
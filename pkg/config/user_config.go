@@ -15,7 +15,24 @@ type UserConfig struct {
 	DisableStartupPopups bool              `yaml:"disableStartupPopups"`
 	CustomCommands       []CustomCommand   `yaml:"customCommands"`
 	Services             map[string]string `yaml:"services"`
-	NotARepository       string            `yaml:"notARepository"`
+	// HostingServices is keyed by the same domains as Services
+	HostingServices map[string]HostingServiceConfig `yaml:"hostingServices"`
+	NotARepository  string                          `yaml:"notARepository" enum:"prompt,create,skip"`
+	// TrustedConfigDirs are the directories whose .lazygit.yml files we load,
+	// along with those of the directories inside them. Only the global config
+	// gets a say in this, given a repo's config can run commands
+	TrustedConfigDirs []string `yaml:"trustedConfigDirs"`
+}
+
+// HostingServiceConfig tells us how to talk to the API of a hosting service
+type HostingServiceConfig struct {
+	// Token authenticates us with the API. If blank we look for one in the
+	// environment e.g. $GITHUB_TOKEN
+	Token string `yaml:"token"`
+	// Username is only needed when authenticating with a Bitbucket app password
+	Username string `yaml:"username"`
+	// APIURL is where to send requests, defaulting to the service's usual API URL
+	APIURL string `yaml:"apiURL"`
 }
 
 type RefresherConfig struct {
@@ -31,11 +48,13 @@ type GuiConfig struct {
 	SkipStashWarning         bool               `yaml:"skipStashWarning"`
 	SidePanelWidth           float64            `yaml:"sidePanelWidth"`
 	ExpandFocusedSidePanel   bool               `yaml:"expandFocusedSidePanel"`
-	MainPanelSplitMode       string             `yaml:"mainPanelSplitMode"`
+	MainPanelSplitMode       string             `yaml:"mainPanelSplitMode" enum:"horizontal,flexible,vertical"`
 	Theme                    ThemeConfig        `yaml:"theme"`
 	CommitLength             CommitLengthConfig `yaml:"commitLength"`
 	SkipNoStagedFilesWarning bool               `yaml:"skipNoStagedFilesWarning"`
 	ShowFileTree             bool               `yaml:"showFileTree"`
+	ShowCommitGraph          bool               `yaml:"showCommitGraph"`
+	ShowCommandLog           bool               `yaml:"showCommandLog"`
 }
 
 type ThemeConfig struct {
@@ -73,10 +92,13 @@ type PagingConfig struct {
 type MergingConfig struct {
 	ManualCommit bool   `yaml:"manualCommit"`
 	Args         string `yaml:"args"`
+	// Tool is passed to git mergetool as --tool. Blank means whatever merge.tool
+	// is set to in git's config
+	Tool string `yaml:"tool"`
 }
 
 type PullConfig struct {
-	Mode string `yaml:"mode"`
+	Mode string `yaml:"mode" enum:"merge,rebase,ff-only"`
 }
 
 type CommitPrefixConfig struct {
@@ -85,7 +107,7 @@ type CommitPrefixConfig struct {
 }
 
 type UpdateConfig struct {
-	Method string `yaml:"method"`
+	Method string `yaml:"method" enum:"prompt,background,never"`
 	Days   int64  `yaml:"days"`
 }
 
@@ -99,6 +121,7 @@ type KeybindingConfig struct {
 	CommitFiles KeybindingCommitFilesConfig `yaml:"commitFiles"`
 	Main        KeybindingMainConfig        `yaml:"main"`
 	Submodules  KeybindingSubmodulesConfig  `yaml:"submodules"`
+	Worktrees   KeybindingWorktreesConfig   `yaml:"worktrees"`
 }
 
 type KeybindingUniversalConfig struct {
@@ -122,6 +145,7 @@ type KeybindingUniversalConfig struct {
 	NextMatch                    string `yaml:"nextMatch"`
 	PrevMatch                    string `yaml:"prevMatch"`
 	StartSearch                  string `yaml:"startSearch"`
+	StartFilter                  string `yaml:"startFilter"`
 	OptionMenu                   string `yaml:"optionMenu"`
 	OptionMenuAlt1               string `yaml:"optionMenu-alt1"`
 	Select                       string `yaml:"select"`
@@ -156,6 +180,9 @@ type KeybindingUniversalConfig struct {
 	CopyToClipboard              string `yaml:"copyToClipboard"`
 	SubmitEditorText             string `yaml:"submitEditorText"`
 	AppendNewline                string `yaml:"appendNewline"`
+	CommandLogMenu               string `yaml:"commandLogMenu"`
+	KeybindingsHelp              string `yaml:"keybindingsHelp"`
+	ToggleRangeSelect            string `yaml:"toggleRangeSelect"`
 }
 
 type KeybindingStatusConfig struct {
@@ -177,6 +204,9 @@ type KeybindingFilesConfig struct {
 	ViewResetOptions         string `yaml:"viewResetOptions"`
 	Fetch                    string `yaml:"fetch"`
 	ToggleTreeView           string `yaml:"toggleTreeView"`
+	ViewBlame                string `yaml:"viewBlame"`
+	OpenMergeTool            string `yaml:"openMergeTool"`
+	ViewMergeConflictOptions string `yaml:"viewMergeConflictOptions"`
 }
 
 type KeybindingBranchesConfig struct {
@@ -192,6 +222,7 @@ type KeybindingBranchesConfig struct {
 	PushTag                string `yaml:"pushTag"`
 	SetUpstream            string `yaml:"setUpstream"`
 	FetchRemote            string `yaml:"fetchRemote"`
+	CreateWorktree         string `yaml:"createWorktree"`
 }
 
 type KeybindingCommitsConfig struct {
@@ -214,21 +245,31 @@ type KeybindingCommitsConfig struct {
 	CheckoutCommit               string `yaml:"checkoutCommit"`
 	ResetCherryPick              string `yaml:"resetCherryPick"`
 	CopyCommitMessageToClipboard string `yaml:"copyCommitMessageToClipboard"`
+	CreateWorktree               string `yaml:"createWorktree"`
+	ViewBisectOptions            string `yaml:"viewBisectOptions"`
+	PlanInteractiveRebase        string `yaml:"planInteractiveRebase"`
+	InsertExecTodo               string `yaml:"insertExecTodo"`
+	InsertBreakTodo              string `yaml:"insertBreakTodo"`
+	MarkCommitAsBaseForRebase    string `yaml:"markCommitAsBaseForRebase"`
 }
 
 type KeybindingStashConfig struct {
-	PopStash string `yaml:"popStash"`
+	PopStash    string `yaml:"popStash"`
+	RenameStash string `yaml:"renameStash"`
 }
 
 type KeybindingCommitFilesConfig struct {
 	CheckoutCommitFile string `yaml:"checkoutCommitFile"`
+	ViewBlame          string `yaml:"viewBlame"`
 }
 
 type KeybindingMainConfig struct {
-	ToggleDragSelect    string `yaml:"toggleDragSelect"`
-	ToggleDragSelectAlt string `yaml:"toggleDragSelect-alt"`
-	ToggleSelectHunk    string `yaml:"toggleSelectHunk"`
-	PickBothHunks       string `yaml:"pickBothHunks"`
+	ToggleDragSelect     string `yaml:"toggleDragSelect"`
+	ToggleDragSelectAlt  string `yaml:"toggleDragSelect-alt"`
+	ToggleSelectHunk     string `yaml:"toggleSelectHunk"`
+	PickBothHunks        string `yaml:"pickBothHunks"`
+	BlamePreviousVersion string `yaml:"blamePreviousVersion"`
+	StashSelection       string `yaml:"stashSelection"`
 }
 
 type KeybindingSubmodulesConfig struct {
@@ -237,6 +278,10 @@ type KeybindingSubmodulesConfig struct {
 	BulkMenu string `yaml:"bulkMenu"`
 }
 
+type KeybindingWorktreesConfig struct {
+	Prune string `yaml:"prune"`
+}
+
 // OSConfig contains config on the level of the os
 type OSConfig struct {
 	// OpenCommand is the command for opening a file
@@ -247,6 +292,7 @@ type OSConfig struct {
 }
 
 type CustomCommand struct {
+	// without a key the command is only in the options menu of its context
 	Key         string                `yaml:"key"`
 	Context     string                `yaml:"context"`
 	Command     string                `yaml:"command"`
@@ -254,23 +300,52 @@ type CustomCommand struct {
 	Prompts     []CustomCommandPrompt `yaml:"prompts"`
 	LoadingText string                `yaml:"loadingText"`
 	Description string                `yaml:"description"`
+	// where to show the command's output, unless it's run as a subprocess
+	Output string `yaml:"output" enum:"none,popup,log,mainView"`
+	// a template which hides the command unless it comes out as 'true'
+	Condition string `yaml:"condition"`
+}
+
+// UnmarshalYAML gives custom commands their defaults, which we can't do in the
+// default config given it has no custom commands
+func (c *CustomCommand) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	// without the methods of CustomCommand, so that we don't recurse. It's named
+	// after it so that errors about unknown fields still make sense
+	type customCommand CustomCommand
+	result := customCommand{Output: "none"}
+	if err := unmarshal(&result); err != nil {
+		return err
+	}
+
+	*c = CustomCommand(result)
+	return nil
 }
 
 type CustomCommandPrompt struct {
-	Type  string `yaml:"type"` // one of 'input' and 'menu'
+	Type  string `yaml:"type" enum:"input,menu,menuFromCommand,confirm"`
 	Title string `yaml:"title"`
+	// the response is available to later templates as .Form.<key>
+	Key string `yaml:"key"`
 
 	// this only apply to prompts
 	InitialValue string `yaml:"initialValue"`
 
 	// this only applies to menus
 	Options []CustomCommandMenuOption
+
+	// these only apply to menuFromCommand
+	Command string `yaml:"command"`
+	Filter  string `yaml:"filter"`
+
+	// this only applies to confirms
+	Body string `yaml:"body"`
 }
 
 type CustomCommandMenuOption struct {
 	Name        string `yaml:"name"`
 	Description string `yaml:"description"`
 	Value       string `yaml:"value"`
+	Key         string `yaml:"key"`
 }
 
 func GetDefaultConfig() *UserConfig {
@@ -294,6 +369,8 @@ func GetDefaultConfig() *UserConfig {
 			},
 			CommitLength:             CommitLengthConfig{Show: true},
 			SkipNoStagedFilesWarning: false,
+			ShowCommitGraph:          true,
+			ShowCommandLog:           false,
 		},
 		Git: GitConfig{
 			Paging: PagingConfig{
@@ -303,6 +380,7 @@ func GetDefaultConfig() *UserConfig {
 			Merging: MergingConfig{
 				ManualCommit: false,
 				Args:         "",
+				Tool:         "",
 			},
 			Pull: PullConfig{
 				Mode: "merge",
@@ -348,6 +426,7 @@ func GetDefaultConfig() *UserConfig {
 				NextMatch:                    "n",
 				PrevMatch:                    "N",
 				StartSearch:                  "/",
+				StartFilter:                  "<c-f>",
 				OptionMenu:                   "x",
 				OptionMenuAlt1:               "?",
 				Select:                       "<space>",
@@ -382,6 +461,9 @@ func GetDefaultConfig() *UserConfig {
 				CopyToClipboard:              "<c-o>",
 				SubmitEditorText:             "<enter>",
 				AppendNewline:                "<a-enter>",
+				CommandLogMenu:               "@",
+				KeybindingsHelp:              "<f1>",
+				ToggleRangeSelect:            "V",
 			},
 			Status: KeybindingStatusConfig{
 				CheckForUpdate:      "u",
@@ -401,6 +483,9 @@ func GetDefaultConfig() *UserConfig {
 				ViewResetOptions:         "D",
 				Fetch:                    "f",
 				ToggleTreeView:           "`",
+				ViewBlame:                "B",
+				OpenMergeTool:            "M",
+				ViewMergeConflictOptions: "X",
 			},
 			Branches: KeybindingBranchesConfig{
 				CopyPullRequestURL:     "<c-y>",
@@ -415,6 +500,7 @@ func GetDefaultConfig() *UserConfig {
 				PushTag:                "P",
 				SetUpstream:            "u",
 				FetchRemote:            "f",
+				CreateWorktree:         "w",
 			},
 			Commits: KeybindingCommitsConfig{
 				SquashDown:                   "s",
@@ -436,29 +522,43 @@ func GetDefaultConfig() *UserConfig {
 				CheckoutCommit:               "<space>",
 				ResetCherryPick:              "<c-R>",
 				CopyCommitMessageToClipboard: "<c-y>",
+				CreateWorktree:               "w",
+				ViewBisectOptions:            "b",
+				PlanInteractiveRebase:        "i",
+				InsertExecTodo:               "X",
+				InsertBreakTodo:              "B",
+				MarkCommitAsBaseForRebase:    "O",
 			},
 			Stash: KeybindingStashConfig{
-				PopStash: "g",
+				PopStash:    "g",
+				RenameStash: "r",
 			},
 			CommitFiles: KeybindingCommitFilesConfig{
 				CheckoutCommitFile: "c",
+				ViewBlame:          "B",
 			},
 			Main: KeybindingMainConfig{
-				ToggleDragSelect:    "v",
-				ToggleDragSelectAlt: "V",
-				ToggleSelectHunk:    "a",
-				PickBothHunks:       "b",
+				ToggleDragSelect:     "v",
+				ToggleDragSelectAlt:  "V",
+				ToggleSelectHunk:     "a",
+				PickBothHunks:        "b",
+				BlamePreviousVersion: "b",
+				StashSelection:       "s",
 			},
 			Submodules: KeybindingSubmodulesConfig{
 				Init:     "i",
 				Update:   "u",
 				BulkMenu: "b",
 			},
+			Worktrees: KeybindingWorktreesConfig{
+				Prune: "p",
+			},
 		},
 		OS:                   GetPlatformDefaultConfig(),
 		DisableStartupPopups: false,
 		CustomCommands:       []CustomCommand(nil),
 		Services:             map[string]string(nil),
+		HostingServices:      map[string]HostingServiceConfig(nil),
 		NotARepository:       "prompt",
 	}
 }
@@ -0,0 +1,348 @@
+package config
+
+import (
+	"fmt"
+	"io/ioutil"
+	"reflect"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	yaml "github.com/jesseduffield/yaml"
+)
+
+// ConfigError is a problem with a user config file. Line is 0 when we can't
+// tell where in the file the problem is
+type ConfigError struct {
+	File    string
+	Line    int
+	Message string
+}
+
+func (e *ConfigError) Error() string {
+	if e.Line == 0 {
+		return fmt.Sprintf("%s: %s", e.File, e.Message)
+	}
+	return fmt.Sprintf("%s:%d: %s", e.File, e.Line, e.Message)
+}
+
+// ConfigErrors are all the problems we found with the user config
+type ConfigErrors []*ConfigError
+
+func (e ConfigErrors) Error() string {
+	messages := make([]string, len(e))
+	for i, err := range e {
+		messages[i] = err.Error()
+	}
+	return strings.Join(messages, "\n")
+}
+
+// InvalidValue is a value in the user config which doesn't make sense. Path is
+// where the value is, as yaml keys and list indices, e.g.
+// {"customCommands", 2, "context"}
+type InvalidValue struct {
+	Path    []interface{}
+	Message string
+}
+
+// Validator checks values which only some other package knows how to make
+// sense of, like keybindings
+type Validator func(*UserConfig) []InvalidValue
+
+// ValidateUserConfig checks the given config, loaded from the given files, for
+// keys we don't know about, values of the wrong type, and values which don't
+// make sense to us or to any of the given validators
+func ValidateUserConfig(configFiles []string, userConfig *UserConfig, validators ...Validator) error {
+	errors := ConfigErrors{}
+	contents, err := readConfigFiles(configFiles)
+	if err != nil {
+		return err
+	}
+
+	for i, fileName := range configFiles {
+		// a strict decode is what tells us about keys we don't know about
+		if err := yaml.UnmarshalStrict([]byte(contents[i]), GetDefaultConfig()); err != nil {
+			errors = append(errors, yamlErrors(fileName, err)...)
+		}
+	}
+
+	invalidValues := validateEnums(reflect.ValueOf(userConfig).Elem(), []interface{}{})
+	for _, validator := range validators {
+		invalidValues = append(invalidValues, validator(userConfig)...)
+	}
+
+	return configErrors(configFiles, contents, errors, invalidValues)
+}
+
+// CheckUserConfig only runs the given validators over the config, which look
+// for values we can't carry on with, like keys we can't bind. Unlike a key we
+// don't know about, which might be left over from an older version, these are
+// worth refusing the config over
+func CheckUserConfig(configFiles []string, userConfig *UserConfig, validators ...Validator) error {
+	contents, err := readConfigFiles(configFiles)
+	if err != nil {
+		return err
+	}
+
+	invalidValues := []InvalidValue{}
+	for _, validator := range validators {
+		invalidValues = append(invalidValues, validator(userConfig)...)
+	}
+
+	return configErrors(configFiles, contents, ConfigErrors{}, invalidValues)
+}
+
+func readConfigFiles(configFiles []string) ([]string, error) {
+	contents := make([]string, len(configFiles))
+	for i, fileName := range configFiles {
+		content, err := ioutil.ReadFile(fileName)
+		if err != nil {
+			return nil, err
+		}
+		contents[i] = string(content)
+	}
+
+	return contents, nil
+}
+
+// configErrors adds the invalid values to the given errors, located in the
+// files they came from, and returns them all in the order they appear in the
+// files, or nil if there are none
+func configErrors(configFiles []string, contents []string, errors ConfigErrors, invalidValues []InvalidValue) error {
+	for _, invalidValue := range invalidValues {
+		errors = append(errors, locateInvalidValue(configFiles, contents, invalidValue))
+	}
+
+	if len(errors) == 0 {
+		return nil
+	}
+
+	// we go through the files in the order they were applied
+	fileIndices := map[string]int{}
+	for i, fileName := range configFiles {
+		fileIndices[fileName] = i
+	}
+	sort.SliceStable(errors, func(i, j int) bool {
+		if errors[i].File != errors[j].File {
+			return fileIndices[errors[i].File] < fileIndices[errors[j].File]
+		}
+		return errors[i].Line < errors[j].Line
+	})
+
+	return errors
+}
+
+var yamlErrorRegex = regexp.MustCompile(`^(?:yaml: )?line (\d+): (.*)$`)
+
+// yamlErrors splits an error from the yaml package into one error per problem,
+// pulling out the line number from each
+func yamlErrors(fileName string, err error) ConfigErrors {
+	messages := []string{err.Error()}
+	if typeError, ok := err.(*yaml.TypeError); ok {
+		messages = typeError.Errors
+	}
+
+	errors := make(ConfigErrors, len(messages))
+	for i, message := range messages {
+		errors[i] = &ConfigError{File: fileName, Message: message}
+		if match := yamlErrorRegex.FindStringSubmatch(message); match != nil {
+			errors[i].Line, _ = strconv.Atoi(match[1])
+			errors[i].Message = match[2]
+		}
+	}
+
+	return errors
+}
+
+// validateEnums checks the values of fields with an enum tag, which lists the
+// values the field may have
+func validateEnums(value reflect.Value, path []interface{}) []InvalidValue {
+	invalidValues := []InvalidValue{}
+
+	switch value.Kind() {
+	case reflect.Struct:
+		for i := 0; i < value.NumField(); i++ {
+			field := value.Type().Field(i)
+			fieldPath := appendPath(path, yamlFieldName(field))
+
+			if enum := enumValues(field); enum != nil && !isEnumValue(enum, value.Field(i).String()) {
+				invalidValues = append(invalidValues, InvalidValue{
+					Path:    fieldPath,
+					Message: fmt.Sprintf("invalid value '%s', expected one of: %s", value.Field(i).String(), strings.Join(enum, ", ")),
+				})
+				continue
+			}
+
+			invalidValues = append(invalidValues, validateEnums(value.Field(i), fieldPath)...)
+		}
+	case reflect.Slice:
+		for i := 0; i < value.Len(); i++ {
+			invalidValues = append(invalidValues, validateEnums(value.Index(i), appendPath(path, i))...)
+		}
+	case reflect.Map:
+		for _, key := range value.MapKeys() {
+			invalidValues = append(invalidValues, validateEnums(value.MapIndex(key), appendPath(path, key.String()))...)
+		}
+	}
+
+	return invalidValues
+}
+
+func enumValues(field reflect.StructField) []string {
+	tag := field.Tag.Get("enum")
+	if tag == "" {
+		return nil
+	}
+	return strings.Split(tag, ",")
+}
+
+func isEnumValue(enum []string, value string) bool {
+	for _, enumValue := range enum {
+		if enumValue == value {
+			return true
+		}
+	}
+	return false
+}
+
+// appendPath appends to a path without sharing the underlying array with any
+// other path appended to the same prefix
+func appendPath(path []interface{}, segment interface{}) []interface{} {
+	return append(append([]interface{}{}, path...), segment)
+}
+
+// yamlFieldName is the key a struct field is given in yaml, which unless the
+// field says otherwise is its name in lowercase
+func yamlFieldName(field reflect.StructField) string {
+	if name := strings.Split(field.Tag.Get("yaml"), ",")[0]; name != "" {
+		return name
+	}
+	return strings.ToLower(field.Name)
+}
+
+// locateInvalidValue finds the file that set the invalid value, which is the
+// last one to mention it given later files override earlier ones
+func locateInvalidValue(configFiles []string, contents []string, invalidValue InvalidValue) *ConfigError {
+	path := make([]string, len(invalidValue.Path))
+	for i, segment := range invalidValue.Path {
+		path[i] = fmt.Sprint(segment)
+	}
+	message := fmt.Sprintf("%s: %s", strings.Join(path, "."), invalidValue.Message)
+
+	bestFile, bestLine, bestDepth := "", 0, 0
+	for i := len(configFiles) - 1; i >= 0; i-- {
+		line, depth := yamlLine(contents[i], invalidValue.Path)
+		if depth == len(invalidValue.Path) {
+			return &ConfigError{File: configFiles[i], Line: line, Message: message}
+		}
+		if depth > bestDepth {
+			bestFile, bestLine, bestDepth = configFiles[i], line, depth
+		}
+	}
+
+	if bestFile == "" && len(configFiles) > 0 {
+		bestFile = configFiles[len(configFiles)-1]
+	}
+
+	return &ConfigError{File: bestFile, Line: bestLine, Message: message}
+}
+
+// yamlEntry is a key or a list item in a yaml file
+type yamlEntry struct {
+	line       int
+	indent     int
+	key        string
+	isListItem bool
+}
+
+// isNestedIn tells whether the entry is part of the value of the given entry,
+// assuming it comes after it. A list can be at the same indentation as the key
+// it's the value of
+func (e yamlEntry) isNestedIn(parent yamlEntry) bool {
+	return e.indent > parent.indent || (e.indent == parent.indent && e.isListItem && !parent.isListItem)
+}
+
+var yamlKeyRegex = regexp.MustCompile(`^("[^"]*"|'[^']*'|[^\s"'#][^:#]*?)\s*:(\s|$)`)
+
+func yamlEntries(content string) []yamlEntry {
+	entries := []yamlEntry{}
+
+	for i, line := range strings.Split(content, "\n") {
+		rest := strings.TrimLeft(line, " ")
+		indent := len(line) - len(rest)
+		if rest == "" || strings.HasPrefix(rest, "#") {
+			continue
+		}
+
+		for rest == "-" || strings.HasPrefix(rest, "- ") {
+			entries = append(entries, yamlEntry{line: i + 1, indent: indent, isListItem: true})
+			afterDash := strings.TrimLeft(rest[1:], " ")
+			indent += len(rest) - len(afterDash)
+			rest = afterDash
+		}
+
+		if match := yamlKeyRegex.FindStringSubmatch(rest); match != nil {
+			key := match[1]
+			if unquoted, err := strconv.Unquote(key); err == nil {
+				key = unquoted
+			} else if strings.HasPrefix(key, "'") {
+				key = strings.Trim(key, "'")
+			}
+			entries = append(entries, yamlEntry{line: i + 1, indent: indent, key: key})
+		}
+	}
+
+	return entries
+}
+
+// yamlLine returns the line in the content of the given path, or of as much of
+// the path as we can find, along with how many segments of the path we found.
+// We only handle block style yaml, which is what people tend to write config in:
+// when a value is in flow style we just point at its key
+func yamlLine(content string, path []interface{}) (int, int) {
+	entries := yamlEntries(content)
+
+	line := 0
+	for depth, segment := range path {
+		// entries now holds the value of the previous segment, whose top level
+		// entries are at the indentation of the first one
+		if len(entries) == 0 {
+			return line, depth
+		}
+		indent := entries[0].indent
+
+		index := -1
+		listIndex := 0
+		for i, entry := range entries {
+			if entry.indent != indent {
+				continue
+			}
+			if key, ok := segment.(string); ok && !entry.isListItem && entry.key == key {
+				index = i
+				break
+			}
+			if n, ok := segment.(int); ok && entry.isListItem {
+				if listIndex == n {
+					index = i
+					break
+				}
+				listIndex++
+			}
+		}
+		if index == -1 {
+			return line, depth
+		}
+
+		parent := entries[index]
+		line = parent.line
+
+		end := index + 1
+		for end < len(entries) && entries[end].isNestedIn(parent) {
+			end++
+		}
+		entries = entries[index+1 : end]
+	}
+
+	return line, len(path)
+}
@@ -0,0 +1,183 @@
+package config
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestYamlLine is a function.
+func TestYamlLine(t *testing.T) {
+	content := `# a comment
+gui:
+  theme:
+    activeBorderColor: [red, bold]
+git:
+  pull:
+    mode: 'rebase'
+customCommands:
+- key: 'a'
+  command: echo a
+- key: 'b'
+
+  # another comment
+  context: 'files'
+  prompts:
+    - type: input
+      title: x
+services:
+  'github.mycompany.com': 'github:github.mycompany.com'
+`
+
+	type scenario struct {
+		testName      string
+		path          []interface{}
+		expectedLine  int
+		expectedDepth int
+	}
+
+	scenarios := []scenario{
+		{"nested key", []interface{}{"git", "pull", "mode"}, 7, 3},
+		{"list item", []interface{}{"customCommands", 1}, 11, 2},
+		{"key in list item", []interface{}{"customCommands", 1, "context"}, 14, 3},
+		{"indented list", []interface{}{"customCommands", 1, "prompts", 0, "title"}, 17, 5},
+		{"quoted key", []interface{}{"services", "github.mycompany.com"}, 19, 2},
+		{"flow style value", []interface{}{"gui", "theme", "activeBorderColor", 1}, 4, 3},
+		{"missing key", []interface{}{"customCommands", 0, "context"}, 9, 2},
+		{"missing top level key", []interface{}{"keybinding", "universal", "quit"}, 0, 0},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.testName, func(t *testing.T) {
+			line, depth := yamlLine(content, s.path)
+			assert.EqualValues(t, s.expectedLine, line)
+			assert.EqualValues(t, s.expectedDepth, depth)
+		})
+	}
+}
+
+// TestValidateUserConfig is a function.
+func TestValidateUserConfig(t *testing.T) {
+	dir, err := ioutil.TempDir("", "lazygit-config")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	writeFile := func(name string, content string) string {
+		fileName := filepath.Join(dir, name)
+		if err := ioutil.WriteFile(fileName, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+		return fileName
+	}
+
+	global := writeFile("config.yml", `gui:
+  scrollHeight: 2
+  showFileTre: true
+git:
+  pull:
+    mode: squash
+customCommands:
+- key: 'a'
+  context: 'files'
+`)
+	repo := writeFile("repo.yml", `customCommands:
+- key: 'b'
+  context: 'files'
+- key: 'c'
+  context: 'nowhere'
+`)
+	valid := writeFile("valid.yml", `git:
+  pull:
+    mode: rebase
+`)
+
+	// stands in for the validators the gui provides
+	validateContexts := func(userConfig *UserConfig) []InvalidValue {
+		invalidValues := []InvalidValue{}
+		for i, customCommand := range userConfig.CustomCommands {
+			if customCommand.Context != "files" {
+				invalidValues = append(invalidValues, InvalidValue{
+					Path:    []interface{}{"customCommands", i, "context"},
+					Message: "unknown context",
+				})
+			}
+		}
+		return invalidValues
+	}
+
+	type scenario struct {
+		testName    string
+		configFiles []string
+		expected    ConfigErrors
+	}
+
+	scenarios := []scenario{
+		{
+			"no problems",
+			[]string{valid},
+			nil,
+		},
+		{
+			"each problem is located in the file that caused it",
+			[]string{global, repo},
+			ConfigErrors{
+				{File: global, Line: 3, Message: "field showFileTre not found in type config.GuiConfig"},
+				{File: global, Line: 6, Message: "git.pull.mode: invalid value 'squash', expected one of: merge, rebase, ff-only"},
+				{File: repo, Line: 5, Message: "customCommands.1.context: unknown context"},
+			},
+		},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.testName, func(t *testing.T) {
+			userConfig, err := loadUserConfigWithDefaults(s.configFiles)
+			assert.NoError(t, err)
+
+			err = ValidateUserConfig(s.configFiles, userConfig, validateContexts)
+			if s.expected == nil {
+				assert.NoError(t, err)
+				return
+			}
+			assert.EqualValues(t, s.expected, err)
+		})
+	}
+
+	t.Run("checking only reports what the validators find", func(t *testing.T) {
+		configFiles := []string{global, repo}
+		userConfig, err := loadUserConfigWithDefaults(configFiles)
+		assert.NoError(t, err)
+
+		err = CheckUserConfig(configFiles, userConfig, validateContexts)
+		assert.EqualValues(t, ConfigErrors{
+			{File: repo, Line: 5, Message: "customCommands.1.context: unknown context"},
+		}, err)
+	})
+}
+
+// TestJSONSchema is a function.
+func TestJSONSchema(t *testing.T) {
+	output, err := JSONSchema()
+	assert.NoError(t, err)
+
+	schema := map[string]interface{}{}
+	assert.NoError(t, json.Unmarshal(output, &schema))
+
+	pull := schema["properties"].(map[string]interface{})["git"].(map[string]interface{})["properties"].(map[string]interface{})["pull"].(map[string]interface{})
+	assert.EqualValues(t, map[string]interface{}{
+		"type":                 "object",
+		"additionalProperties": false,
+		"properties": map[string]interface{}{
+			"mode": map[string]interface{}{
+				"type":    "string",
+				"default": "merge",
+				"enum":    []interface{}{"merge", "rebase", "ff-only"},
+			},
+		},
+	}, pull)
+}
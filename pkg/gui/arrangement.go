@@ -38,6 +38,28 @@ func (gui *Gui) mainSectionChildren() []*boxlayout.Box {
 	}
 }
 
+// mainSectionWithCmdLog puts the command log, if we're showing it, beneath the
+// main panels
+func (gui *Gui) mainSectionWithCmdLog(mainPanelsDirection boxlayout.Direction) []*boxlayout.Box {
+	result := []*boxlayout.Box{
+		{
+			Direction: mainPanelsDirection,
+			Weight:    1,
+			Children:  gui.mainSectionChildren(),
+		},
+	}
+
+	if gui.showCmdLog {
+		_, height := gui.g.Size()
+		result = append(result, &boxlayout.Box{
+			Window: "cmdLog",
+			Size:   height / 4,
+		})
+	}
+
+	return result
+}
+
 func (gui *Gui) getMidSectionWeights() (int, int) {
 	currentWindow := gui.currentWindow()
 
@@ -67,11 +89,11 @@ func (gui *Gui) getMidSectionWeights() (int, int) {
 }
 
 func (gui *Gui) infoSectionChildren(informationStr string, appStatus string) []*boxlayout.Box {
-	if gui.State.Searching.isSearching {
+	if gui.State.Searching.isSearching || gui.State.Searching.filterContext != nil {
 		return []*boxlayout.Box{
 			{
 				Window: "searchPrefix",
-				Size:   len(SEARCH_PREFIX),
+				Size:   len(gui.searchPrefix()),
 			},
 			{
 				Window: "search",
@@ -159,9 +181,9 @@ func (gui *Gui) getWindowDimensions(informationStr string, appStatus string) map
 						ConditionalChildren: gui.sidePanelChildren,
 					},
 					{
-						Direction: mainPanelsDirection,
+						Direction: boxlayout.ROW,
 						Weight:    mainSectionWeight,
-						Children:  gui.mainSectionChildren(),
+						Children:  gui.mainSectionWithCmdLog(mainPanelsDirection),
 					},
 				},
 			},
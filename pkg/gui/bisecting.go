@@ -0,0 +1,306 @@
+package gui
+
+import (
+	"fmt"
+	"os/exec"
+	"strings"
+	"sync"
+
+	"github.com/fatih/color"
+	"github.com/jesseduffield/gocui"
+	"github.com/jesseduffield/lazygit/pkg/commands/models"
+	"github.com/jesseduffield/lazygit/pkg/commands/oscommands"
+	"github.com/jesseduffield/lazygit/pkg/utils"
+)
+
+func (gui *Gui) bisectStatusStr() string {
+	info := gui.State.Modes.Bisecting.Info
+
+	if info.Done() {
+		sha := info.FirstNewSha
+		if len(sha) > 8 {
+			sha = sha[:8]
+		}
+		return utils.ResolvePlaceholderString(
+			gui.Tr.LcBisectDone,
+			map[string]string{
+				"term": info.NewTerm,
+				"sha":  sha,
+			},
+		)
+	}
+
+	if info.Bisecting() {
+		return utils.ResolvePlaceholderString(
+			gui.Tr.LcBisectCandidates,
+			map[string]string{
+				"candidates": fmt.Sprintf("%d", info.Candidates),
+				"steps":      fmt.Sprintf("%d", info.Steps),
+			},
+		)
+	}
+
+	return utils.ResolvePlaceholderString(
+		gui.Tr.LcBisectWaiting,
+		map[string]string{
+			"newTerm": info.NewTerm,
+			"oldTerm": info.OldTerm,
+		},
+	)
+}
+
+func (gui *Gui) handleOpenBisectMenu() error {
+	resetItem := &menuItem{
+		displayStrings: []string{
+			gui.Tr.LcResetBisect,
+			color.New(color.FgRed).Sprint("git bisect reset"),
+		},
+		onPress: gui.handleResetBisect,
+	}
+
+	if gui.State.Modes.Bisecting.Run != nil {
+		menuItems := []*menuItem{
+			{
+				displayString: gui.Tr.LcStopBisectScript,
+				onPress: func() error {
+					gui.stopBisectScript()
+					return gui.refreshSidePanels(refreshOptions{mode: ASYNC})
+				},
+			},
+			resetItem,
+		}
+		return gui.createMenu(gui.Tr.Bisect, menuItems, createMenuOptions{showCancel: true})
+	}
+
+	commit := gui.getSelectedLocalCommit()
+	if commit == nil {
+		return nil
+	}
+
+	info := gui.State.Modes.Bisecting.Info
+
+	markItem := func(term string, template string) *menuItem {
+		return &menuItem{
+			displayStrings: []string{
+				utils.ResolvePlaceholderString(
+					template,
+					map[string]string{
+						"sha":  commit.ShortSha(),
+						"term": term,
+					},
+				),
+				color.New(color.FgYellow).Sprintf("git bisect %s %s", term, commit.ShortSha()),
+			},
+			onPress: func() error {
+				return gui.markBisectCommit(commit, term)
+			},
+		}
+	}
+
+	if !info.Started {
+		menuItems := []*menuItem{
+			markItem(info.NewTerm, gui.Tr.BisectMarkStart),
+			markItem(info.OldTerm, gui.Tr.BisectMarkStart),
+		}
+		return gui.createMenu(gui.Tr.Bisect, menuItems, createMenuOptions{showCancel: true})
+	}
+
+	menuItems := []*menuItem{
+		markItem(info.NewTerm, gui.Tr.BisectMark),
+		markItem(info.OldTerm, gui.Tr.BisectMark),
+		markItem("skip", gui.Tr.BisectSkip),
+	}
+
+	if info.Bisecting() && !info.Done() {
+		menuItems = append(menuItems, &menuItem{
+			displayStrings: []string{
+				utils.ResolvePlaceholderString(gui.Tr.BisectRunScript, map[string]string{"term": info.NewTerm}),
+				color.New(color.FgYellow).Sprint("git bisect run"),
+			},
+			onPress: gui.handleBisectRun,
+		})
+	}
+
+	menuItems = append(menuItems, resetItem)
+
+	return gui.createMenu(gui.Tr.Bisect, menuItems, createMenuOptions{showCancel: true})
+}
+
+func (gui *Gui) markBisectCommit(commit *models.Commit, term string) error {
+	return gui.WithWaitingStatus(gui.Tr.BisectingStatus, func() error {
+		if !gui.State.Modes.Bisecting.Info.Started {
+			if err := gui.withSpan(gui.Tr.SpanBisectMark).BisectStart(); err != nil {
+				return err
+			}
+		}
+
+		if err := gui.withSpan(gui.Tr.SpanBisectMark).BisectMark(commit.Sha, term); err != nil {
+			return err
+		}
+
+		return gui.refreshSidePanels(refreshOptions{then: gui.selectBisectCurrentCommit})
+	})
+}
+
+// selectBisectCurrentCommit moves the cursor to the commit git wants us to test
+// next, or to the culprit if we've found it
+func (gui *Gui) selectBisectCurrentCommit() {
+	info := gui.State.Modes.Bisecting.Info
+	sha := info.CurrentSha
+	if info.Done() {
+		sha = info.FirstNewSha
+	}
+
+	for i, commit := range gui.State.Commits {
+		if commit.Sha == sha {
+			gui.State.Panels.Commits.SelectedLineIdx = i
+			if err := gui.State.Contexts.BranchCommits.HandleFocus(); err != nil {
+				gui.Log.Error(err)
+			}
+			return
+		}
+	}
+}
+
+func (gui *Gui) handleBisectRun() error {
+	info := gui.State.Modes.Bisecting.Info
+
+	return gui.prompt(promptOpts{
+		title: utils.ResolvePlaceholderString(
+			gui.Tr.BisectRunScriptPrompt,
+			map[string]string{
+				"newTerm": info.NewTerm,
+				"oldTerm": info.OldTerm,
+			},
+		),
+		handleConfirm: func(script string) error {
+			script = strings.TrimSpace(script)
+			if script == "" {
+				return nil
+			}
+
+			return gui.runBisectScript(script)
+		},
+	})
+}
+
+// bisectRun is a `git bisect run` script. We collect its output ourselves
+// rather than streaming it to the main view as a task, so that the script keeps
+// going when something else takes over the main view
+type bisectRun struct {
+	cmd      *exec.Cmd
+	mutex    sync.Mutex
+	output   strings.Builder
+	onOutput func()
+}
+
+func (r *bisectRun) Write(p []byte) (int, error) {
+	r.mutex.Lock()
+	n, err := r.output.Write(p)
+	r.mutex.Unlock()
+
+	r.onOutput()
+
+	return n, err
+}
+
+func (r *bisectRun) getOutput() string {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	return r.output.String()
+}
+
+// runBisectScript runs `git bisect run`, showing its output in the main view
+// whenever the commits panel is focused
+func (gui *Gui) runBisectScript(script string) error {
+	// the script doesn't run through the OS command, so we call its hooks
+	// ourselves in order to log it
+	osCommand := gui.osCommandWithSpan(gui.Tr.SpanBisectRun)
+	cmd := osCommand.ExecutableFromString(gui.GitCommand.BisectRunCmdStr(script))
+
+	run := &bisectRun{cmd: cmd}
+	run.onOutput = func() {
+		gui.g.Update(func(*gocui.Gui) error {
+			if gui.State.Modes.Bisecting.Run != run || gui.currentSideContext() != gui.State.Contexts.BranchCommits {
+				return nil
+			}
+			return gui.renderBisectRun(run)
+		})
+	}
+	cmd.Stdout = run
+	cmd.Stderr = run
+	// the script is a child of git, and would otherwise outlive it
+	oscommands.PrepareForKillWithChildren(cmd)
+
+	osCommand.BeforeExecuteCmd(cmd)
+	if err := cmd.Start(); err != nil {
+		osCommand.AfterExecuteCmd(cmd, err)
+		return gui.surfaceError(err)
+	}
+
+	gui.State.Modes.Bisecting.Run = run
+
+	go utils.Safe(func() {
+		err := cmd.Wait()
+		osCommand.AfterExecuteCmd(cmd, err)
+
+		// if the script was stopped, whoever stopped it has moved on already
+		if gui.State.Modes.Bisecting.Run != run {
+			return
+		}
+
+		// we refresh before letting go of the main view so that the script's
+		// output stays up until the user moves on
+		if err := gui.refreshSidePanels(refreshOptions{}); err != nil {
+			gui.Log.Error(err)
+		}
+		gui.State.Modes.Bisecting.Run = nil
+	})
+
+	return gui.renderBisectRun(run)
+}
+
+func (gui *Gui) renderBisectRun(run *bisectRun) error {
+	return gui.refreshMainViews(refreshMainOpts{
+		main: &viewUpdateOpts{
+			title: gui.Tr.BisectRunTitle,
+			task:  NewRenderStringTask(run.getOutput()),
+		},
+	})
+}
+
+// stopBisectScript kills the running `git bisect run`, leaving the bisect where
+// the script got to
+func (gui *Gui) stopBisectScript() {
+	run := gui.State.Modes.Bisecting.Run
+	if run == nil {
+		return
+	}
+
+	gui.State.Modes.Bisecting.Run = nil
+	if err := oscommands.KillWithChildren(run.cmd); err != nil {
+		gui.Log.Error(err)
+	}
+}
+
+func (gui *Gui) handleResetBisect() error {
+	return gui.ask(askOpts{
+		title: gui.Tr.ResetBisectTitle,
+		prompt: utils.ResolvePlaceholderString(
+			gui.Tr.ResetBisectPrompt,
+			map[string]string{
+				"ref": gui.State.Modes.Bisecting.Info.StartRef,
+			},
+		),
+		handleConfirm: func() error {
+			gui.stopBisectScript()
+
+			if err := gui.withSpan(gui.Tr.SpanBisectReset).BisectReset(); err != nil {
+				return gui.surfaceError(err)
+			}
+
+			return gui.refreshSidePanels(refreshOptions{mode: ASYNC})
+		},
+	})
+}
@@ -0,0 +1,227 @@
+package gui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/jesseduffield/gocui"
+	"github.com/jesseduffield/lazygit/pkg/gui/presentation"
+	"github.com/jesseduffield/lazygit/pkg/theme"
+	"github.com/jesseduffield/lazygit/pkg/utils"
+)
+
+func (gui *Gui) handleFileBlame() error {
+	file := gui.getSelectedFile()
+	if file == nil {
+		return nil
+	}
+
+	return gui.enterBlame(file.Name, "")
+}
+
+func (gui *Gui) handleCommitFileBlame() error {
+	file := gui.getSelectedCommitFile()
+	if file == nil {
+		return nil
+	}
+
+	return gui.enterBlame(file.Name, gui.State.Panels.CommitFiles.refName)
+}
+
+func (gui *Gui) enterBlame(filename string, ref string) error {
+	gui.State.Panels.Blame.History = nil
+	if err := gui.loadBlame(blameTarget{Filename: filename, Ref: ref}); err != nil {
+		return gui.surfaceError(err)
+	}
+
+	return gui.pushContext(gui.State.Contexts.Blame)
+}
+
+// loadBlame only replaces our current blame if git is happy to blame the target,
+// so that a failed attempt leaves us where we were
+func (gui *Gui) loadBlame(target blameTarget) error {
+	lines, err := gui.GitCommand.GetBlame(target.Filename, target.Ref)
+	if err != nil {
+		return err
+	}
+
+	state := gui.State.Panels.Blame
+	state.blameTarget = target
+	state.Lines = lines
+	if state.SelectedLineIdx > len(lines)-1 {
+		state.SelectedLineIdx = len(lines) - 1
+	}
+	if state.SelectedLineIdx < 0 {
+		state.SelectedLineIdx = 0
+	}
+
+	return nil
+}
+
+func (gui *Gui) refreshBlamePanel() error {
+	state := gui.State.Panels.Blame
+
+	displayStrings := presentation.GetBlameDisplayStrings(state.Lines, gui.Tr.BlameNotCommitted)
+	lines := strings.Split(utils.RenderDisplayStrings(displayStrings), "\n")
+	if state.SelectedLineIdx < len(lines) {
+		lines[state.SelectedLineIdx] = utils.ColoredString(utils.Decolorise(lines[state.SelectedLineIdx]), theme.SelectedRangeBgColor)
+	}
+
+	gui.focusBlameLine()
+
+	return gui.refreshMainViews(refreshMainOpts{
+		main: &viewUpdateOpts{
+			title:  gui.blameTitle(),
+			task:   NewRenderStringWithoutScrollTask(strings.Join(lines, "\n")),
+			noWrap: true,
+		},
+	})
+}
+
+func (gui *Gui) blameTitle() string {
+	state := gui.State.Panels.Blame
+
+	ref := gui.Tr.BlameWorkingTree
+	if state.Ref != "" {
+		ref = state.Ref
+		if len(ref) == 40 {
+			ref = ref[:8]
+		}
+	}
+
+	return fmt.Sprintf("%s: %s (%s)", gui.Tr.BlameTitle, state.Filename, ref)
+}
+
+// focusBlameLine scrolls the main view just enough to keep the selected line in view
+func (gui *Gui) focusBlameLine() {
+	mainView := gui.Views.Main
+	selectedLineIdx := gui.State.Panels.Blame.SelectedLineIdx
+
+	_, viewHeight := mainView.Size()
+	_, origin := mainView.Origin()
+
+	newOrigin := origin
+	if selectedLineIdx < origin {
+		newOrigin = selectedLineIdx
+	} else if selectedLineIdx > origin+viewHeight-1 {
+		newOrigin = selectedLineIdx - viewHeight + 1
+	}
+
+	gui.g.Update(func(*gocui.Gui) error {
+		return mainView.SetOrigin(0, newOrigin)
+	})
+}
+
+func (gui *Gui) handleBlamePrevLine() error {
+	return gui.moveBlameSelection(-1)
+}
+
+func (gui *Gui) handleBlameNextLine() error {
+	return gui.moveBlameSelection(1)
+}
+
+func (gui *Gui) moveBlameSelection(change int) error {
+	state := gui.State.Panels.Blame
+
+	newIdx := state.SelectedLineIdx + change
+	if newIdx < 0 || newIdx > len(state.Lines)-1 {
+		return nil
+	}
+	state.SelectedLineIdx = newIdx
+
+	return gui.refreshBlamePanel()
+}
+
+func (gui *Gui) handleBlameGoToCommit() error {
+	state := gui.State.Panels.Blame
+	if len(state.Lines) == 0 {
+		return nil
+	}
+
+	line := state.Lines[state.SelectedLineIdx]
+	if !line.Committed() {
+		return gui.createErrorPanel(gui.Tr.BlameLineNotCommitted)
+	}
+
+	findCommit := func() int {
+		for i, commit := range gui.State.Commits {
+			if commit.Sha == line.Sha {
+				return i
+			}
+		}
+		return -1
+	}
+
+	idx := findCommit()
+	if idx == -1 && gui.State.Panels.Commits.LimitCommits {
+		gui.State.Panels.Commits.LimitCommits = false
+		if err := gui.refreshCommitsWithLimit(); err != nil {
+			return gui.surfaceError(err)
+		}
+		idx = findCommit()
+	}
+
+	if idx == -1 {
+		return gui.createErrorPanel(gui.Tr.BlameCommitNotFound)
+	}
+
+	gui.State.Panels.Commits.SelectedLineIdx = idx
+
+	return gui.pushContext(gui.State.Contexts.BranchCommits)
+}
+
+// handleBlamePreviousVersion blames the file as of just before the selected
+// line's commit, letting us walk back through the line's history
+func (gui *Gui) handleBlamePreviousVersion() error {
+	state := gui.State.Panels.Blame
+	if len(state.Lines) == 0 {
+		return nil
+	}
+
+	line := state.Lines[state.SelectedLineIdx]
+	if line.PreviousSha == "" {
+		return gui.createErrorPanel(gui.Tr.BlameNoPreviousVersion)
+	}
+
+	current := state.blameTarget
+	target := blameTarget{
+		Filename: line.PreviousFilename,
+		Ref:      line.PreviousSha,
+		// the line may well have moved in the previous version, but it's likely
+		// to be close to where it was when the commit introduced it
+		SelectedLineIdx: line.OriginalLineNumber - 1,
+	}
+	if err := gui.loadBlame(target); err != nil {
+		return gui.surfaceError(err)
+	}
+	state.History = append(state.History, current)
+
+	return gui.refreshBlamePanel()
+}
+
+func (gui *Gui) handleEscapeBlame() error {
+	state := gui.State.Panels.Blame
+
+	if len(state.History) > 0 {
+		target := state.History[len(state.History)-1]
+		if err := gui.loadBlame(target); err != nil {
+			return gui.surfaceError(err)
+		}
+		state.History = state.History[:len(state.History)-1]
+
+		return gui.refreshBlamePanel()
+	}
+
+	return gui.returnFromContext()
+}
+
+func (gui *Gui) getBlameOptions() map[string]string {
+	keybindingConfig := gui.Config.GetUserConfig().Keybinding
+
+	return map[string]string{
+		fmt.Sprintf("%s %s", gui.getKeyDisplay(keybindingConfig.Universal.PrevItem), gui.getKeyDisplay(keybindingConfig.Universal.NextItem)): gui.Tr.LcSelectLine,
+		gui.getKeyDisplay(keybindingConfig.Universal.GoInto):          gui.Tr.LcGoToBlamedCommit,
+		gui.getKeyDisplay(keybindingConfig.Main.BlamePreviousVersion): gui.Tr.LcBlamePreviousVersion,
+		gui.getKeyDisplay(keybindingConfig.Universal.Return):          gui.Tr.LcExitBlame,
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"strings"
 
+	"github.com/fatih/color"
 	"github.com/jesseduffield/lazygit/pkg/commands"
 	"github.com/jesseduffield/lazygit/pkg/commands/models"
 	"github.com/jesseduffield/lazygit/pkg/gui/presentation"
@@ -93,11 +94,22 @@ func (gui *Gui) handleCreatePullRequestPress() error {
 	pullRequest := commands.NewPullRequest(gui.GitCommand)
 
 	branch := gui.getSelectedBranch()
-	if err := pullRequest.Create(branch); err != nil {
-		return gui.surfaceError(err)
+	if branch == nil {
+		return nil
 	}
 
-	return nil
+	client, err := pullRequest.HostingClient()
+	if err != nil {
+		// without access to the service's API, the best we can do is take the user
+		// to the service's page for creating one
+		if err := pullRequest.Create(branch); err != nil {
+			return gui.surfaceError(err)
+		}
+
+		return nil
+	}
+
+	return gui.createPullRequestForm(client, branch)
 }
 
 func (gui *Gui) handleCopyPullRequestURLPress() error {
@@ -134,7 +146,7 @@ func (gui *Gui) handleForceCheckout() error {
 		title:  title,
 		prompt: message,
 		handleConfirm: func() error {
-			if err := gui.GitCommand.Checkout(branch.Name, commands.CheckoutOptions{Force: true}); err != nil {
+			if err := gui.withSpan(gui.Tr.SpanForceCheckout).Checkout(branch.Name, commands.CheckoutOptions{Force: true}); err != nil {
 				_ = gui.surfaceError(err)
 			}
 			return gui.refreshSidePanels(refreshOptions{mode: ASYNC})
@@ -164,7 +176,7 @@ func (gui *Gui) handleCheckoutRef(ref string, options handleCheckoutRefOptions)
 	}
 
 	return gui.WithWaitingStatus(waitingStatus, func() error {
-		if err := gui.GitCommand.Checkout(ref, cmdOptions); err != nil {
+		if err := gui.withSpan(gui.Tr.SpanCheckout).Checkout(ref, cmdOptions); err != nil {
 			// note, this will only work for english-language git commands. If we force git to use english, and the error isn't this one, then the user will receive an english command they may not understand. I'm not sure what the best solution to this is. Running the command once in english and a second time in the native language is one option
 
 			if options.onRefNotFound != nil && strings.Contains(err.Error(), "did not match any file(s) known to git") {
@@ -178,15 +190,15 @@ func (gui *Gui) handleCheckoutRef(ref string, options handleCheckoutRefOptions)
 					title:  gui.Tr.AutoStashTitle,
 					prompt: gui.Tr.AutoStashPrompt,
 					handleConfirm: func() error {
-						if err := gui.GitCommand.StashSave(gui.Tr.StashPrefix + ref); err != nil {
+						if err := gui.withSpan(gui.Tr.SpanCheckout).StashSave(gui.Tr.StashPrefix + ref); err != nil {
 							return gui.surfaceError(err)
 						}
-						if err := gui.GitCommand.Checkout(ref, cmdOptions); err != nil {
+						if err := gui.withSpan(gui.Tr.SpanCheckout).Checkout(ref, cmdOptions); err != nil {
 							return gui.surfaceError(err)
 						}
 
 						onSuccess()
-						if err := gui.GitCommand.StashDo(0, "pop"); err != nil {
+						if err := gui.withSpan(gui.Tr.SpanCheckout).StashDo(0, "pop"); err != nil {
 							if err := gui.refreshSidePanels(refreshOptions{mode: BLOCK_UI}); err != nil {
 								return err
 							}
@@ -242,7 +254,7 @@ func (gui *Gui) createNewBranchWithName(newBranchName string) error {
 		return nil
 	}
 
-	if err := gui.GitCommand.NewBranch(newBranchName, branch.Name); err != nil {
+	if err := gui.withSpan(gui.Tr.SpanNewBranch).NewBranch(newBranchName, branch.Name); err != nil {
 		return gui.surfaceError(err)
 	}
 
@@ -255,6 +267,10 @@ func (gui *Gui) handleDeleteBranch() error {
 }
 
 func (gui *Gui) deleteBranch(force bool) error {
+	if gui.State.Panels.Branches.IsRangeSelecting() {
+		return gui.deleteSelectedBranches(force)
+	}
+
 	selectedBranch := gui.getSelectedBranch()
 	if selectedBranch == nil {
 		return nil
@@ -285,7 +301,7 @@ func (gui *Gui) deleteNamedBranch(selectedBranch *models.Branch, force bool) err
 		title:  title,
 		prompt: message,
 		handleConfirm: func() error {
-			if err := gui.GitCommand.DeleteBranch(selectedBranch.Name, force); err != nil {
+			if err := gui.withSpan(gui.Tr.SpanDeleteBranch).DeleteBranch(selectedBranch.Name, force); err != nil {
 				errMessage := err.Error()
 				if !force && strings.Contains(errMessage, "is not fully merged") {
 					return gui.deleteNamedBranch(selectedBranch, true)
@@ -297,6 +313,75 @@ func (gui *Gui) deleteNamedBranch(selectedBranch *models.Branch, force bool) err
 	})
 }
 
+func (gui *Gui) deleteSelectedBranches(force bool) error {
+	startIdx, endIdx := gui.State.Contexts.Branches.getSelectedRange()
+	branches := gui.State.Branches[startIdx : endIdx+1]
+
+	checkedOutBranch := gui.getCheckedOutBranch()
+	for _, branch := range branches {
+		if branch.Name == checkedOutBranch.Name {
+			return gui.createErrorPanel(gui.Tr.CantDeleteCheckOutBranch)
+		}
+	}
+
+	return gui.deleteNamedBranches(branches, force)
+}
+
+// deleteNamedBranches deletes each of the given branches, asking again before
+// force deleting any that turn out not to be fully merged
+func (gui *Gui) deleteNamedBranches(branches []*models.Branch, force bool) error {
+	branchNames := make([]string, len(branches))
+	for i, branch := range branches {
+		branchNames[i] = branch.Name
+	}
+
+	templateStr := gui.Tr.DeleteBranchesMessage
+	if force {
+		templateStr = gui.Tr.ForceDeleteBranchesMessage
+	}
+	message := utils.ResolvePlaceholderString(
+		templateStr,
+		map[string]string{
+			"branchNames": strings.Join(branchNames, ", "),
+		},
+	)
+
+	return gui.ask(askOpts{
+		title:  gui.Tr.DeleteBranch,
+		prompt: message,
+		handleConfirm: func() error {
+			gui.State.Panels.Branches.CancelRangeSelect()
+
+			unmergedBranches := []*models.Branch{}
+			var deleteErr error
+			for _, branch := range branches {
+				if err := gui.withSpan(gui.Tr.SpanDeleteBranch).DeleteBranch(branch.Name, force); err != nil {
+					if !force && strings.Contains(err.Error(), "is not fully merged") {
+						unmergedBranches = append(unmergedBranches, branch)
+						continue
+					}
+					deleteErr = err
+					break
+				}
+			}
+
+			if err := gui.refreshSidePanels(refreshOptions{mode: ASYNC, scope: []RefreshableView{BRANCHES}}); err != nil {
+				return err
+			}
+
+			if deleteErr != nil {
+				return gui.createErrorPanel(deleteErr.Error())
+			}
+
+			if len(unmergedBranches) > 0 {
+				return gui.deleteNamedBranches(unmergedBranches, true)
+			}
+
+			return nil
+		},
+	})
+}
+
 func (gui *Gui) mergeBranchIntoCheckedOutBranch(branchName string) error {
 	if ok, err := gui.validateNotInFilterMode(); err != nil || !ok {
 		return err
@@ -321,7 +406,7 @@ func (gui *Gui) mergeBranchIntoCheckedOutBranch(branchName string) error {
 		title:  gui.Tr.MergingTitle,
 		prompt: prompt,
 		handleConfirm: func() error {
-			err := gui.GitCommand.Merge(branchName, commands.MergeOpts{})
+			err := gui.withSpan(gui.Tr.SpanMerge).Merge(branchName, commands.MergeOpts{})
 			return gui.handleGenericMergeCommandResult(err)
 		},
 	})
@@ -350,22 +435,58 @@ func (gui *Gui) handleRebaseOntoBranch(selectedBranchName string) error {
 	if selectedBranchName == checkedOutBranch {
 		return gui.createErrorPanel(gui.Tr.CantRebaseOntoSelf)
 	}
-	prompt := utils.ResolvePlaceholderString(
-		gui.Tr.ConfirmRebase,
+
+	// if the user has marked a base commit, we only transplant the commits after it
+	baseCommit := gui.State.Modes.MarkedBaseCommit.Commit
+	baseSha := ""
+	title := utils.ResolvePlaceholderString(
+		gui.Tr.RebaseOntoBranchTitle,
 		map[string]string{
 			"checkedOutBranch": checkedOutBranch,
 			"selectedBranch":   selectedBranchName,
 		},
 	)
+	cmdStr := "git rebase " + selectedBranchName
+	if baseCommit != nil {
+		baseSha = baseCommit.Sha
+		title = utils.ResolvePlaceholderString(
+			gui.Tr.RebaseOntoBranchFromBaseTitle,
+			map[string]string{
+				"checkedOutBranch": checkedOutBranch,
+				"selectedBranch":   selectedBranchName,
+				"baseSha":          baseCommit.ShortSha(),
+			},
+		)
+		cmdStr = fmt.Sprintf("git rebase --onto %s %s", selectedBranchName, baseCommit.ShortSha())
+	}
 
-	return gui.ask(askOpts{
-		title:  gui.Tr.RebasingTitle,
-		prompt: prompt,
-		handleConfirm: func() error {
-			err := gui.GitCommand.RebaseBranch(selectedBranchName)
-			return gui.handleGenericMergeCommandResult(err)
-		},
-	})
+	rebaseItem := func(displayString string, rebaseMerges bool) *menuItem {
+		itemCmdStr := cmdStr
+		if rebaseMerges {
+			itemCmdStr = strings.Replace(cmdStr, "git rebase", "git rebase --rebase-merges", 1)
+		}
+
+		return &menuItem{
+			displayStrings: []string{displayString, color.New(color.FgYellow).Sprint(itemCmdStr)},
+			onPress: func() error {
+				return gui.WithWaitingStatus(gui.Tr.RebasingStatus, func() error {
+					gui.State.Modes.MarkedBaseCommit.Reset()
+					err := gui.withSpan(gui.Tr.SpanRebaseBranch).RebaseBranchWithOptions(
+						selectedBranchName,
+						commands.RebaseBranchOptions{Upstream: baseSha, RebaseMerges: rebaseMerges},
+					)
+					return gui.handleGenericMergeCommandResult(err)
+				})
+			},
+		}
+	}
+
+	menuItems := []*menuItem{
+		rebaseItem(gui.Tr.LcSimpleRebase, false),
+		rebaseItem(gui.Tr.LcRebaseKeepingMerges, true),
+	}
+
+	return gui.createMenu(title, menuItems, createMenuOptions{showCancel: true})
 }
 
 func (gui *Gui) handleFastForward() error {
@@ -405,7 +526,7 @@ func (gui *Gui) handleFastForward() error {
 		if gui.State.Panels.Branches.SelectedLineIdx == 0 {
 			_ = gui.pullWithMode("ff-only", PullFilesOptions{})
 		} else {
-			err := gui.GitCommand.FastForward(branch.Name, remoteName, remoteBranchName, gui.promptUserForCredential)
+			err := gui.withSpan(gui.Tr.SpanFastForward).FastForward(branch.Name, remoteName, remoteBranchName, gui.promptUserForCredential)
 			gui.handleCredentialsPopup(err)
 			_ = gui.refreshSidePanels(refreshOptions{mode: ASYNC, scope: []RefreshableView{BRANCHES}})
 		}
@@ -433,7 +554,7 @@ func (gui *Gui) handleRenameBranch() error {
 			title:          gui.Tr.NewBranchNamePrompt + " " + branch.Name + ":",
 			initialContent: branch.Name,
 			handleConfirm: func(newBranchName string) error {
-				if err := gui.GitCommand.RenameBranch(branch.Name, newBranchName); err != nil {
+				if err := gui.withSpan(gui.Tr.SpanRenameBranch).RenameBranch(branch.Name, newBranchName); err != nil {
 					return gui.surfaceError(err)
 				}
 
@@ -502,7 +623,7 @@ func (gui *Gui) handleNewBranchOffCurrentItem() error {
 		title:          message,
 		initialContent: prefilledName,
 		handleConfirm: func(response string) error {
-			if err := gui.GitCommand.NewBranch(sanitizedBranchName(response), item.ID()); err != nil {
+			if err := gui.withSpan(gui.Tr.SpanNewBranch).NewBranch(sanitizedBranchName(response), item.ID()); err != nil {
 				return err
 			}
 
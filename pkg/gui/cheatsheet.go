@@ -0,0 +1,242 @@
+package gui
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/fatih/color"
+	"github.com/jesseduffield/lazygit/pkg/gui/types"
+	"github.com/jesseduffield/lazygit/pkg/utils"
+)
+
+// BindingSection is a group of keybindings as they're listed in the cheatsheet,
+// e.g. those of the files panel
+type BindingSection struct {
+	Title    string
+	Bindings []*Binding
+}
+
+// GetBindingSections groups the keybindings of the current config, including
+// those of custom commands, into sections for each view and context
+func (gui *Gui) GetBindingSections() []*BindingSection {
+	bindingSections := []*BindingSection{}
+
+	bindings := append(gui.GetCustomCommandKeybindings(), gui.GetInitialKeybindings()...)
+
+	type contextAndViewType struct {
+		subtitle string
+		title    string
+	}
+
+	contextAndViewBindingMap := map[contextAndViewType][]*Binding{}
+
+outer:
+	for _, binding := range bindings {
+		// the cheatsheet is a list of keys
+		if binding.Key == nil {
+			continue
+		}
+
+		if binding.Tag == "navigation" {
+			key := contextAndViewType{subtitle: "", title: "navigation"}
+			for _, navBinding := range contextAndViewBindingMap[key] {
+				if navBinding.Description == binding.Description {
+					continue outer
+				}
+			}
+			contextAndViewBindingMap[key] = append(contextAndViewBindingMap[key], binding)
+
+			continue outer
+		}
+
+		contexts := []string{}
+		if len(binding.Contexts) == 0 {
+			contexts = append(contexts, "")
+		} else {
+			contexts = append(contexts, binding.Contexts...)
+		}
+
+		for _, context := range contexts {
+			key := contextAndViewType{subtitle: context, title: binding.ViewName}
+			contextAndViewBindingMap[key] = append(contextAndViewBindingMap[key], binding)
+		}
+	}
+
+	type groupedBindingsType struct {
+		contextAndView contextAndViewType
+		bindings       []*Binding
+	}
+
+	groupedBindings := make([]groupedBindingsType, 0, len(contextAndViewBindingMap))
+
+	for contextAndView, contextBindings := range contextAndViewBindingMap {
+		groupedBindings = append(groupedBindings, groupedBindingsType{contextAndView: contextAndView, bindings: contextBindings})
+	}
+
+	sort.Slice(groupedBindings, func(i, j int) bool {
+		first := groupedBindings[i].contextAndView
+		second := groupedBindings[j].contextAndView
+		if first.title == "" {
+			return true
+		}
+		if second.title == "" {
+			return false
+		}
+		if first.title == "navigation" {
+			return true
+		}
+		if second.title == "navigation" {
+			return false
+		}
+		return first.title < second.title || (first.title == second.title && first.subtitle < second.subtitle)
+	})
+
+	for _, group := range groupedBindings {
+		contextAndView := group.contextAndView
+		viewName := contextAndView.title
+		if viewName == "" {
+			viewName = "global"
+		}
+		translatedView := gui.cheatsheetTitle(viewName)
+		var title string
+		if contextAndView.subtitle == "" {
+			addendum := " " + gui.Tr.Panel
+			if viewName == "global" || viewName == "navigation" {
+				addendum = ""
+			}
+			title = fmt.Sprintf("%s%s", translatedView, addendum)
+		} else {
+			translatedContextName := gui.cheatsheetTitle(contextAndView.subtitle)
+			title = fmt.Sprintf("%s %s (%s)", translatedView, gui.Tr.Panel, translatedContextName)
+		}
+
+		for _, binding := range group.bindings {
+			bindingSections = addBindingToSection(title, bindingSections, binding)
+		}
+	}
+
+	return bindingSections
+}
+
+func addBindingToSection(title string, bindingSections []*BindingSection, binding *Binding) []*BindingSection {
+	if binding.Description == "" && binding.Alternative == "" {
+		return bindingSections
+	}
+
+	for _, section := range bindingSections {
+		if title == section.Title {
+			section.Bindings = append(section.Bindings, binding)
+			return bindingSections
+		}
+	}
+
+	section := &BindingSection{
+		Title:    title,
+		Bindings: []*Binding{binding},
+	}
+
+	return append(bindingSections, section)
+}
+
+// cheatsheetTitle is the title of a view or context in the cheatsheet
+func (gui *Gui) cheatsheetTitle(name string) string {
+	tr := gui.Tr
+
+	contextTitleMap := map[string]string{
+		"global":         tr.GlobalTitle,
+		"navigation":     tr.NavigationTitle,
+		"branches":       tr.BranchesTitle,
+		"localBranches":  tr.LocalBranchesTitle,
+		"files":          tr.FilesTitle,
+		"status":         tr.StatusTitle,
+		"submodules":     tr.SubmodulesTitle,
+		"subCommits":     tr.SubCommitsTitle,
+		"remoteBranches": tr.RemoteBranchesTitle,
+		"remotes":        tr.RemotesTitle,
+		"reflogCommits":  tr.ReflogCommitsTitle,
+		"tags":           tr.TagsTitle,
+		"worktrees":      tr.WorktreesTitle,
+		"pullRequests":   tr.PullRequestsTitle,
+		"commitFiles":    tr.CommitFilesTitle,
+		"commitMessage":  tr.CommitMessageTitle,
+		"commits":        tr.CommitsTitle,
+		"confirmation":   tr.ConfirmationTitle,
+		"credentials":    tr.CredentialsTitle,
+		"information":    tr.InformationTitle,
+		"main":           tr.MainTitle,
+		"patchBuilding":  tr.PatchBuildingTitle,
+		"merging":        tr.MergingTitle,
+		"blame":          tr.BlameTitle,
+		"normal":         tr.NormalTitle,
+		"staging":        tr.StagingTitle,
+		"menu":           tr.MenuTitle,
+		"search":         tr.SearchTitle,
+		"secondary":      tr.SecondaryTitle,
+		"stash":          tr.StashTitle,
+		"suggestions":    tr.SuggestionsTitle,
+	}
+
+	title, ok := contextTitleMap[name]
+	if !ok {
+		// better to show the name than nothing at all
+		return name
+	}
+
+	return title
+}
+
+// handleOpenKeybindingsHelp shows a prompt in which you can search the
+// keybindings of every view and context
+func (gui *Gui) handleOpenKeybindingsHelp() error {
+	if gui.popupPanelFocused() {
+		return nil
+	}
+
+	rows := [][]string{}
+	for _, section := range gui.GetBindingSections() {
+		for _, binding := range section.Bindings {
+			key := GetKeyDisplay(binding.Key)
+			if key == "" {
+				continue
+			}
+			rows = append(rows, []string{key, binding.Description, section.Title})
+		}
+	}
+
+	// we search on the uncoloured rows, and show them aligned and coloured
+	haystack := make([]string, len(rows))
+	for i, row := range rows {
+		haystack[i] = strings.Join(row, " ")
+	}
+	displayRows := make([][]string, len(rows))
+	for i, row := range rows {
+		displayRows[i] = []string{
+			utils.ColoredString(row[0], color.FgCyan),
+			fmt.Sprintf("%s %s", row[1], utils.ColoredString("("+row[2]+")", color.FgBlue)),
+		}
+	}
+	labels := strings.Split(utils.RenderDisplayStrings(displayRows), "\n")
+
+	return gui.prompt(promptOpts{
+		title: gui.Tr.KeybindingsHelpTitle,
+		findSuggestionsFunc: func(input string) []*types.Suggestion {
+			indexes := utils.FuzzySearchIndexes(input, haystack)
+			if input == "" {
+				indexes = make([]int, len(haystack))
+				for i := range haystack {
+					indexes[i] = i
+				}
+			}
+
+			suggestions := make([]*types.Suggestion, len(indexes))
+			for i, index := range indexes {
+				suggestions[i] = &types.Suggestion{Value: rows[index][0], Label: labels[index]}
+			}
+			return suggestions
+		},
+		handleConfirm: func(string) error {
+			return nil
+		},
+	})
+}
@@ -0,0 +1,107 @@
+package gui
+
+import (
+	"io/ioutil"
+	"strings"
+	"time"
+
+	"github.com/jesseduffield/gocui"
+	"github.com/jesseduffield/lazygit/pkg/commands"
+	"github.com/jesseduffield/lazygit/pkg/commands/oscommands"
+	"github.com/jesseduffield/lazygit/pkg/gui/cmdlog"
+	"github.com/jesseduffield/lazygit/pkg/gui/presentation"
+	"github.com/jesseduffield/lazygit/pkg/utils"
+)
+
+// withSpan returns a copy of our git command whose commands get recorded in the
+// command log under the given action, e.g. 'Rebase: squash down'. Anything the
+// user asks us to do should go through this rather than gui.GitCommand directly
+func (gui *Gui) withSpan(span string) *commands.GitCommand {
+	return gui.GitCommand.WithOSCommand(gui.osCommandWithSpan(span))
+}
+
+func (gui *Gui) osCommandWithSpan(span string) *oscommands.OSCommand {
+	before, after := gui.CmdLog.Hooks(span)
+
+	osCommand := gui.GitCommand.OSCommand.Clone()
+	osCommand.SetBeforeExecuteCmd(before)
+	osCommand.SetAfterExecuteCmd(after)
+
+	return osCommand
+}
+
+// onCmdLogChange is called from whichever goroutine ran the command
+func (gui *Gui) onCmdLogChange() {
+	if gui.g == nil || !gui.showCmdLog {
+		return
+	}
+
+	gui.g.Update(func(*gocui.Gui) error {
+		gui.renderCmdLog()
+		return nil
+	})
+}
+
+func (gui *Gui) renderCmdLog() {
+	if gui.Views.CmdLog == nil {
+		return
+	}
+
+	gui.setViewContent(gui.Views.CmdLog, presentation.GetCmdLogDisplayString(gui.CmdLog.Spans()))
+}
+
+func (gui *Gui) handleCreateCmdLogMenu() error {
+	toggleStr := gui.Tr.LcShowCommandLog
+	if gui.showCmdLog {
+		toggleStr = gui.Tr.LcHideCommandLog
+	}
+
+	menuItems := []*menuItem{
+		{
+			displayString: toggleStr,
+			onPress:       gui.handleToggleCmdLog,
+		},
+		{
+			displayString: gui.Tr.LcExportCommandLog,
+			onPress:       gui.handleExportCmdLog,
+		},
+	}
+
+	return gui.createMenu(gui.Tr.CommandLogTitle, menuItems, createMenuOptions{showCancel: true})
+}
+
+func (gui *Gui) handleToggleCmdLog() error {
+	gui.showCmdLog = !gui.showCmdLog
+	if gui.showCmdLog {
+		gui.renderCmdLog()
+	}
+
+	return nil
+}
+
+func (gui *Gui) handleExportCmdLog() error {
+	spans := gui.CmdLog.Spans()
+	if len(spans) == 0 {
+		return gui.createErrorPanel(gui.Tr.CommandLogEmpty)
+	}
+
+	return gui.prompt(promptOpts{
+		title:          gui.Tr.ExportCommandLogPrompt,
+		initialContent: "lazygit-session.sh",
+		handleConfirm: func(path string) error {
+			path = strings.TrimSpace(path)
+			if path == "" {
+				return nil
+			}
+
+			script := cmdlog.Script(spans, time.Now())
+			if err := ioutil.WriteFile(path, []byte(script), 0644); err != nil {
+				return gui.surfaceError(err)
+			}
+
+			gui.raiseToast(utils.ResolvePlaceholderString(gui.Tr.CommandLogExported, map[string]string{"path": path}))
+
+			return nil
+		},
+	})
+}
@@ -0,0 +1,245 @@
+package cmdlog
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+)
+
+// CmdLog records the commands we run on the user's behalf, grouped by the
+// action that triggered them (e.g. 'Rebase: squash down')
+type CmdLog struct {
+	mutex sync.Mutex
+	spans []*Span
+
+	// the entries of commands we've started but not yet heard back from
+	running map[*exec.Cmd]*Entry
+
+	// called whenever an entry is added or updated, from whichever goroutine
+	// happened to run the command
+	onChange func()
+}
+
+// Span is a group of commands run as part of a single action
+type Span struct {
+	Name    string
+	Entries []*Entry
+}
+
+type Entry struct {
+	Args []string
+	// the environment variables the command was given on top of our own
+	Env []string
+	Dir string
+
+	Start time.Time
+	Done  bool
+	// -1 if the command couldn't be run at all
+	ExitCode int
+
+	// only set for commands whose output the user asked to see in the log
+	Output string
+}
+
+func NewCmdLog(onChange func()) *CmdLog {
+	return &CmdLog{
+		running:  map[*exec.Cmd]*Entry{},
+		onChange: onChange,
+	}
+}
+
+// Hooks returns functions to pass to OSCommand's SetBeforeExecuteCmd and
+// SetAfterExecuteCmd so that each command it runs gets logged under the given span
+func (l *CmdLog) Hooks(spanName string) (func(*exec.Cmd), func(*exec.Cmd, error)) {
+	before := func(cmd *exec.Cmd) {
+		l.mutex.Lock()
+		entry := &Entry{
+			Args:  cmd.Args,
+			Env:   extraEnv(cmd.Env),
+			Dir:   cmdDir(cmd),
+			Start: time.Now(),
+		}
+		l.running[cmd] = entry
+		l.addEntry(spanName, entry)
+		l.mutex.Unlock()
+
+		l.onChange()
+	}
+
+	after := func(cmd *exec.Cmd, err error) {
+		l.mutex.Lock()
+		entry, ok := l.running[cmd]
+		if ok {
+			delete(l.running, cmd)
+			entry.Done = true
+			entry.ExitCode = exitCode(err)
+		}
+		l.mutex.Unlock()
+
+		if ok {
+			l.onChange()
+		}
+	}
+
+	return before, after
+}
+
+// SetOutput attaches the given output to the latest command of the given span
+func (l *CmdLog) SetOutput(spanName string, output string) {
+	l.mutex.Lock()
+	found := false
+	for i := len(l.spans) - 1; i >= 0 && !found; i-- {
+		span := l.spans[i]
+		if span.Name == spanName && len(span.Entries) > 0 {
+			span.Entries[len(span.Entries)-1].Output = output
+			found = true
+		}
+	}
+	l.mutex.Unlock()
+
+	if found {
+		l.onChange()
+	}
+}
+
+// addEntry adds the entry to the latest span if it's for the same action,
+// so that e.g. staging a few files in a row reads as one group
+func (l *CmdLog) addEntry(spanName string, entry *Entry) {
+	if len(l.spans) == 0 || l.spans[len(l.spans)-1].Name != spanName {
+		l.spans = append(l.spans, &Span{Name: spanName})
+	}
+
+	span := l.spans[len(l.spans)-1]
+	span.Entries = append(span.Entries, entry)
+}
+
+// Spans returns a snapshot of the log which is safe to read while commands
+// continue to run
+func (l *CmdLog) Spans() []Span {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	result := make([]Span, len(l.spans))
+	for i, span := range l.spans {
+		entries := make([]*Entry, len(span.Entries))
+		for j, entry := range span.Entries {
+			entryCopy := *entry
+			entries[j] = &entryCopy
+		}
+		result[i] = Span{Name: span.Name, Entries: entries}
+	}
+
+	return result
+}
+
+// Script renders the log as a shell script that replays the commands in
+// order. Commands that failed are left in as comments, so that the script
+// doesn't attempt something that didn't happen in the session
+func Script(spans []Span, exportedAt time.Time) string {
+	var builder strings.Builder
+	builder.WriteString("#!/bin/sh\n")
+	builder.WriteString(fmt.Sprintf("# exported from lazygit at %s\n", exportedAt.Format(time.RFC3339)))
+
+	dir := ""
+	for _, span := range spans {
+		builder.WriteString(fmt.Sprintf("\n# %s\n", span.Name))
+		for _, entry := range span.Entries {
+			if entry.Dir != dir {
+				builder.WriteString(fmt.Sprintf("cd %s\n", shellQuote(entry.Dir)))
+				dir = entry.Dir
+			}
+
+			command := entry.ShellCommand()
+			if entry.Done && entry.ExitCode != 0 {
+				builder.WriteString(fmt.Sprintf("# exited with %d: %s\n", entry.ExitCode, command))
+			} else {
+				builder.WriteString(command + "\n")
+			}
+		}
+	}
+
+	return builder.String()
+}
+
+// ShellCommand returns the command as you'd enter it in a shell, including any
+// environment variables we set for it
+func (e *Entry) ShellCommand() string {
+	words := make([]string, 0, len(e.Env)+len(e.Args))
+	for _, envVar := range e.Env {
+		parts := strings.SplitN(envVar, "=", 2)
+		words = append(words, parts[0]+"="+shellQuote(parts[1]))
+	}
+	for _, arg := range e.Args {
+		words = append(words, shellQuote(arg))
+	}
+
+	return strings.Join(words, " ")
+}
+
+// String returns the command as you'd enter it in a shell, minus the
+// environment variables, which are just noise when viewing the log
+func (e *Entry) String() string {
+	words := make([]string, len(e.Args))
+	for i, arg := range e.Args {
+		words[i] = shellQuote(arg)
+	}
+
+	return strings.Join(words, " ")
+}
+
+var safeShellWordRegex = regexp.MustCompile(`^[\w@%+=:,./-]+$`)
+
+func shellQuote(str string) string {
+	if safeShellWordRegex.MatchString(str) {
+		return str
+	}
+
+	return "'" + strings.Replace(str, "'", `'\''`, -1) + "'"
+}
+
+// extraEnv returns the variables in env which we don't already have in our own
+// environment
+func extraEnv(env []string) []string {
+	ownEnv := map[string]bool{}
+	for _, envVar := range os.Environ() {
+		ownEnv[envVar] = true
+	}
+
+	result := []string{}
+	for _, envVar := range env {
+		if !ownEnv[envVar] && strings.Contains(envVar, "=") {
+			result = append(result, envVar)
+		}
+	}
+
+	return result
+}
+
+func cmdDir(cmd *exec.Cmd) string {
+	if cmd.Dir != "" {
+		return cmd.Dir
+	}
+
+	dir, err := os.Getwd()
+	if err != nil {
+		return ""
+	}
+
+	return dir
+}
+
+func exitCode(err error) int {
+	if err == nil {
+		return 0
+	}
+
+	if exitErr, ok := err.(*exec.ExitError); ok {
+		return exitErr.ExitCode()
+	}
+
+	return -1
+}
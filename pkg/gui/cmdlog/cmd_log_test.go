@@ -0,0 +1,88 @@
+package cmdlog
+
+import (
+	"errors"
+	"os/exec"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestCmdLogHooks is a function.
+func TestCmdLogHooks(t *testing.T) {
+	changes := 0
+	cmdLog := NewCmdLog(func() { changes++ })
+
+	stageBefore, stageAfter := cmdLog.Hooks("Files: stage")
+	squashBefore, squashAfter := cmdLog.Hooks("Rebase: squash down")
+
+	run := func(before func(*exec.Cmd), after func(*exec.Cmd, error), err error, args ...string) {
+		cmd := exec.Command(args[0], args[1:]...)
+		cmd.Dir = "/repo"
+		before(cmd)
+		after(cmd, err)
+	}
+
+	run(stageBefore, stageAfter, nil, "git", "add", "--", "a")
+	run(stageBefore, stageAfter, nil, "git", "add", "--", "b")
+	run(squashBefore, squashAfter, errors.New("could not run"), "git", "rebase", "--interactive", "abc123^")
+
+	spans := cmdLog.Spans()
+
+	assert.Equal(t, 6, changes)
+	assert.Len(t, spans, 2)
+	assert.Equal(t, "Files: stage", spans[0].Name)
+	assert.Len(t, spans[0].Entries, 2)
+	assert.EqualValues(t, []string{"git", "add", "--", "b"}, spans[0].Entries[1].Args)
+	assert.True(t, spans[0].Entries[1].Done)
+	assert.Equal(t, 0, spans[0].Entries[1].ExitCode)
+	assert.Equal(t, "Rebase: squash down", spans[1].Name)
+	assert.Equal(t, -1, spans[1].Entries[0].ExitCode)
+
+	cmdLog.SetOutput("Files: stage", "staged b")
+	spans = cmdLog.Spans()
+
+	assert.Equal(t, 7, changes)
+	assert.Equal(t, "", spans[0].Entries[0].Output)
+	assert.Equal(t, "staged b", spans[0].Entries[1].Output)
+}
+
+// TestScript is a function.
+func TestScript(t *testing.T) {
+	spans := []Span{
+		{
+			Name: "Commit: commit",
+			Entries: []*Entry{
+				{Args: []string{"git", "commit", "-m", "it's done"}, Dir: "/my repo", Done: true},
+			},
+		},
+		{
+			Name: "Rebase: squash down",
+			Entries: []*Entry{
+				{
+					Args: []string{"git", "rebase", "--interactive", "abc123^"},
+					Env:  []string{"GIT_SEQUENCE_EDITOR=/usr/bin/lazygit"},
+					Dir:  "/my repo",
+					Done: true,
+				},
+				{Args: []string{"git", "push"}, Dir: "/other", Done: true, ExitCode: 1},
+			},
+		},
+	}
+
+	expected := `#!/bin/sh
+# exported from lazygit at 2021-03-04T05:06:07Z
+
+# Commit: commit
+cd '/my repo'
+git commit -m 'it'\''s done'
+
+# Rebase: squash down
+GIT_SEQUENCE_EDITOR=/usr/bin/lazygit git rebase --interactive 'abc123^'
+cd /other
+# exited with 1: git push
+`
+
+	assert.Equal(t, expected, Script(spans, time.Date(2021, 3, 4, 5, 6, 7, 0, time.UTC)))
+}
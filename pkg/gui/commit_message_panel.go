@@ -12,7 +12,7 @@ import (
 // runSyncOrAsyncCommand takes the output of a command that may have returned
 // either no error, an error, or a subprocess to execute, and if a subprocess
 // needs to be run, it runs it
-func (gui *Gui) runSyncOrAsyncCommand(sub *exec.Cmd, err error) (bool, error) {
+func (gui *Gui) runSyncOrAsyncCommand(span string, sub *exec.Cmd, err error) (bool, error) {
 	if err != nil {
 		return false, gui.surfaceError(err)
 	}
@@ -20,7 +20,7 @@ func (gui *Gui) runSyncOrAsyncCommand(sub *exec.Cmd, err error) (bool, error) {
 		return true, nil
 	}
 
-	err = gui.runSubprocessWithSuspense(sub)
+	err = gui.runSubprocessWithSuspense(span, sub)
 	if err != nil {
 		return false, err
 	}
@@ -37,7 +37,8 @@ func (gui *Gui) handleCommitConfirm() error {
 	if skipHookPrefix != "" && strings.HasPrefix(message, skipHookPrefix) {
 		flags = "--no-verify"
 	}
-	ok, err := gui.runSyncOrAsyncCommand(gui.GitCommand.Commit(message, flags))
+	sub, err := gui.withSpan(gui.Tr.SpanCommit).Commit(message, flags)
+	ok, err := gui.runSyncOrAsyncCommand(gui.Tr.SpanCommit, sub, err)
 	if err != nil {
 		return err
 	}
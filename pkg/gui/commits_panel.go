@@ -1,6 +1,7 @@
 package gui
 
 import (
+	"fmt"
 	"sync"
 
 	"github.com/jesseduffield/lazygit/pkg/commands"
@@ -16,7 +17,13 @@ func (gui *Gui) getSelectedLocalCommit() *models.Commit {
 		return nil
 	}
 
-	return gui.State.Commits[selectedLine]
+	commit := gui.State.Commits[selectedLine]
+	// todo lines like 'exec' and 'break' have no commit to act on
+	if commit.Sha == "" {
+		return nil
+	}
+
+	return commit
 }
 
 func (gui *Gui) handleCommitSelect() error {
@@ -32,10 +39,19 @@ func (gui *Gui) handleCommitSelect() error {
 
 	gui.escapeLineByLinePanel()
 
+	// while a bisect script is going we show how it's getting on instead
+	if run := gui.State.Modes.Bisecting.Run; run != nil {
+		return gui.renderBisectRun(run)
+	}
+
 	var task updateTask
 	commit := gui.getSelectedLocalCommit()
-	if commit == nil {
+	if commit == nil && state.SelectedLineIdx >= 0 && state.SelectedLineIdx < len(gui.State.Commits) {
+		task = NewRenderStringTask(gui.State.Commits[state.SelectedLineIdx].Description())
+	} else if commit == nil {
 		task = NewRenderStringTask(gui.Tr.NoCommitsThisBranch)
+	} else if gui.filterLineRangeArg() != "" {
+		task = NewRenderStringTask(state.LineRangeDiffs[commit.Sha])
 	} else {
 		cmd := gui.OSCommand.ExecutableFromString(
 			gui.GitCommand.ShowCmdStr(commit.Sha, gui.State.Modes.Filtering.GetPath()),
@@ -108,28 +124,104 @@ func (gui *Gui) refreshCommits() error {
 	return nil
 }
 
+// refForLog returns the ref we load the commits panel from. When following a
+// range of lines, that's the ref whose version of the file we picked the lines
+// from. While bisecting, HEAD is wherever git has taken us to test next, so we
+// load from wherever we were when we started in order to keep the newer
+// commits in view
+func (gui *Gui) refForLog() string {
+	if lineRange := gui.State.Modes.Filtering.GetLineRange(); lineRange != nil {
+		return lineRange.Ref
+	}
+
+	info := gui.State.Modes.Bisecting.Info
+	if !info.Started || info.StartRef == "" {
+		return "HEAD"
+	}
+
+	return info.StartRef
+}
+
+// filterLineRangeArg returns the range of lines we're following, in the form
+// `git log -L` takes, or an empty string if we're not following any
+func (gui *Gui) filterLineRangeArg() string {
+	lineRange := gui.State.Modes.Filtering.GetLineRange()
+	if lineRange == nil {
+		return ""
+	}
+
+	return fmt.Sprintf("%d,%d", lineRange.Start, lineRange.End)
+}
+
 func (gui *Gui) refreshCommitsWithLimit() error {
 	gui.Mutexes.BranchCommitsMutex.Lock()
 	defer gui.Mutexes.BranchCommitsMutex.Unlock()
 
 	builder := commands.NewCommitListBuilder(gui.Log, gui.GitCommand, gui.OSCommand, gui.Tr)
 
+	gui.State.Modes.Bisecting.Info = gui.GitCommand.GetBisectInfo()
+
 	commits, err := builder.GetCommits(
 		commands.GetCommitsOptions{
 			Limit:                gui.State.Panels.Commits.LimitCommits,
 			FilterPath:           gui.State.Modes.Filtering.GetPath(),
+			FilterLineRange:      gui.filterLineRangeArg(),
 			IncludeRebaseCommits: true,
-			RefName:              "HEAD",
+			RefName:              gui.refForLog(),
 		},
 	)
 	if err != nil {
 		return err
 	}
-	gui.State.Commits = commits
+
+	lineRangeDiffs, err := gui.loadLineRangeDiffs()
+	if err != nil {
+		return err
+	}
+	gui.State.Panels.Commits.LineRangeDiffs = lineRangeDiffs
+
+	if len(commits) > 0 && commits[0].Sha != gui.State.Modes.RebasePlanning.HeadSha {
+		gui.State.Modes.RebasePlanning.Reset()
+	}
+	// e.g. after a checkout the marked commit may no longer be on our branch,
+	// in which case rebasing from it would transplant the wrong commits
+	if marked := &gui.State.Modes.MarkedBaseCommit; marked.Active() && !containsCommit(commits, marked.Commit.Sha) {
+		marked.Reset()
+	}
+	gui.State.Commits = gui.withRebasePlan(commits)
 
 	return gui.postRefreshUpdate(gui.State.Contexts.BranchCommits)
 }
 
+// loadLineRangeDiffs gets how each commit changed the lines we're following,
+// if we're following any. We get them along with the commits because finding
+// them means walking the lines' history, which we'd otherwise do again each
+// time a commit is selected
+func (gui *Gui) loadLineRangeDiffs() (map[string]string, error) {
+	lineRangeArg := gui.filterLineRangeArg()
+	if lineRangeArg == "" {
+		return nil, nil
+	}
+
+	output, err := gui.OSCommand.RunCommandWithOutput(
+		gui.GitCommand.LineRangeLogCmdStr(gui.refForLog(), lineRangeArg, gui.State.Modes.Filtering.GetPath()),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return commands.SplitLineRangeLog(output), nil
+}
+
+func containsCommit(commits []*models.Commit, sha string) bool {
+	for _, commit := range commits {
+		if commit.Sha == sha {
+			return true
+		}
+	}
+	return false
+}
+
 func (gui *Gui) refreshRebaseCommits() error {
 	gui.Mutexes.BranchCommitsMutex.Lock()
 	defer gui.Mutexes.BranchCommitsMutex.Unlock()
@@ -164,12 +256,15 @@ func (gui *Gui) handleCommitSquashDown() error {
 		return nil
 	}
 
+	startIdx, endIdx := gui.State.Contexts.BranchCommits.getSelectedRange()
+
 	return gui.ask(askOpts{
 		title:  gui.Tr.Squash,
-		prompt: gui.Tr.SureSquashThisCommit,
+		prompt: gui.selectedCommitsPrompt(gui.Tr.SureSquashThisCommit, gui.Tr.SureSquashTheseCommits),
 		handleConfirm: func() error {
 			return gui.WithWaitingStatus(gui.Tr.SquashingStatus, func() error {
-				err := gui.GitCommand.InteractiveRebase(gui.State.Commits, gui.State.Panels.Commits.SelectedLineIdx, "squash")
+				gui.State.Panels.Commits.CancelRangeSelect()
+				err := gui.withSpan(gui.Tr.SpanSquashDown).InteractiveRebaseRange(gui.State.Commits, startIdx, endIdx, "squash")
 				return gui.handleGenericMergeCommandResult(err)
 			})
 		},
@@ -193,12 +288,15 @@ func (gui *Gui) handleCommitFixup() error {
 		return nil
 	}
 
+	startIdx, endIdx := gui.State.Contexts.BranchCommits.getSelectedRange()
+
 	return gui.ask(askOpts{
 		title:  gui.Tr.Fixup,
-		prompt: gui.Tr.SureFixupThisCommit,
+		prompt: gui.selectedCommitsPrompt(gui.Tr.SureFixupThisCommit, gui.Tr.SureFixupTheseCommits),
 		handleConfirm: func() error {
 			return gui.WithWaitingStatus(gui.Tr.FixingStatus, func() error {
-				err := gui.GitCommand.InteractiveRebase(gui.State.Commits, gui.State.Panels.Commits.SelectedLineIdx, "fixup")
+				gui.State.Panels.Commits.CancelRangeSelect()
+				err := gui.withSpan(gui.Tr.SpanFixup).InteractiveRebaseRange(gui.State.Commits, startIdx, endIdx, "fixup")
 				return gui.handleGenericMergeCommandResult(err)
 			})
 		},
@@ -236,7 +334,7 @@ func (gui *Gui) handleRenameCommit() error {
 		title:          gui.Tr.LcRenameCommit,
 		initialContent: message,
 		handleConfirm: func(response string) error {
-			if err := gui.GitCommand.RenameCommit(response); err != nil {
+			if err := gui.withSpan(gui.Tr.SpanRewordCommit).RenameCommit(response); err != nil {
 				return gui.surfaceError(err)
 			}
 
@@ -258,39 +356,88 @@ func (gui *Gui) handleRenameCommitEditor() error {
 		return nil
 	}
 
-	subProcess, err := gui.GitCommand.RewordCommit(gui.State.Commits, gui.State.Panels.Commits.SelectedLineIdx)
+	subProcess, err := gui.withSpan(gui.Tr.SpanRewordCommit).RewordCommit(gui.State.Commits, gui.State.Panels.Commits.SelectedLineIdx)
 	if err != nil {
 		return gui.surfaceError(err)
 	}
 	if subProcess != nil {
-		return gui.runSubprocessWithSuspense(subProcess)
+		return gui.runSubprocessWithSuspense(gui.Tr.SpanRewordCommit, subProcess)
 	}
 
 	return nil
 }
 
 // handleMidRebaseCommand sees if the selected commit is in fact a rebasing
-// commit meaning you are trying to edit the todo file rather than actually
-// begin a rebase. It then updates the todo file with that action
+// commit meaning you are trying to edit the todo (of a rebase in progress or
+// one we're planning) rather than actually begin a rebase. It then updates the
+// todo with that action
 func (gui *Gui) handleMidRebaseCommand(action string) (bool, error) {
 	selectedCommit := gui.State.Commits[gui.State.Panels.Commits.SelectedLineIdx]
 	if selectedCommit.Status != "rebasing" {
+		if gui.State.Modes.RebasePlanning.Active() {
+			return true, gui.createErrorPanel(gui.Tr.CommitNotInRebasePlan)
+		}
 		return false, nil
 	}
 
-	// for now we do not support setting 'reword' because it requires an editor
-	// and that means we either unconditionally wait around for the subprocess to ask for
-	// our input or we set a lazygit client as the EDITOR env variable and have it
-	// request us to edit the commit message when prompted.
-	if action == "reword" {
+	// for now we do not support setting 'reword' in the middle of a rebase because
+	// it requires an editor and that means we either unconditionally wait around
+	// for the subprocess to ask for our input or we set a lazygit client as the
+	// EDITOR env variable and have it request us to edit the commit message when
+	// prompted. When planning, we hand the terminal over to the editor once the
+	// rebase starts.
+	if action == "reword" && !gui.State.Modes.RebasePlanning.Active() {
 		return true, gui.createErrorPanel(gui.Tr.LcRewordNotSupported)
 	}
 
-	if err := gui.GitCommand.EditRebaseTodo(gui.State.Panels.Commits.SelectedLineIdx, action); err != nil {
-		return false, gui.surfaceError(err)
+	startIdx, endIdx := gui.State.Contexts.BranchCommits.getSelectedRange()
+	if startIdx == endIdx && selectedCommit.Sha == "" && action != "drop" {
+		return true, gui.createErrorPanel(
+			utils.ResolvePlaceholderString(
+				gui.Tr.CantSetActionOnTodo,
+				map[string]string{"action": action, "todoAction": selectedCommit.Action},
+			),
+		)
 	}
 
-	return true, gui.refreshRebaseCommits()
+	gui.State.Panels.Commits.CancelRangeSelect()
+
+	return true, gui.editRebaseTodos(gui.Tr.SpanSetTodoAction, func(todos []*models.Commit) ([]*models.Commit, error) {
+		// we go from the bottom up so that removing a line doesn't shift the ones
+		// we've yet to get to. When acting on a range we leave alone any commits in
+		// it which have already been rebased, along with any lines like 'exec'
+		// which can't take the action
+		for i := utils.Min(endIdx, len(todos)-1); i >= startIdx; i-- {
+			if todos[i].Sha == "" {
+				if action != "drop" {
+					continue
+				}
+
+				var err error
+				if todos, err = commands.RemoveTodo(todos, i); err != nil {
+					return nil, err
+				}
+				continue
+			}
+
+			if err := commands.SetTodoAction(todos, i, action); err != nil {
+				return nil, err
+			}
+		}
+
+		return todos, nil
+	})
+}
+
+// selectedCommitsPrompt returns the given prompt for a single commit, unless
+// the user has selected a range of commits to act on
+func (gui *Gui) selectedCommitsPrompt(singlePrompt string, rangePrompt string) string {
+	startIdx, endIdx := gui.State.Contexts.BranchCommits.getSelectedRange()
+	if startIdx == endIdx {
+		return singlePrompt
+	}
+
+	return utils.ResolvePlaceholderString(rangePrompt, map[string]string{"count": fmt.Sprintf("%d", endIdx-startIdx+1)})
 }
 
 func (gui *Gui) handleCommitDelete() error {
@@ -306,12 +453,15 @@ func (gui *Gui) handleCommitDelete() error {
 		return nil
 	}
 
+	startIdx, endIdx := gui.State.Contexts.BranchCommits.getSelectedRange()
+
 	return gui.ask(askOpts{
 		title:  gui.Tr.DeleteCommitTitle,
-		prompt: gui.Tr.DeleteCommitPrompt,
+		prompt: gui.selectedCommitsPrompt(gui.Tr.DeleteCommitPrompt, gui.Tr.DeleteCommitsPrompt),
 		handleConfirm: func() error {
 			return gui.WithWaitingStatus(gui.Tr.DeletingStatus, func() error {
-				err := gui.GitCommand.InteractiveRebase(gui.State.Commits, gui.State.Panels.Commits.SelectedLineIdx, "drop")
+				gui.State.Panels.Commits.CancelRangeSelect()
+				err := gui.withSpan(gui.Tr.SpanDropCommit).InteractiveRebaseRange(gui.State.Commits, startIdx, endIdx, "drop")
 				return gui.handleGenericMergeCommandResult(err)
 			})
 		},
@@ -322,22 +472,31 @@ func (gui *Gui) handleCommitMoveDown() error {
 	if ok, err := gui.validateNotInFilterMode(); err != nil || !ok {
 		return err
 	}
+	// the commit we'd swap with may not be in the filtered list
+	if gui.State.Contexts.BranchCommits.isFiltering() {
+		return gui.createErrorPanel(gui.Tr.CantMoveCommitsWhileFiltering)
+	}
 
 	index := gui.State.Panels.Commits.SelectedLineIdx
 	selectedCommit := gui.State.Commits[index]
 	if selectedCommit.Status == "rebasing" {
-		if gui.State.Commits[index+1].Status != "rebasing" {
+		if index+1 >= len(gui.State.Commits) || gui.State.Commits[index+1].Status != "rebasing" {
 			return nil
 		}
-		if err := gui.GitCommand.MoveTodoDown(index); err != nil {
-			return gui.surfaceError(err)
-		}
-		gui.State.Panels.Commits.SelectedLineIdx++
-		return gui.refreshRebaseCommits()
+		return gui.editRebaseTodos(gui.Tr.SpanMoveCommitDown, func(todos []*models.Commit) ([]*models.Commit, error) {
+			if err := commands.MoveTodoDown(todos, index); err != nil {
+				return nil, err
+			}
+			gui.State.Panels.Commits.SelectedLineIdx++
+			return todos, nil
+		})
+	}
+	if gui.State.Modes.RebasePlanning.Active() {
+		return gui.createErrorPanel(gui.Tr.CommitNotInRebasePlan)
 	}
 
 	return gui.WithWaitingStatus(gui.Tr.MovingStatus, func() error {
-		err := gui.GitCommand.MoveCommitDown(gui.State.Commits, index)
+		err := gui.withSpan(gui.Tr.SpanMoveCommitDown).MoveCommitDown(gui.State.Commits, index)
 		if err == nil {
 			gui.State.Panels.Commits.SelectedLineIdx++
 		}
@@ -349,6 +508,10 @@ func (gui *Gui) handleCommitMoveUp() error {
 	if ok, err := gui.validateNotInFilterMode(); err != nil || !ok {
 		return err
 	}
+	// the commit we'd swap with may not be in the filtered list
+	if gui.State.Contexts.BranchCommits.isFiltering() {
+		return gui.createErrorPanel(gui.Tr.CantMoveCommitsWhileFiltering)
+	}
 
 	index := gui.State.Panels.Commits.SelectedLineIdx
 	if index == 0 {
@@ -356,15 +519,20 @@ func (gui *Gui) handleCommitMoveUp() error {
 	}
 	selectedCommit := gui.State.Commits[index]
 	if selectedCommit.Status == "rebasing" {
-		if err := gui.GitCommand.MoveTodoDown(index - 1); err != nil {
-			return gui.surfaceError(err)
-		}
-		gui.State.Panels.Commits.SelectedLineIdx--
-		return gui.refreshRebaseCommits()
+		return gui.editRebaseTodos(gui.Tr.SpanMoveCommitUp, func(todos []*models.Commit) ([]*models.Commit, error) {
+			if err := commands.MoveTodoDown(todos, index-1); err != nil {
+				return nil, err
+			}
+			gui.State.Panels.Commits.SelectedLineIdx--
+			return todos, nil
+		})
+	}
+	if gui.State.Modes.RebasePlanning.Active() {
+		return gui.createErrorPanel(gui.Tr.CommitNotInRebasePlan)
 	}
 
 	return gui.WithWaitingStatus(gui.Tr.MovingStatus, func() error {
-		err := gui.GitCommand.MoveCommitDown(gui.State.Commits, index-1)
+		err := gui.withSpan(gui.Tr.SpanMoveCommitUp).MoveCommitDown(gui.State.Commits, index-1)
 		if err == nil {
 			gui.State.Panels.Commits.SelectedLineIdx--
 		}
@@ -386,7 +554,7 @@ func (gui *Gui) handleCommitEdit() error {
 	}
 
 	return gui.WithWaitingStatus(gui.Tr.RebasingStatus, func() error {
-		err = gui.GitCommand.InteractiveRebase(gui.State.Commits, gui.State.Panels.Commits.SelectedLineIdx, "edit")
+		err = gui.withSpan(gui.Tr.SpanEditCommit).InteractiveRebase(gui.State.Commits, gui.State.Panels.Commits.SelectedLineIdx, "edit")
 		return gui.handleGenericMergeCommandResult(err)
 	})
 }
@@ -401,7 +569,7 @@ func (gui *Gui) handleCommitAmendTo() error {
 		prompt: gui.Tr.AmendCommitPrompt,
 		handleConfirm: func() error {
 			return gui.WithWaitingStatus(gui.Tr.AmendingStatus, func() error {
-				err := gui.GitCommand.AmendTo(gui.State.Commits[gui.State.Panels.Commits.SelectedLineIdx].Sha)
+				err := gui.withSpan(gui.Tr.SpanAmendToCommit).AmendTo(gui.State.Commits[gui.State.Panels.Commits.SelectedLineIdx].Sha)
 				return gui.handleGenericMergeCommandResult(err)
 			})
 		},
@@ -431,7 +599,7 @@ func (gui *Gui) handleCommitRevert() error {
 		return err
 	}
 
-	if err := gui.GitCommand.Revert(gui.State.Commits[gui.State.Panels.Commits.SelectedLineIdx].Sha); err != nil {
+	if err := gui.withSpan(gui.Tr.SpanRevertCommit).Revert(gui.State.Commits[gui.State.Panels.Commits.SelectedLineIdx].Sha); err != nil {
 		return gui.surfaceError(err)
 	}
 	gui.State.Panels.Commits.SelectedLineIdx++
@@ -468,7 +636,7 @@ func (gui *Gui) handleCreateFixupCommit() error {
 		title:  gui.Tr.CreateFixupCommit,
 		prompt: prompt,
 		handleConfirm: func() error {
-			if err := gui.GitCommand.CreateFixupCommit(commit.Sha); err != nil {
+			if err := gui.withSpan(gui.Tr.SpanCreateFixupCommit).CreateFixupCommit(commit.Sha); err != nil {
 				return gui.surfaceError(err)
 			}
 
@@ -499,7 +667,7 @@ func (gui *Gui) handleSquashAllAboveFixupCommits() error {
 		prompt: prompt,
 		handleConfirm: func() error {
 			return gui.WithWaitingStatus(gui.Tr.SquashingStatus, func() error {
-				err := gui.GitCommand.SquashAllAboveFixupCommits(commit.Sha)
+				err := gui.withSpan(gui.Tr.SpanSquashAboveFixupCommits).SquashAllAboveFixupCommits(commit.Sha)
 				return gui.handleGenericMergeCommandResult(err)
 			})
 		},
@@ -507,27 +675,12 @@ func (gui *Gui) handleSquashAllAboveFixupCommits() error {
 }
 
 func (gui *Gui) handleTagCommit() error {
-	// TODO: bring up menu asking if you want to make a lightweight or annotated tag
-	// if annotated, switch to a subprocess to create the message
-
 	commit := gui.getSelectedLocalCommit()
 	if commit == nil {
 		return nil
 	}
 
-	return gui.handleCreateLightweightTag(commit.Sha)
-}
-
-func (gui *Gui) handleCreateLightweightTag(commitSha string) error {
-	return gui.prompt(promptOpts{
-		title: gui.Tr.TagNameTitle,
-		handleConfirm: func(response string) error {
-			if err := gui.GitCommand.CreateLightweightTag(response, commitSha); err != nil {
-				return gui.surfaceError(err)
-			}
-			return gui.refreshSidePanels(refreshOptions{mode: ASYNC, scope: []RefreshableView{COMMITS, TAGS}})
-		},
-	})
+	return gui.createTagMenu(commit.Sha)
 }
 
 func (gui *Gui) handleCheckoutCommit() error {
@@ -566,6 +719,18 @@ func (gui *Gui) handleOpenSearchForCommitsPanel(_viewName string) error {
 	return gui.handleOpenSearch("commits")
 }
 
+func (gui *Gui) handleOpenFilterForCommitsPanel(listContext *ListContext) error {
+	// as with searching, we want to filter all the commits rather than just the ones we've loaded
+	if gui.State.Panels.Commits.LimitCommits {
+		gui.State.Panels.Commits.LimitCommits = false
+		if err := gui.refreshSidePanels(refreshOptions{mode: ASYNC, scope: []RefreshableView{COMMITS}}); err != nil {
+			return err
+		}
+	}
+
+	return gui.handleOpenFilter(listContext)
+}
+
 func (gui *Gui) handleGotoBottomForCommitsPanel() error {
 	// we usually lazyload these commits but now that we're searching we need to load them now
 	if gui.State.Panels.Commits.LimitCommits {
@@ -603,3 +768,46 @@ func (gui *Gui) handleCopySelectedCommitMessageToClipboard() error {
 
 	return nil
 }
+
+// handleMarkCommitAsBaseForRebase marks the selected commit so that the next
+// rebase onto a branch only transplants the commits after it. Marking the same
+// commit again unmarks it
+func (gui *Gui) handleMarkCommitAsBaseForRebase() error {
+	if ok, err := gui.validateNotInFilterMode(); err != nil || !ok {
+		return err
+	}
+
+	commit := gui.getSelectedLocalCommit()
+	if commit == nil {
+		return nil
+	}
+
+	if commit.Status == "rebasing" {
+		return gui.createErrorPanel(gui.Tr.CantMarkTodoAsBaseCommit)
+	}
+
+	marked := &gui.State.Modes.MarkedBaseCommit
+	if marked.Active() && marked.Commit.Sha == commit.Sha {
+		return gui.exitMarkedBaseCommit()
+	}
+
+	marked.Commit = commit
+
+	return nil
+}
+
+func (gui *Gui) markedBaseCommitStatusStr() string {
+	return utils.ResolvePlaceholderString(
+		gui.Tr.LcMarkedBaseCommit,
+		map[string]string{
+			"sha": gui.State.Modes.MarkedBaseCommit.Commit.ShortSha(),
+			"key": gui.getKeyDisplay(gui.Config.GetUserConfig().Keybinding.Branches.RebaseBranch),
+		},
+	)
+}
+
+func (gui *Gui) exitMarkedBaseCommit() error {
+	gui.State.Modes.MarkedBaseCommit.Reset()
+
+	return nil
+}
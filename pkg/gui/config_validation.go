@@ -0,0 +1,162 @@
+package gui
+
+import (
+	"fmt"
+	"reflect"
+	"regexp"
+	"strings"
+	"text/template"
+	"unicode/utf8"
+
+	"github.com/jesseduffield/lazygit/pkg/config"
+)
+
+// UserConfigValidators check the parts of the user config that only the gui
+// knows how to make sense of. Anything they let through, we can bind
+func UserConfigValidators() []config.Validator {
+	return []config.Validator{validateKeybindings, validateCustomCommands}
+}
+
+// isValidKey tells whether getKey will make sense of the given key
+func isValidKey(key string) bool {
+	runeCount := utf8.RuneCountInString(key)
+	return runeCount == 1 || (runeCount > 1 && keymap[strings.ToLower(key)] != nil)
+}
+
+// sameKey tells whether two valid keys come out as the same key from getKey
+func sameKey(a string, b string) bool {
+	if utf8.RuneCountInString(a) == 1 || utf8.RuneCountInString(b) == 1 {
+		return a == b
+	}
+	return keymap[strings.ToLower(a)] == keymap[strings.ToLower(b)]
+}
+
+func invalidKeyMessage(key string) string {
+	if key == "" {
+		return "no key given"
+	}
+	return fmt.Sprintf("unrecognised key '%s'. For permitted values see https://github.com/jesseduffield/lazygit/blob/master/docs/keybindings/Custom_Keybindings.md", key)
+}
+
+func validateKeybindings(userConfig *config.UserConfig) []config.InvalidValue {
+	invalidValues := []config.InvalidValue{}
+
+	// each section of the keybinding config is a struct of keys
+	sections := reflect.ValueOf(userConfig.Keybinding)
+	for i := 0; i < sections.NumField(); i++ {
+		section := sections.Field(i)
+		for j := 0; j < section.NumField(); j++ {
+			key := section.Field(j).String()
+			if isValidKey(key) {
+				continue
+			}
+
+			invalidValues = append(invalidValues, config.InvalidValue{
+				Path: []interface{}{
+					"keybinding",
+					yamlName(sections.Type().Field(i)),
+					yamlName(section.Type().Field(j)),
+				},
+				Message: invalidKeyMessage(key),
+			})
+		}
+	}
+
+	return invalidValues
+}
+
+func yamlName(field reflect.StructField) string {
+	return strings.Split(field.Tag.Get("yaml"), ",")[0]
+}
+
+func validateCustomCommands(userConfig *config.UserConfig) []config.InvalidValue {
+	invalidValues := []config.InvalidValue{}
+
+	for i, customCommand := range userConfig.CustomCommands {
+		// a command without a key is only in the options menu
+		if customCommand.Key != "" && !isValidKey(customCommand.Key) {
+			invalidValues = append(invalidValues, config.InvalidValue{
+				Path:    []interface{}{"customCommands", i, "key"},
+				Message: invalidKeyMessage(customCommand.Key),
+			})
+		}
+
+		if customCommand.Context != "global" && !isContextKey(ContextKey(customCommand.Context)) {
+			message := fmt.Sprintf("unknown context '%s'", customCommand.Context)
+			if customCommand.Context == "" {
+				message = "no context given"
+			}
+
+			invalidValues = append(invalidValues, config.InvalidValue{
+				Path:    []interface{}{"customCommands", i, "context"},
+				Message: fmt.Sprintf("%s. Permitted contexts: global, %s", message, strings.Join(allContextKeyStrings(), ", ")),
+			})
+		}
+
+		if _, err := template.New("condition").Parse(customCommand.Condition); err != nil {
+			invalidValues = append(invalidValues, config.InvalidValue{
+				Path:    []interface{}{"customCommands", i, "condition"},
+				Message: fmt.Sprintf("invalid template: %s", err),
+			})
+		}
+
+		for j, prompt := range customCommand.Prompts {
+			if prompt.Type == "menuFromCommand" && prompt.Command == "" {
+				invalidValues = append(invalidValues, config.InvalidValue{
+					Path:    []interface{}{"customCommands", i, "prompts", j},
+					Message: "no command given for menuFromCommand prompt",
+				})
+			}
+
+			if _, err := regexp.Compile(prompt.Filter); err != nil {
+				invalidValues = append(invalidValues, config.InvalidValue{
+					Path:    []interface{}{"customCommands", i, "prompts", j, "filter"},
+					Message: fmt.Sprintf("invalid regular expression: %s", err),
+				})
+			}
+
+			for k, option := range prompt.Options {
+				if option.Key == "" {
+					continue
+				}
+
+				if !isValidKey(option.Key) {
+					invalidValues = append(invalidValues, config.InvalidValue{
+						Path:    []interface{}{"customCommands", i, "prompts", j, "options", k, "key"},
+						Message: invalidKeyMessage(option.Key),
+					})
+					continue
+				}
+
+				for _, reservedKey := range reservedMenuKeys(userConfig.Keybinding) {
+					if isValidKey(reservedKey) && sameKey(option.Key, reservedKey) {
+						invalidValues = append(invalidValues, config.InvalidValue{
+							Path:    []interface{}{"customCommands", i, "prompts", j, "options", k, "key"},
+							Message: fmt.Sprintf("'%s' is one of the menu's own keys", option.Key),
+						})
+						break
+					}
+				}
+			}
+		}
+	}
+
+	return invalidValues
+}
+
+func isContextKey(key ContextKey) bool {
+	for _, contextKey := range allContextKeys {
+		if contextKey == key {
+			return true
+		}
+	}
+	return false
+}
+
+func allContextKeyStrings() []string {
+	result := make([]string, len(allContextKeys))
+	for i, contextKey := range allContextKeys {
+		result[i] = string(contextKey)
+	}
+	return result
+}
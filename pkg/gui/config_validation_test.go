@@ -0,0 +1,46 @@
+package gui
+
+import (
+	"testing"
+
+	"github.com/jesseduffield/lazygit/pkg/config"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestValidateCustomCommandOptionKeys is a function.
+func TestValidateCustomCommandOptionKeys(t *testing.T) {
+	type scenario struct {
+		testName string
+		key      string
+		expected []string
+	}
+
+	scenarios := []scenario{
+		{"no key", "", []string{}},
+		{"a free key", "f", []string{}},
+		{"an unrecognised key", "<nope>", []string{"unrecognised key '<nope>'. For permitted values see https://github.com/jesseduffield/lazygit/blob/master/docs/keybindings/Custom_Keybindings.md"}},
+		{"the key for filtering the menu", "/", []string{"'/' is one of the menu's own keys"}},
+		{"the key for pressing an item", "<ENTER>", []string{"'<ENTER>' is one of the menu's own keys"}},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.testName, func(t *testing.T) {
+			userConfig := config.GetDefaultConfig()
+			userConfig.CustomCommands = []config.CustomCommand{
+				{
+					Context: "global",
+					Command: "echo {{index .PromptResponses 0}}",
+					Prompts: []config.CustomCommandPrompt{
+						{Type: "menu", Options: []config.CustomCommandMenuOption{{Name: "option", Key: s.key}}},
+					},
+				},
+			}
+
+			messages := []string{}
+			for _, invalidValue := range validateCustomCommands(userConfig) {
+				messages = append(messages, invalidValue.Message)
+			}
+			assert.EqualValues(t, s.expected, messages)
+		})
+	}
+}
@@ -195,7 +195,7 @@ func (gui *Gui) prepareConfirmationPanel(title, prompt string, hasLoader bool, f
 		}
 		suggestionsView.Wrap = true
 		suggestionsView.FgColor = theme.GocuiDefaultTextColor
-		gui.setSuggestions([]*types.Suggestion{})
+		gui.setSuggestions(findSuggestionsFunc(prompt))
 		suggestionsView.Visible = true
 	}
 
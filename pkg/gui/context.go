@@ -24,6 +24,8 @@ const (
 	REMOTES_CONTEXT_KEY             ContextKey = "remotes"
 	REMOTE_BRANCHES_CONTEXT_KEY     ContextKey = "remoteBranches"
 	TAGS_CONTEXT_KEY                ContextKey = "tags"
+	WORKTREES_CONTEXT_KEY           ContextKey = "worktrees"
+	PULL_REQUESTS_CONTEXT_KEY       ContextKey = "pullRequests"
 	BRANCH_COMMITS_CONTEXT_KEY      ContextKey = "commits"
 	REFLOG_COMMITS_CONTEXT_KEY      ContextKey = "reflogCommits"
 	SUB_COMMITS_CONTEXT_KEY         ContextKey = "subCommits"
@@ -33,6 +35,7 @@ const (
 	MAIN_MERGING_CONTEXT_KEY        ContextKey = "merging"
 	MAIN_PATCH_BUILDING_CONTEXT_KEY ContextKey = "patchBuilding"
 	MAIN_STAGING_CONTEXT_KEY        ContextKey = "staging"
+	MAIN_BLAME_CONTEXT_KEY          ContextKey = "blame"
 	MENU_CONTEXT_KEY                ContextKey = "menu"
 	CREDENTIALS_CONTEXT_KEY         ContextKey = "credentials"
 	CONFIRMATION_CONTEXT_KEY        ContextKey = "confirmation"
@@ -49,6 +52,8 @@ var allContextKeys = []ContextKey{
 	REMOTES_CONTEXT_KEY,
 	REMOTE_BRANCHES_CONTEXT_KEY,
 	TAGS_CONTEXT_KEY,
+	WORKTREES_CONTEXT_KEY,
+	PULL_REQUESTS_CONTEXT_KEY,
 	BRANCH_COMMITS_CONTEXT_KEY,
 	REFLOG_COMMITS_CONTEXT_KEY,
 	SUB_COMMITS_CONTEXT_KEY,
@@ -58,6 +63,7 @@ var allContextKeys = []ContextKey{
 	MAIN_MERGING_CONTEXT_KEY,
 	MAIN_PATCH_BUILDING_CONTEXT_KEY,
 	MAIN_STAGING_CONTEXT_KEY,
+	MAIN_BLAME_CONTEXT_KEY,
 	MENU_CONTEXT_KEY,
 	CREDENTIALS_CONTEXT_KEY,
 	CONFIRMATION_CONTEXT_KEY,
@@ -76,6 +82,8 @@ type ContextTree struct {
 	Remotes        *ListContext
 	RemoteBranches *ListContext
 	Tags           *ListContext
+	Worktrees      *ListContext
+	PullRequests   *ListContext
 	BranchCommits  *ListContext
 	CommitFiles    *ListContext
 	ReflogCommits  *ListContext
@@ -86,6 +94,7 @@ type ContextTree struct {
 	Staging        Context
 	PatchBuilding  Context
 	Merging        Context
+	Blame          Context
 	Credentials    Context
 	Confirmation   Context
 	CommitMessage  Context
@@ -101,6 +110,8 @@ func (gui *Gui) allContexts() []Context {
 		gui.State.Contexts.Remotes,
 		gui.State.Contexts.RemoteBranches,
 		gui.State.Contexts.Tags,
+		gui.State.Contexts.Worktrees,
+		gui.State.Contexts.PullRequests,
 		gui.State.Contexts.BranchCommits,
 		gui.State.Contexts.CommitFiles,
 		gui.State.Contexts.ReflogCommits,
@@ -112,6 +123,7 @@ func (gui *Gui) allContexts() []Context {
 		gui.State.Contexts.Normal,
 		gui.State.Contexts.Staging,
 		gui.State.Contexts.Merging,
+		gui.State.Contexts.Blame,
 		gui.State.Contexts.PatchBuilding,
 		gui.State.Contexts.SubCommits,
 		gui.State.Contexts.Suggestions,
@@ -217,6 +229,8 @@ func (gui *Gui) contextTree() ContextTree {
 		SubCommits:     gui.subCommitsListContext(),
 		Branches:       gui.branchesListContext(),
 		Tags:           gui.tagsListContext(),
+		Worktrees:      gui.worktreesListContext(),
+		PullRequests:   gui.pullRequestsListContext(),
 		Stash:          gui.stashListContext(),
 		Normal: BasicContext{
 			OnFocus: func() error {
@@ -253,6 +267,13 @@ func (gui *Gui) contextTree() ContextTree {
 			Key:             MAIN_MERGING_CONTEXT_KEY,
 			OnGetOptionsMap: gui.getMergingOptions,
 		},
+		Blame: BasicContext{
+			OnFocus:         gui.refreshBlamePanel,
+			Kind:            MAIN_CONTEXT,
+			ViewName:        "main",
+			Key:             MAIN_BLAME_CONTEXT_KEY,
+			OnGetOptionsMap: gui.getBlameOptions,
+		},
 		Credentials: BasicContext{
 			OnFocus:  gui.handleCredentialsViewFocused,
 			Kind:     PERSISTENT_POPUP,
@@ -327,6 +348,14 @@ func (tree ContextTree) initialViewTabContextMap() map[string][]tabContext {
 				tab:      "Tags",
 				contexts: []Context{tree.Tags},
 			},
+			{
+				tab:      "Worktrees",
+				contexts: []Context{tree.Worktrees},
+			},
+			{
+				tab:      "Pull Requests",
+				contexts: []Context{tree.PullRequests},
+			},
 		},
 		"commits": {
 			{
@@ -476,6 +505,12 @@ func (gui *Gui) deactivateContext(c Context) error {
 		}
 	}
 
+	if c == gui.State.Searching.filterContext {
+		if err := gui.clearFilter(); err != nil {
+			return err
+		}
+	}
+
 	// if we are the kind of context that is sent to back upon deactivation, we should do that
 	if view != nil && c.GetKind() == TEMPORARY_POPUP || c.GetKind() == PERSISTENT_POPUP || c.GetKey() == COMMIT_FILES_CONTEXT_KEY {
 		view.Visible = false
@@ -700,7 +735,7 @@ func (gui *Gui) changeMainViewsContext(contextKey ContextKey) {
 	}
 
 	switch contextKey {
-	case MAIN_NORMAL_CONTEXT_KEY, MAIN_PATCH_BUILDING_CONTEXT_KEY, MAIN_STAGING_CONTEXT_KEY, MAIN_MERGING_CONTEXT_KEY:
+	case MAIN_NORMAL_CONTEXT_KEY, MAIN_PATCH_BUILDING_CONTEXT_KEY, MAIN_STAGING_CONTEXT_KEY, MAIN_MERGING_CONTEXT_KEY, MAIN_BLAME_CONTEXT_KEY:
 		gui.Views.Main.Context = string(contextKey)
 		gui.Views.Secondary.Context = string(contextKey)
 	default:
@@ -0,0 +1,269 @@
+package gui
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"strings"
+
+	"github.com/fatih/color"
+	"github.com/jesseduffield/lazygit/pkg/commands/hosting"
+	"github.com/jesseduffield/lazygit/pkg/commands/models"
+	"github.com/jesseduffield/lazygit/pkg/utils"
+)
+
+// pullRequestForm is what we're going to create a pull request with. The form
+// itself is a menu which we bring back up after each field is edited
+type pullRequestForm struct {
+	client hosting.Client
+	opts   hosting.CreatePullRequestOpts
+	// the remote which the head and base branches are on
+	remoteName string
+	// we suggest a title and body from the branch's commits, which we redo
+	// whenever the base changes, until the user writes their own
+	contentEdited bool
+}
+
+func (gui *Gui) createPullRequestForm(client hosting.Client, branch *models.Branch) error {
+	remoteName := gui.GitCommand.TrackedRemoteName()
+	if !gui.GitCommand.CheckRemoteBranchExists(remoteName, branch) {
+		return gui.createErrorPanel(gui.Tr.NoBranchOnRemote)
+	}
+
+	baseBranches := gui.pullRequestBaseBranches(remoteName, branch)
+	if len(baseBranches) == 0 {
+		return gui.createErrorPanel(gui.Tr.NoPullRequestBaseBranches)
+	}
+
+	form := &pullRequestForm{
+		client:     client,
+		remoteName: remoteName,
+		opts: hosting.CreatePullRequestOpts{
+			HeadBranch: branch.Name,
+			BaseBranch: defaultPullRequestBase(baseBranches),
+		},
+	}
+	if err := gui.suggestPullRequestContent(form); err != nil {
+		return gui.surfaceError(err)
+	}
+
+	return gui.showPullRequestForm(form)
+}
+
+// pullRequestBaseBranches are the branches on the remote that the branch could
+// be merged into
+func (gui *Gui) pullRequestBaseBranches(remoteName string, branch *models.Branch) []string {
+	result := []string{}
+	for _, remote := range gui.State.Remotes {
+		if remote.Name != remoteName {
+			continue
+		}
+		for _, remoteBranch := range remote.Branches {
+			if remoteBranch.Name != branch.Name && remoteBranch.Name != "HEAD" {
+				result = append(result, remoteBranch.Name)
+			}
+		}
+	}
+
+	return result
+}
+
+func defaultPullRequestBase(baseBranches []string) string {
+	for _, candidate := range []string{"main", "master", "develop"} {
+		for _, name := range baseBranches {
+			if name == candidate {
+				return name
+			}
+		}
+	}
+
+	return baseBranches[0]
+}
+
+func (gui *Gui) suggestPullRequestContent(form *pullRequestForm) error {
+	if form.contentEdited {
+		return nil
+	}
+
+	title, body, err := gui.GitCommand.GetPullRequestContent(form.remoteName+"/"+form.opts.BaseBranch, form.opts.HeadBranch)
+	if err != nil {
+		return err
+	}
+	form.opts.Title = title
+	form.opts.Body = body
+
+	return nil
+}
+
+func (gui *Gui) showPullRequestForm(form *pullRequestForm) error {
+	field := func(name string, value string, onPress func() error) *menuItem {
+		return &menuItem{
+			displayStrings: []string{name, utils.ColoredString(value, color.FgYellow)},
+			onPress:        onPress,
+		}
+	}
+
+	draft := gui.Tr.LcNo
+	if form.opts.Draft {
+		draft = gui.Tr.LcYes
+	}
+
+	menuItems := []*menuItem{
+		field(gui.Tr.LcPullRequestBase, form.opts.BaseBranch, func() error {
+			return gui.handlePickPullRequestBase(form)
+		}),
+		field(gui.Tr.LcPullRequestTitle, form.opts.Title, func() error {
+			return gui.prompt(promptOpts{
+				title:          gui.Tr.PullRequestTitlePrompt,
+				initialContent: form.opts.Title,
+				handleConfirm: func(title string) error {
+					form.opts.Title = title
+					form.contentEdited = true
+					return gui.showPullRequestForm(form)
+				},
+			})
+		}),
+		field(gui.Tr.LcPullRequestDescription, pullRequestBodySummary(form.opts.Body), func() error {
+			return gui.handleEditPullRequestBody(form)
+		}),
+		field(gui.Tr.LcPullRequestDraft, draft, func() error {
+			form.opts.Draft = !form.opts.Draft
+			return gui.showPullRequestForm(form)
+		}),
+		field(gui.Tr.LcPullRequestReviewers, strings.Join(form.opts.Reviewers, ", "), func() error {
+			return gui.promptForList(gui.Tr.PullRequestReviewersPrompt, form.opts.Reviewers, func(reviewers []string) error {
+				form.opts.Reviewers = reviewers
+				return gui.showPullRequestForm(form)
+			})
+		}),
+		field(gui.Tr.LcPullRequestLabels, strings.Join(form.opts.Labels, ", "), func() error {
+			return gui.promptForList(gui.Tr.PullRequestLabelsPrompt, form.opts.Labels, func(labels []string) error {
+				form.opts.Labels = labels
+				return gui.showPullRequestForm(form)
+			})
+		}),
+		{
+			displayStrings: []string{utils.ColoredString(gui.Tr.LcSubmitPullRequest, color.FgGreen)},
+			onPress: func() error {
+				return gui.submitPullRequest(form)
+			},
+		},
+	}
+
+	return gui.createMenu(gui.Tr.CreatePullRequestTitle, menuItems, createMenuOptions{showCancel: true})
+}
+
+// pullRequestBodySummary fits the body on one line of the form
+func pullRequestBodySummary(body string) string {
+	lines := utils.SplitLines(body)
+	if len(lines) == 0 {
+		return ""
+	}
+	if len(lines) == 1 {
+		return utils.TruncateWithEllipsis(lines[0], 50)
+	}
+
+	return fmt.Sprintf("%s (+%d)", utils.TruncateWithEllipsis(lines[0], 40), len(lines)-1)
+}
+
+func (gui *Gui) handlePickPullRequestBase(form *pullRequestForm) error {
+	branch := &models.Branch{Name: form.opts.HeadBranch}
+	baseBranches := gui.pullRequestBaseBranches(form.remoteName, branch)
+
+	menuItems := make([]*menuItem, len(baseBranches))
+	for i, name := range baseBranches {
+		name := name
+		menuItems[i] = &menuItem{
+			displayString: name,
+			onPress: func() error {
+				form.opts.BaseBranch = name
+				if err := gui.suggestPullRequestContent(form); err != nil {
+					return gui.surfaceError(err)
+				}
+				return gui.showPullRequestForm(form)
+			},
+		}
+	}
+
+	return gui.createMenu(gui.Tr.PullRequestBaseTitle, menuItems, createMenuOptions{showCancel: true})
+}
+
+// handleEditPullRequestBody has the user write the body in their editor, given
+// it usually spans several lines
+func (gui *Gui) handleEditPullRequestBody(form *pullRequestForm) error {
+	file, err := ioutil.TempFile("", "lazygit-pull-request-*.md")
+	if err != nil {
+		return gui.surfaceError(err)
+	}
+	defer os.Remove(file.Name())
+
+	if _, err := file.WriteString(form.opts.Body + "\n"); err != nil {
+		file.Close()
+		return gui.surfaceError(err)
+	}
+	if err := file.Close(); err != nil {
+		return gui.surfaceError(err)
+	}
+
+	sub, err := gui.withSpan(gui.Tr.SpanEditFile).EditFile(file.Name())
+	if err != nil {
+		return gui.surfaceError(err)
+	}
+	if err := gui.runSubprocessWithSuspense(gui.Tr.SpanEditFile, sub); err != nil {
+		return err
+	}
+
+	content, err := ioutil.ReadFile(file.Name())
+	if err != nil {
+		return gui.surfaceError(err)
+	}
+	form.opts.Body = strings.TrimSpace(string(content))
+	form.contentEdited = true
+
+	return gui.showPullRequestForm(form)
+}
+
+// promptForList prompts for a comma-separated list
+func (gui *Gui) promptForList(title string, initial []string, handleConfirm func([]string) error) error {
+	return gui.prompt(promptOpts{
+		title:          title,
+		initialContent: strings.Join(initial, ", "),
+		handleConfirm: func(response string) error {
+			items := []string{}
+			for _, item := range strings.Split(response, ",") {
+				if item = strings.TrimSpace(item); item != "" {
+					items = append(items, item)
+				}
+			}
+			return handleConfirm(items)
+		},
+	})
+}
+
+func (gui *Gui) submitPullRequest(form *pullRequestForm) error {
+	if strings.TrimSpace(form.opts.Title) == "" {
+		return gui.createErrorPanel(gui.Tr.PullRequestTitleRequired)
+	}
+
+	return gui.WithWaitingStatus(gui.Tr.CreatingPullRequestStatus, func() error {
+		pr, err := form.client.CreatePullRequest(form.opts)
+		if pr != nil {
+			if err := gui.OSCommand.CopyToClipboard(pr.URL); err != nil {
+				gui.Log.Error(err)
+			}
+			gui.raiseToast(utils.ResolvePlaceholderString(
+				gui.Tr.PullRequestCreated,
+				map[string]string{"number": fmt.Sprintf("%d", pr.Number)},
+			))
+
+			if err := gui.refreshSidePanels(refreshOptions{scope: []RefreshableView{PULL_REQUESTS}, mode: ASYNC}); err != nil {
+				return err
+			}
+		}
+		if err != nil {
+			return gui.surfaceError(err)
+		}
+
+		return nil
+	})
+}
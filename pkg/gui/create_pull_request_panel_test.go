@@ -0,0 +1,35 @@
+package gui
+
+import (
+	"testing"
+
+	"github.com/jesseduffield/lazygit/pkg/commands/models"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestPullRequestBaseBranches is a function.
+func TestPullRequestBaseBranches(t *testing.T) {
+	type scenario struct {
+		testName   string
+		remoteName string
+		expected   []string
+	}
+
+	scenarios := []scenario{
+		{"the branch is on origin", "origin", []string{"main"}},
+		{"the branch is on another remote", "upstream", []string{"master", "develop"}},
+		{"there's no such remote", "fork", []string{}},
+	}
+
+	remotes := []*models.Remote{
+		{Name: "origin", Branches: []*models.RemoteBranch{{Name: "HEAD"}, {Name: "main"}, {Name: "feature"}}},
+		{Name: "upstream", Branches: []*models.RemoteBranch{{Name: "master"}, {Name: "feature"}, {Name: "develop"}}},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.testName, func(t *testing.T) {
+			gui := &Gui{State: &guiState{Remotes: remotes}}
+			assert.EqualValues(t, s.expected, gui.pullRequestBaseBranches(s.remoteName, &models.Branch{Name: "feature"}))
+		})
+	}
+}
@@ -1,8 +1,12 @@
 package gui
 
 import (
+	"errors"
 	"log"
+	"os/exec"
+	"regexp"
 	"strings"
+	"sync"
 
 	"github.com/fatih/color"
 	"github.com/jesseduffield/gocui"
@@ -26,9 +30,10 @@ type CustomCommandObjects struct {
 	SelectedCommitFilePath string
 	CheckedOutBranch       *models.Branch
 	PromptResponses        []string
+	Form                   map[string]string
 }
 
-func (gui *Gui) resolveTemplate(templateStr string, promptResponses []string) (string, error) {
+func (gui *Gui) resolveTemplate(templateStr string, promptResponses []string, form map[string]string) (string, error) {
 	objects := CustomCommandObjects{
 		SelectedFile:           gui.getSelectedFile(),
 		SelectedPath:           gui.getSelectedPath(),
@@ -44,23 +49,67 @@ func (gui *Gui) resolveTemplate(templateStr string, promptResponses []string) (s
 		SelectedSubCommit:      gui.getSelectedSubCommit(),
 		CheckedOutBranch:       gui.currentBranch(),
 		PromptResponses:        promptResponses,
+		Form:                   form,
 	}
 
 	return utils.ResolveTemplate(templateStr, objects)
 }
 
+// runCustomCommandInMainView streams the command's output to the main view. The
+// command runs as a task rather than through the OS command, so we call its
+// hooks ourselves in order to log it
+func (gui *Gui) runCustomCommandInMainView(customCommand config.CustomCommand, cmdStr string) error {
+	osCommand := gui.osCommandWithSpan(gui.Tr.SpanCustomCommand)
+	cmd := osCommand.ShellCommandFromString(cmdStr)
+
+	osCommand.BeforeExecuteCmd(cmd)
+
+	var once sync.Once
+	onDone := func() {
+		once.Do(func() {
+			var err error
+			if cmd.ProcessState != nil && !cmd.ProcessState.Success() {
+				err = &exec.ExitError{ProcessState: cmd.ProcessState}
+			}
+			osCommand.AfterExecuteCmd(cmd, err)
+
+			if err := gui.refreshSidePanels(refreshOptions{mode: ASYNC}); err != nil {
+				gui.Log.Error(err)
+			}
+		})
+	}
+
+	return gui.refreshMainViews(refreshMainOpts{
+		main: &viewUpdateOpts{
+			title: customCommandDescription(customCommand),
+			task:  NewStreamCommandTask(cmd, onDone),
+		},
+	})
+}
+
 func (gui *Gui) handleCustomCommandKeybinding(customCommand config.CustomCommand) func() error {
 	return func() error {
 		promptResponses := make([]string, len(customCommand.Prompts))
+		// the responses to the prompts with a key, by that key
+		form := map[string]string{}
 
 		f := func() error {
-			cmdStr, err := gui.resolveTemplate(customCommand.Command, promptResponses)
+			cmdStr, err := gui.resolveTemplate(customCommand.Command, promptResponses, form)
 			if err != nil {
 				return gui.surfaceError(err)
 			}
 
 			if customCommand.Subprocess {
-				return gui.runSubprocessWithSuspense(gui.OSCommand.PrepareShellSubProcess(cmdStr))
+				return gui.runSubprocessWithSuspense(gui.Tr.SpanCustomCommand, gui.OSCommand.PrepareShellSubProcess(cmdStr))
+			}
+
+			if customCommand.Output == "mainView" {
+				// when run from the options menu, going back to the command's
+				// context re-renders the main view, so we queue up behind that
+				gui.g.Update(func(*gocui.Gui) error {
+					return gui.runCustomCommandInMainView(customCommand, cmdStr)
+				})
+				return nil
 			}
 
 			loadingText := customCommand.LoadingText
@@ -68,10 +117,16 @@ func (gui *Gui) handleCustomCommandKeybinding(customCommand config.CustomCommand
 				loadingText = gui.Tr.LcRunningCustomCommandStatus
 			}
 			return gui.WithWaitingStatus(loadingText, func() error {
-				if err := gui.OSCommand.RunShellCommand(cmdStr); err != nil {
+				output, err := gui.osCommandWithSpan(gui.Tr.SpanCustomCommand).RunShellCommandWithOutput(cmdStr)
+				if err != nil {
 					return gui.surfaceError(err)
 				}
-				return gui.refreshSidePanels(refreshOptions{})
+
+				if err := gui.refreshSidePanels(refreshOptions{}); err != nil {
+					return err
+				}
+
+				return gui.showCustomCommandOutput(customCommand, output)
 			})
 		}
 
@@ -86,15 +141,24 @@ func (gui *Gui) handleCustomCommandKeybinding(customCommand config.CustomCommand
 			// need to do this because f's value will change with each iteration
 			wrappedF := f
 
+			respond := func(response string) error {
+				promptResponses[idx] = response
+				if prompt.Key != "" {
+					form[prompt.Key] = response
+				}
+
+				return wrappedF()
+			}
+
 			switch prompt.Type {
 			case "input":
 				f = func() error {
-					title, err := gui.resolveTemplate(prompt.Title, promptResponses)
+					title, err := gui.resolveTemplate(prompt.Title, promptResponses, form)
 					if err != nil {
 						return gui.surfaceError(err)
 					}
 
-					initialValue, err := gui.resolveTemplate(prompt.InitialValue, promptResponses)
+					initialValue, err := gui.resolveTemplate(prompt.InitialValue, promptResponses, form)
 					if err != nil {
 						return gui.surfaceError(err)
 					}
@@ -102,11 +166,7 @@ func (gui *Gui) handleCustomCommandKeybinding(customCommand config.CustomCommand
 					return gui.prompt(promptOpts{
 						title:          title,
 						initialContent: initialValue,
-						handleConfirm: func(str string) error {
-							promptResponses[idx] = str
-
-							return wrappedF()
-						},
+						handleConfirm:  respond,
 					})
 				}
 			case "menu":
@@ -121,17 +181,17 @@ func (gui *Gui) handleCustomCommandKeybinding(customCommand config.CustomCommand
 							// this allows you to only pass values rather than bother with names/descriptions
 							nameTemplate = option.Value
 						}
-						name, err := gui.resolveTemplate(nameTemplate, promptResponses)
+						name, err := gui.resolveTemplate(nameTemplate, promptResponses, form)
 						if err != nil {
 							return gui.surfaceError(err)
 						}
 
-						description, err := gui.resolveTemplate(option.Description, promptResponses)
+						description, err := gui.resolveTemplate(option.Description, promptResponses, form)
 						if err != nil {
 							return gui.surfaceError(err)
 						}
 
-						value, err := gui.resolveTemplate(option.Value, promptResponses)
+						value, err := gui.resolveTemplate(option.Value, promptResponses, form)
 						if err != nil {
 							return gui.surfaceError(err)
 						}
@@ -139,22 +199,92 @@ func (gui *Gui) handleCustomCommandKeybinding(customCommand config.CustomCommand
 						menuItems[i] = &menuItem{
 							displayStrings: []string{name, utils.ColoredString(description, color.FgYellow)},
 							onPress: func() error {
-								promptResponses[idx] = value
-
-								return wrappedF()
+								return respond(value)
 							},
 						}
+						if option.Key != "" {
+							menuItems[i].key = gui.getKey(option.Key)
+						}
 					}
 
-					title, err := gui.resolveTemplate(prompt.Title, promptResponses)
+					title, err := gui.resolveTemplate(prompt.Title, promptResponses, form)
 					if err != nil {
 						return gui.surfaceError(err)
 					}
 
 					return gui.createMenu(title, menuItems, createMenuOptions{showCancel: true})
 				}
+			case "menuFromCommand":
+				f = func() error {
+					cmdStr, err := gui.resolveTemplate(prompt.Command, promptResponses, form)
+					if err != nil {
+						return gui.surfaceError(err)
+					}
+
+					title, err := gui.resolveTemplate(prompt.Title, promptResponses, form)
+					if err != nil {
+						return gui.surfaceError(err)
+					}
+
+					return gui.WithWaitingStatus(gui.Tr.LcRunningCustomCommandStatus, func() error {
+						output, err := gui.OSCommand.RunShellCommandWithStdout(cmdStr)
+						if err != nil {
+							return err
+						}
+
+						options, err := menuOptionsFromCommandOutput(output, prompt.Filter)
+						if err != nil {
+							return err
+						}
+						if len(options) == 0 {
+							return errors.New(utils.ResolvePlaceholderString(gui.Tr.NoMenuOptionsFromCommand, map[string]string{"command": cmdStr}))
+						}
+
+						menuItems := make([]*menuItem, len(options))
+						for i, option := range options {
+							option := option
+
+							name := option.Name
+							if name == "" {
+								name = option.Value
+							}
+
+							menuItems[i] = &menuItem{
+								displayStrings: []string{name, utils.ColoredString(option.Description, color.FgYellow)},
+								onPress: func() error {
+									return respond(option.Value)
+								},
+							}
+						}
+
+						gui.g.Update(func(*gocui.Gui) error {
+							return gui.createMenu(title, menuItems, createMenuOptions{showCancel: true})
+						})
+						return nil
+					})
+				}
+			case "confirm":
+				f = func() error {
+					title, err := gui.resolveTemplate(prompt.Title, promptResponses, form)
+					if err != nil {
+						return gui.surfaceError(err)
+					}
+
+					body, err := gui.resolveTemplate(prompt.Body, promptResponses, form)
+					if err != nil {
+						return gui.surfaceError(err)
+					}
+
+					return gui.ask(askOpts{
+						title:  title,
+						prompt: body,
+						handleConfirm: func() error {
+							return respond("true")
+						},
+					})
+				}
 			default:
-				return gui.createErrorPanel("custom command prompt must have a type of 'input' or 'menu'")
+				return gui.createErrorPanel("custom command prompt must have a type of 'input', 'menu', 'menuFromCommand' or 'confirm'")
 			}
 
 		}
@@ -163,6 +293,100 @@ func (gui *Gui) handleCustomCommandKeybinding(customCommand config.CustomCommand
 	}
 }
 
+// showCustomCommandOutput shows the output of a custom command wherever the
+// command asks for it to be shown
+func (gui *Gui) showCustomCommandOutput(customCommand config.CustomCommand, output string) error {
+	switch customCommand.Output {
+	case "popup":
+		if strings.TrimSpace(output) == "" {
+			output = gui.Tr.CustomCommandNoOutput
+		}
+
+		return gui.ask(askOpts{
+			title:  customCommandDescription(customCommand),
+			prompt: strings.TrimRight(output, "\n"),
+		})
+	case "log":
+		gui.CmdLog.SetOutput(gui.Tr.SpanCustomCommand, output)
+
+		gui.g.Update(func(*gocui.Gui) error {
+			if !gui.showCmdLog {
+				return gui.handleToggleCmdLog()
+			}
+			return nil
+		})
+	}
+
+	return nil
+}
+
+// customCommandConditionHolds tells whether the given condition template comes
+// out as 'true'. A template which can't be resolved, e.g. because there's no
+// selected commit for it to look at, doesn't hold
+func (gui *Gui) customCommandConditionHolds(condition string) bool {
+	result, err := gui.resolveTemplate(condition, nil, map[string]string{})
+	if err != nil {
+		gui.Log.Warn(err)
+		return false
+	}
+
+	return strings.TrimSpace(result) == "true"
+}
+
+func customCommandDescription(customCommand config.CustomCommand) string {
+	if customCommand.Description != "" {
+		return customCommand.Description
+	}
+
+	return customCommand.Command
+}
+
+// menuOptionsFromCommandOutput turns each line of a command's output into a
+// menu option. If there's a filter, we skip the lines which don't match it, and
+// take the name, value and description of each option from the filter's
+// capture groups of those names. Without a value group the value is the whole
+// match
+func menuOptionsFromCommandOutput(output string, filter string) ([]config.CustomCommandMenuOption, error) {
+	if filter == "" {
+		// each line is an option in its own right
+		filter = "^.*$"
+	}
+
+	regex, err := regexp.Compile(filter)
+	if err != nil {
+		return nil, err
+	}
+	groupNames := regex.SubexpNames()
+
+	options := []config.CustomCommandMenuOption{}
+	for _, line := range strings.Split(output, "\n") {
+		line = strings.TrimRight(line, "\r")
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+
+		match := regex.FindStringSubmatch(line)
+		if match == nil {
+			continue
+		}
+
+		option := config.CustomCommandMenuOption{Value: match[0]}
+		for i, groupName := range groupNames {
+			switch groupName {
+			case "name":
+				option.Name = match[i]
+			case "value":
+				option.Value = match[i]
+			case "description":
+				option.Description = match[i]
+			}
+		}
+		options = append(options, option)
+	}
+
+	return options, nil
+}
+
 func (gui *Gui) GetCustomCommandKeybindings() []*Binding {
 	bindings := []*Binding{}
 	customCommands := gui.Config.GetUserConfig().CustomCommands
@@ -192,19 +416,27 @@ func (gui *Gui) GetCustomCommandKeybindings() []*Binding {
 			contexts = []string{customCommand.Context}
 		}
 
-		description := customCommand.Description
-		if description == "" {
-			description = customCommand.Command
-		}
-
-		bindings = append(bindings, &Binding{
+		binding := &Binding{
 			ViewName:    viewName,
 			Contexts:    contexts,
-			Key:         gui.getKey(customCommand.Key),
 			Modifier:    gocui.ModNone,
 			Handler:     gui.handleCustomCommandKeybinding(customCommand),
-			Description: description,
-		})
+			Description: customCommandDescription(customCommand),
+		}
+
+		// without a key the command is only in the options menu
+		if customCommand.Key != "" {
+			binding.Key = gui.getKey(customCommand.Key)
+		}
+
+		if customCommand.Condition != "" {
+			condition := customCommand.Condition
+			binding.Condition = func() bool {
+				return gui.customCommandConditionHolds(condition)
+			}
+		}
+
+		bindings = append(bindings, binding)
 	}
 
 	return bindings
@@ -0,0 +1,77 @@
+package gui
+
+import (
+	"testing"
+
+	"github.com/jesseduffield/gocui"
+	"github.com/jesseduffield/lazygit/pkg/commands"
+	"github.com/jesseduffield/lazygit/pkg/commands/oscommands"
+	"github.com/jesseduffield/lazygit/pkg/config"
+	"github.com/jesseduffield/lazygit/pkg/i18n"
+	"github.com/jesseduffield/lazygit/pkg/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestMenuOptionsFromCommandOutput is a function.
+func TestMenuOptionsFromCommandOutput(t *testing.T) {
+	type scenario struct {
+		testName string
+		output   string
+		filter   string
+		expected []config.CustomCommandMenuOption
+	}
+
+	scenarios := []scenario{
+		{
+			"no filter",
+			"origin/master\norigin/develop\n\n",
+			"",
+			[]config.CustomCommandMenuOption{
+				{Value: "origin/master"},
+				{Value: "origin/develop"},
+			},
+		},
+		{
+			"named groups",
+			"a1b2c3 fix the thing\r\nd4e5f6 add another thing\r\n",
+			`^(?P<value>[0-9a-f]+) (?P<description>.*)$`,
+			[]config.CustomCommandMenuOption{
+				{Value: "a1b2c3", Description: "fix the thing"},
+				{Value: "d4e5f6", Description: "add another thing"},
+			},
+		},
+		{
+			"lines not matching the filter are skipped",
+			"PROJ-12 In Progress Fix login\nheader\nPROJ-13 Done Add logout\n",
+			`^(?P<name>PROJ-\d+) (?:In Progress|Done) (?P<description>.*)$`,
+			[]config.CustomCommandMenuOption{
+				{Name: "PROJ-12", Value: "PROJ-12 In Progress Fix login", Description: "Fix login"},
+				{Name: "PROJ-13", Value: "PROJ-13 Done Add logout", Description: "Add logout"},
+			},
+		},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.testName, func(t *testing.T) {
+			options, err := menuOptionsFromCommandOutput(s.output, s.filter)
+			assert.NoError(t, err)
+			assert.EqualValues(t, s.expected, options)
+		})
+	}
+}
+
+// TestSetMenuKeybindingsWithKeylessCustomCommand is a function.
+func TestSetMenuKeybindingsWithKeylessCustomCommand(t *testing.T) {
+	appConfig := config.NewDummyAppConfig()
+	appConfig.GetUserConfig().CustomCommands = []config.CustomCommand{
+		{Context: "menu", Command: "echo hello"},
+	}
+
+	gui, err := NewGui(utils.NewDummyLog(), commands.NewDummyGitCommand(), oscommands.NewDummyOSCommand(), i18n.NewTranslationSet(utils.NewDummyLog()), appConfig, nil, "", false)
+	assert.NoError(t, err)
+
+	// setting keybindings doesn't need a terminal
+	gui.g = &gocui.Gui{}
+
+	assert.NoError(t, gui.setMenuKeybindings([]*menuItem{{displayString: "item", onPress: func() error { return nil }}}))
+}
@@ -48,6 +48,13 @@ func (gui *Gui) currentDiffTerminals() []string {
 			return names
 		}
 		return nil
+	case PULL_REQUESTS_CONTEXT_KEY:
+		// a pull request's ID is its URL, which git knows nothing about
+		pr := gui.getSelectedPullRequest()
+		if pr != nil {
+			return []string{pr.HeadSha}
+		}
+		return nil
 	default:
 		context := gui.currentSideListContext()
 		if context == nil {
@@ -1,6 +1,16 @@
 package gui
 
+import (
+	"fmt"
+
+	"github.com/jesseduffield/lazygit/pkg/utils"
+)
+
 func (gui *Gui) handleCreateDiscardMenu() error {
+	if gui.State.Panels.Files.IsRangeSelecting() {
+		return gui.handleDiscardSelectedRange()
+	}
+
 	node := gui.getSelectedFileNode()
 	if node == nil {
 		return nil
@@ -12,7 +22,7 @@ func (gui *Gui) handleCreateDiscardMenu() error {
 			{
 				displayString: gui.Tr.LcDiscardAllChanges,
 				onPress: func() error {
-					if err := gui.GitCommand.DiscardAllDirChanges(node); err != nil {
+					if err := gui.withSpan(gui.Tr.SpanDiscardAllChanges).DiscardAllDirChanges(node); err != nil {
 						return gui.surfaceError(err)
 					}
 					return gui.refreshSidePanels(refreshOptions{mode: ASYNC, scope: []RefreshableView{FILES}})
@@ -24,7 +34,7 @@ func (gui *Gui) handleCreateDiscardMenu() error {
 			menuItems = append(menuItems, &menuItem{
 				displayString: gui.Tr.LcDiscardUnstagedChanges,
 				onPress: func() error {
-					if err := gui.GitCommand.DiscardUnstagedDirChanges(node); err != nil {
+					if err := gui.withSpan(gui.Tr.SpanDiscardUnstagedChanges).DiscardUnstagedDirChanges(node); err != nil {
 						return gui.surfaceError(err)
 					}
 
@@ -52,7 +62,7 @@ func (gui *Gui) handleCreateDiscardMenu() error {
 				{
 					displayString: gui.Tr.LcDiscardAllChanges,
 					onPress: func() error {
-						if err := gui.GitCommand.DiscardAllFileChanges(file); err != nil {
+						if err := gui.withSpan(gui.Tr.SpanDiscardAllChanges).DiscardAllFileChanges(file); err != nil {
 							return gui.surfaceError(err)
 						}
 						return gui.refreshSidePanels(refreshOptions{mode: ASYNC, scope: []RefreshableView{FILES}})
@@ -64,7 +74,7 @@ func (gui *Gui) handleCreateDiscardMenu() error {
 				menuItems = append(menuItems, &menuItem{
 					displayString: gui.Tr.LcDiscardUnstagedChanges,
 					onPress: func() error {
-						if err := gui.GitCommand.DiscardUnstagedFileChanges(file); err != nil {
+						if err := gui.withSpan(gui.Tr.SpanDiscardUnstagedChanges).DiscardUnstagedFileChanges(file); err != nil {
 							return gui.surfaceError(err)
 						}
 
@@ -77,3 +87,26 @@ func (gui *Gui) handleCreateDiscardMenu() error {
 
 	return gui.createMenu(node.GetPath(), menuItems, createMenuOptions{showCancel: true})
 }
+
+func (gui *Gui) handleDiscardSelectedRange() error {
+	nodes := gui.getSelectedFileNodes()
+
+	return gui.ask(askOpts{
+		title: gui.Tr.DiscardSelectedTitle,
+		prompt: utils.ResolvePlaceholderString(
+			gui.Tr.DiscardSelectedPrompt,
+			map[string]string{"count": fmt.Sprintf("%d", len(nodes))},
+		),
+		handleConfirm: func() error {
+			gui.State.Panels.Files.CancelRangeSelect()
+
+			for _, node := range nodes {
+				if err := gui.withSpan(gui.Tr.SpanDiscardAllChanges).DiscardAllDirChanges(node); err != nil {
+					return gui.surfaceError(err)
+				}
+			}
+
+			return gui.refreshSidePanels(refreshOptions{mode: ASYNC, scope: []RefreshableView{FILES}})
+		},
+	})
+}
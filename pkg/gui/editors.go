@@ -1,6 +1,7 @@
 package gui
 
 import (
+	"strings"
 	"unicode"
 
 	"github.com/jesseduffield/gocui"
@@ -94,3 +95,17 @@ func (gui *Gui) defaultEditor(v *gocui.View, key gocui.Key, ch rune, mod gocui.M
 
 	return matched
 }
+
+// searchEditor narrows down the list being filtered as you type, if you're
+// filtering rather than searching
+func (gui *Gui) searchEditor(v *gocui.View, key gocui.Key, ch rune, mod gocui.Modifier) bool {
+	matched := gocui.DefaultEditor.Edit(v, key, ch, mod)
+
+	if !gui.State.Searching.isSearching {
+		if err := gui.onFilterChange(strings.TrimSpace(v.Buffer())); err != nil {
+			gui.Log.Error(err)
+		}
+	}
+
+	return matched
+}
@@ -24,6 +24,33 @@ func (gui *Gui) getSelectedFileNode() *filetree.FileNode {
 	return gui.State.FileManager.GetItemAtIndex(selectedLine)
 }
 
+// getSelectedFileNodes returns the nodes in the selected range, leaving out any
+// which are within a selected directory so that we don't act on them twice
+func (gui *Gui) getSelectedFileNodes() []*filetree.FileNode {
+	startIdx, endIdx := gui.State.Contexts.Files.getSelectedRange()
+
+	nodes := []*filetree.FileNode{}
+	for i := startIdx; i <= endIdx; i++ {
+		node := gui.State.FileManager.GetItemAtIndex(i)
+		if node == nil {
+			continue
+		}
+
+		withinSelectedDir := false
+		for _, selectedNode := range nodes {
+			if !selectedNode.IsLeaf() && strings.HasPrefix(node.GetPath(), selectedNode.GetPath()+"/") {
+				withinSelectedDir = true
+				break
+			}
+		}
+		if !withinSelectedDir {
+			nodes = append(nodes, node)
+		}
+	}
+
+	return nodes
+}
+
 func (gui *Gui) getSelectedFile() *models.File {
 	node := gui.getSelectedFileNode()
 	if node == nil {
@@ -42,7 +69,7 @@ func (gui *Gui) getSelectedPath() string {
 }
 
 func (gui *Gui) selectFile(alreadySelected bool) error {
-	gui.Views.Files.FocusPoint(0, gui.State.Panels.Files.SelectedLineIdx)
+	gui.State.Contexts.Files.focusSelectedLine(gui.Views.Files)
 
 	node := gui.getSelectedFileNode()
 
@@ -168,7 +195,7 @@ func (gui *Gui) stageSelectedFile() error {
 		return nil
 	}
 
-	return gui.GitCommand.StageFile(file.Name)
+	return gui.withSpan(gui.Tr.SpanStageFile).StageFile(file.Name)
 }
 
 func (gui *Gui) handleEnterFile() error {
@@ -205,6 +232,10 @@ func (gui *Gui) enterFile(forceSecondaryFocused bool, selectedLineIdx int) error
 }
 
 func (gui *Gui) handleFilePress() error {
+	if gui.State.Panels.Files.IsRangeSelecting() {
+		return gui.handleToggleStagedRange()
+	}
+
 	node := gui.getSelectedFileNode()
 	if node == nil {
 		return nil
@@ -218,11 +249,11 @@ func (gui *Gui) handleFilePress() error {
 		}
 
 		if file.HasUnstagedChanges {
-			if err := gui.GitCommand.StageFile(file.Name); err != nil {
+			if err := gui.withSpan(gui.Tr.SpanStageFile).StageFile(file.Name); err != nil {
 				return gui.surfaceError(err)
 			}
 		} else {
-			if err := gui.GitCommand.UnStageFile(file.Names(), file.Tracked); err != nil {
+			if err := gui.withSpan(gui.Tr.SpanUnstageFile).UnStageFile(file.Names(), file.Tracked); err != nil {
 				return gui.surfaceError(err)
 			}
 		}
@@ -234,17 +265,64 @@ func (gui *Gui) handleFilePress() error {
 		}
 
 		if node.GetHasUnstagedChanges() {
-			if err := gui.GitCommand.StageFile(node.Path); err != nil {
+			if err := gui.withSpan(gui.Tr.SpanStageFile).StageFile(node.Path); err != nil {
 				return gui.surfaceError(err)
 			}
 		} else {
 			// pretty sure it doesn't matter that we're always passing true here
-			if err := gui.GitCommand.UnStageFile([]string{node.Path}, true); err != nil {
+			if err := gui.withSpan(gui.Tr.SpanUnstageFile).UnStageFile([]string{node.Path}, true); err != nil {
+				return gui.surfaceError(err)
+			}
+		}
+	}
+
+	if err := gui.refreshSidePanels(refreshOptions{scope: []RefreshableView{FILES}}); err != nil {
+		return err
+	}
+
+	return gui.selectFile(true)
+}
+
+// handleToggleStagedRange stages everything in the selected range, or if it's
+// all staged already, unstages it
+func (gui *Gui) handleToggleStagedRange() error {
+	nodes := gui.getSelectedFileNodes()
+
+	hasUnstagedChanges := false
+	for _, node := range nodes {
+		// we can't stage files with inline merge conflicts, or we'd end up with
+		// those >>>>>> lines actually staged
+		if node.GetHasInlineMergeConflicts() {
+			return gui.createErrorPanel(gui.Tr.ErrStageDirWithInlineMergeConflicts)
+		}
+
+		if node.GetHasUnstagedChanges() {
+			hasUnstagedChanges = true
+		}
+	}
+
+	for _, node := range nodes {
+		if hasUnstagedChanges {
+			path := node.GetPath()
+			if node.IsLeaf() {
+				path = node.File.Name
+			}
+			if err := gui.withSpan(gui.Tr.SpanStageFile).StageFile(path); err != nil {
+				return gui.surfaceError(err)
+			}
+		} else if node.IsLeaf() {
+			if err := gui.withSpan(gui.Tr.SpanUnstageFile).UnStageFile(node.File.Names(), node.File.Tracked); err != nil {
+				return gui.surfaceError(err)
+			}
+		} else {
+			if err := gui.withSpan(gui.Tr.SpanUnstageFile).UnStageFile([]string{node.GetPath()}, true); err != nil {
 				return gui.surfaceError(err)
 			}
 		}
 	}
 
+	gui.State.Panels.Files.CancelRangeSelect()
+
 	if err := gui.refreshSidePanels(refreshOptions{scope: []RefreshableView{FILES}}); err != nil {
 		return err
 	}
@@ -268,9 +346,9 @@ func (gui *Gui) focusAndSelectFile() error {
 func (gui *Gui) handleStageAll() error {
 	var err error
 	if gui.allFilesStaged() {
-		err = gui.GitCommand.UnstageAll()
+		err = gui.withSpan(gui.Tr.SpanUnstageAll).UnstageAll()
 	} else {
-		err = gui.GitCommand.StageAll()
+		err = gui.withSpan(gui.Tr.SpanStageAll).StageAll()
 	}
 	if err != nil {
 		_ = gui.surfaceError(err)
@@ -296,7 +374,7 @@ func (gui *Gui) handleIgnoreFile() error {
 	unstageFiles := func() error {
 		return node.ForEachFile(func(file *models.File) error {
 			if file.HasStagedChanges {
-				if err := gui.GitCommand.UnStageFile(file.Names(), file.Tracked); err != nil {
+				if err := gui.withSpan(gui.Tr.SpanIgnoreFile).UnStageFile(file.Names(), file.Tracked); err != nil {
 					return err
 				}
 			}
@@ -315,11 +393,11 @@ func (gui *Gui) handleIgnoreFile() error {
 					return err
 				}
 
-				if err := gui.GitCommand.RemoveTrackedFiles(node.GetPath()); err != nil {
+				if err := gui.withSpan(gui.Tr.SpanIgnoreFile).RemoveTrackedFiles(node.GetPath()); err != nil {
 					return err
 				}
 
-				if err := gui.GitCommand.Ignore(node.GetPath()); err != nil {
+				if err := gui.withSpan(gui.Tr.SpanIgnoreFile).Ignore(node.GetPath()); err != nil {
 					return err
 				}
 				return gui.refreshSidePanels(refreshOptions{scope: []RefreshableView{FILES}})
@@ -331,7 +409,7 @@ func (gui *Gui) handleIgnoreFile() error {
 		return err
 	}
 
-	if err := gui.GitCommand.Ignore(node.GetPath()); err != nil {
+	if err := gui.withSpan(gui.Tr.SpanIgnoreFile).Ignore(node.GetPath()); err != nil {
 		return gui.surfaceError(err)
 	}
 
@@ -364,7 +442,7 @@ func (gui *Gui) commitPrefixConfigForRepo() *config.CommitPrefixConfig {
 func (gui *Gui) prepareFilesForCommit() error {
 	noStagedFiles := len(gui.stagedFiles()) == 0
 	if noStagedFiles && gui.Config.GetUserConfig().Gui.SkipNoStagedFilesWarning {
-		err := gui.GitCommand.StageAll()
+		err := gui.withSpan(gui.Tr.SpanStageAll).StageAll()
 		if err != nil {
 			return err
 		}
@@ -419,7 +497,7 @@ func (gui *Gui) promptToStageAllAndRetry(retry func() error) error {
 		title:  gui.Tr.NoFilesStagedTitle,
 		prompt: gui.Tr.NoFilesStagedPrompt,
 		handleConfirm: func() error {
-			if err := gui.GitCommand.StageAll(); err != nil {
+			if err := gui.withSpan(gui.Tr.SpanStageAll).StageAll(); err != nil {
 				return gui.surfaceError(err)
 			}
 			if err := gui.refreshFilesAndSubmodules(); err != nil {
@@ -449,7 +527,8 @@ func (gui *Gui) handleAmendCommitPress() error {
 		prompt: gui.Tr.SureToAmend,
 		handleConfirm: func() error {
 			return gui.WithWaitingStatus(gui.Tr.AmendingStatus, func() error {
-				ok, err := gui.runSyncOrAsyncCommand(gui.GitCommand.AmendHead())
+				sub, err := gui.withSpan(gui.Tr.SpanAmendCommit).AmendHead()
+				ok, err := gui.runSyncOrAsyncCommand(gui.Tr.SpanAmendCommit, sub, err)
 				if err != nil {
 					return err
 				}
@@ -475,12 +554,14 @@ func (gui *Gui) handleCommitEditorPress() error {
 	}
 
 	return gui.runSubprocessWithSuspense(
+		gui.Tr.SpanCommit,
 		gui.OSCommand.PrepareSubProcess("git", "commit"),
 	)
 }
 
 func (gui *Gui) editFile(filename string) error {
-	_, err := gui.runSyncOrAsyncCommand(gui.GitCommand.EditFile(filename))
+	sub, err := gui.withSpan(gui.Tr.SpanEditFile).EditFile(filename)
+	_, err = gui.runSyncOrAsyncCommand(gui.Tr.SpanEditFile, sub, err)
 	return err
 }
 
@@ -631,7 +712,7 @@ func (gui *Gui) handlePullFiles() error {
 			title:          gui.Tr.EnterUpstream,
 			initialContent: "origin/" + currentBranch.Name,
 			handleConfirm: func(upstream string) error {
-				if err := gui.GitCommand.SetUpstreamBranch(upstream); err != nil {
+				if err := gui.withSpan(gui.Tr.SpanSetUpstream).SetUpstreamBranch(upstream); err != nil {
 					errorMessage := err.Error()
 					if strings.Contains(errorMessage, "does not exist") {
 						errorMessage = fmt.Sprintf("upstream branch %s not found.\nIf you expect it to exist, you should fetch (with 'f').\nOtherwise, you should push (with 'shift+P')", upstream)
@@ -667,7 +748,7 @@ func (gui *Gui) pullWithMode(mode string, opts PullFilesOptions) error {
 	gui.Mutexes.FetchMutex.Lock()
 	defer gui.Mutexes.FetchMutex.Unlock()
 
-	err := gui.GitCommand.Fetch(
+	err := gui.withSpan(gui.Tr.SpanPull).Fetch(
 		commands.FetchOptions{
 			PromptUserForCredential: gui.promptUserForCredential,
 			RemoteName:              opts.RemoteName,
@@ -681,13 +762,13 @@ func (gui *Gui) pullWithMode(mode string, opts PullFilesOptions) error {
 
 	switch mode {
 	case "rebase":
-		err := gui.GitCommand.RebaseBranch("FETCH_HEAD")
+		err := gui.withSpan(gui.Tr.SpanPull).RebaseBranch("FETCH_HEAD")
 		return gui.handleGenericMergeCommandResult(err)
 	case "merge":
-		err := gui.GitCommand.Merge("FETCH_HEAD", commands.MergeOpts{})
+		err := gui.withSpan(gui.Tr.SpanPull).Merge("FETCH_HEAD", commands.MergeOpts{})
 		return gui.handleGenericMergeCommandResult(err)
 	case "ff-only":
-		err := gui.GitCommand.Merge("FETCH_HEAD", commands.MergeOpts{FastForwardOnly: true})
+		err := gui.withSpan(gui.Tr.SpanPull).Merge("FETCH_HEAD", commands.MergeOpts{FastForwardOnly: true})
 		return gui.handleGenericMergeCommandResult(err)
 	default:
 		return gui.createErrorPanel(fmt.Sprintf("git pull mode '%s' unrecognised", mode))
@@ -700,7 +781,7 @@ func (gui *Gui) pushWithForceFlag(force bool, upstream string, args string) erro
 	}
 	go utils.Safe(func() {
 		branchName := gui.getCheckedOutBranch().Name
-		err := gui.GitCommand.Push(branchName, force, upstream, args, gui.promptUserForCredential)
+		err := gui.withSpan(gui.Tr.SpanPush).Push(branchName, force, upstream, args, gui.promptUserForCredential)
 		if err != nil && !force && strings.Contains(err.Error(), "Updates were rejected") {
 			forcePushDisabled := gui.Config.GetUserConfig().Git.DisableForcePushing
 			if forcePushDisabled {
@@ -789,7 +870,7 @@ func (gui *Gui) handleSwitchToMerge() error {
 }
 
 func (gui *Gui) openFile(filename string) error {
-	if err := gui.OSCommand.OpenFile(filename); err != nil {
+	if err := gui.osCommandWithSpan(gui.Tr.SpanOpenFile).OpenFile(filename); err != nil {
 		return gui.surfaceError(err)
 	}
 	return nil
@@ -809,6 +890,7 @@ func (gui *Gui) handleCustomCommand() error {
 		title: gui.Tr.CustomCommand,
 		handleConfirm: func(command string) error {
 			return gui.runSubprocessWithSuspense(
+				gui.Tr.SpanShellCommand,
 				gui.OSCommand.PrepareShellSubProcess(command),
 			)
 		},
@@ -820,22 +902,85 @@ func (gui *Gui) handleCreateStashMenu() error {
 		{
 			displayString: gui.Tr.LcStashAllChanges,
 			onPress: func() error {
-				return gui.handleStashSave(gui.GitCommand.StashSave)
+				return gui.handleStashSave(gui.withSpan(gui.Tr.SpanStash).StashSave)
 			},
 		},
 		{
 			displayString: gui.Tr.LcStashStagedChanges,
 			onPress: func() error {
-				return gui.handleStashSave(gui.GitCommand.StashSaveStagedChanges)
+				if len(gui.stagedFiles()) == 0 {
+					return gui.createErrorPanel(gui.Tr.NoStagedFilesToStash)
+				}
+				return gui.promptForStashMessage(gui.withSpan(gui.Tr.SpanStashStaged).StashSaveStagedChanges)
+			},
+		},
+		{
+			displayString: gui.Tr.LcStashAllChangesIncludingUntracked,
+			onPress: func() error {
+				return gui.promptForStashMessage(func(message string) error {
+					return gui.withSpan(gui.Tr.SpanStashIncludingUntracked).StashPush(commands.StashPushOpts{
+						Message:          message,
+						IncludeUntracked: true,
+					})
+				})
+			},
+		},
+		{
+			displayString: gui.Tr.LcStashAllChangesKeepIndex,
+			onPress: func() error {
+				return gui.handleStashSave(func(message string) error {
+					return gui.withSpan(gui.Tr.SpanStashKeepIndex).StashPush(commands.StashPushOpts{
+						Message:   message,
+						KeepIndex: true,
+					})
+				})
 			},
 		},
 	}
 
+	nodes := gui.getSelectedFileNodes()
+	if len(nodes) > 0 {
+		displayString := gui.Tr.LcStashSelectedPaths
+		if len(nodes) == 1 {
+			displayString = utils.ResolvePlaceholderString(gui.Tr.LcStashPath, map[string]string{"path": nodes[0].GetPath()})
+		}
+
+		menuItems = append(menuItems, &menuItem{
+			displayString: displayString,
+			onPress: func() error {
+				return gui.handleStashPaths(nodes)
+			},
+		})
+	}
+
 	return gui.createMenu(gui.Tr.LcStashOptions, menuItems, createMenuOptions{showCancel: true})
 }
 
+// handleStashPaths stashes the changes to the given files and directories,
+// including any untracked files among them given the user picked those out
+func (gui *Gui) handleStashPaths(nodes []*filetree.FileNode) error {
+	paths := make([]string, len(nodes))
+	includeUntracked := false
+	for i, node := range nodes {
+		paths[i] = node.GetPath()
+		if node.AnyFile(func(file *models.File) bool { return !file.Tracked }) {
+			includeUntracked = true
+		}
+	}
+
+	return gui.promptForStashMessage(func(message string) error {
+		gui.State.Panels.Files.CancelRangeSelect()
+
+		return gui.withSpan(gui.Tr.SpanStashPaths).StashPush(commands.StashPushOpts{
+			Message:          message,
+			Paths:            paths,
+			IncludeUntracked: includeUntracked,
+		})
+	})
+}
+
 func (gui *Gui) handleStashChanges() error {
-	return gui.handleStashSave(gui.GitCommand.StashSave)
+	return gui.handleStashSave(gui.withSpan(gui.Tr.SpanStash).StashSave)
 }
 
 func (gui *Gui) handleCreateResetToUpstreamMenu() error {
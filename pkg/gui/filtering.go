@@ -1,5 +1,12 @@
 package gui
 
+import (
+	"fmt"
+
+	"github.com/jesseduffield/lazygit/pkg/gui/modes/filtering"
+	"github.com/jesseduffield/lazygit/pkg/utils"
+)
+
 func (gui *Gui) validateNotInFilterMode() (bool, error) {
 	if gui.State.Modes.Filtering.Active() {
 		err := gui.ask(askOpts{
@@ -28,6 +35,15 @@ func (gui *Gui) clearFiltering() error {
 
 func (gui *Gui) setFiltering(path string) error {
 	gui.State.Modes.Filtering.SetPath(path)
+	return gui.enterFilterMode()
+}
+
+func (gui *Gui) setLineRangeFiltering(path string, lineRange *filtering.LineRange) error {
+	gui.State.Modes.Filtering.SetLineRange(path, lineRange)
+	return gui.enterFilterMode()
+}
+
+func (gui *Gui) enterFilterMode() error {
 	if gui.State.ScreenMode == SCREEN_NORMAL {
 		gui.State.ScreenMode = SCREEN_HALF
 	}
@@ -40,3 +56,23 @@ func (gui *Gui) setFiltering(path string) error {
 		gui.State.Contexts.BranchCommits.GetPanelState().SetSelectedLineIdx(0)
 	}})
 }
+
+// filteringDescription describes what we're filtering the commits by, for
+// showing in the status bar
+func (gui *Gui) filteringDescription() string {
+	path := gui.State.Modes.Filtering.GetPath()
+
+	lineRange := gui.State.Modes.Filtering.GetLineRange()
+	if lineRange == nil {
+		return fmt.Sprintf("%s '%s'", gui.Tr.LcFilteringBy, path)
+	}
+
+	return utils.ResolvePlaceholderString(
+		gui.Tr.LcFilteringByLines,
+		map[string]string{
+			"start": fmt.Sprintf("%d", lineRange.Start),
+			"end":   fmt.Sprintf("%d", lineRange.End),
+			"path":  path,
+		},
+	)
+}
@@ -3,6 +3,8 @@ package gui
 import (
 	"fmt"
 	"strings"
+
+	"github.com/jesseduffield/lazygit/pkg/utils"
 )
 
 func (gui *Gui) handleCreateFilteringMenuPanel() error {
@@ -31,6 +33,22 @@ func (gui *Gui) handleCreateFilteringMenuPanel() error {
 		})
 	}
 
+	if lineRangePath, lineRange := gui.selectedLineRange(); lineRange != nil {
+		menuItems = append(menuItems, &menuItem{
+			displayString: utils.ResolvePlaceholderString(
+				gui.Tr.LcFilterByLines,
+				map[string]string{
+					"start": fmt.Sprintf("%d", lineRange.Start),
+					"end":   fmt.Sprintf("%d", lineRange.End),
+					"path":  lineRangePath,
+				},
+			),
+			onPress: func() error {
+				return gui.setLineRangeFiltering(lineRangePath, lineRange)
+			},
+		})
+	}
+
 	menuItems = append(menuItems, &menuItem{
 		displayString: gui.Tr.LcFilterPathOption,
 		onPress: func() error {
@@ -32,6 +32,7 @@ func (gui *Gui) gitFlowFinishBranch(gitFlowConfig string, branchName string) err
 	}
 
 	return gui.runSubprocessWithSuspense(
+		gui.Tr.SpanGitFlowFinish,
 		gui.OSCommand.PrepareSubProcess("git", "flow", branchType, "finish", suffix),
 	)
 }
@@ -56,6 +57,7 @@ func (gui *Gui) handleCreateGitFlowMenu() error {
 				title: title,
 				handleConfirm: func(name string) error {
 					return gui.runSubprocessWithSuspense(
+						gui.Tr.SpanGitFlowStart,
 						gui.OSCommand.PrepareSubProcess("git", "flow", branchType, "start", name),
 					)
 				},
@@ -205,17 +205,27 @@ func (gui *Gui) fetch(canPromptForCredentials bool) (err error) {
 	defer gui.Mutexes.FetchMutex.Unlock()
 
 	fetchOpts := commands.FetchOptions{}
+	gitCommand := gui.GitCommand
+	// we only prompt for credentials when the user asked for the fetch, as
+	// opposed to our periodic background fetch, which has no place in the log
 	if canPromptForCredentials {
 		fetchOpts.PromptUserForCredential = gui.promptUserForCredential
+		gitCommand = gui.withSpan(gui.Tr.SpanFetch)
 	}
 
-	err = gui.GitCommand.Fetch(fetchOpts)
+	err = gitCommand.Fetch(fetchOpts)
 
 	if canPromptForCredentials && err != nil && strings.Contains(err.Error(), "exit status 128") {
 		_ = gui.createErrorPanel(gui.Tr.PassUnameWrong)
 	}
 
-	_ = gui.refreshSidePanels(refreshOptions{scope: []RefreshableView{BRANCHES, COMMITS, REMOTES, TAGS}, mode: ASYNC})
+	scope := []RefreshableView{BRANCHES, COMMITS, REMOTES, TAGS}
+	// we don't hit the hosting service's API until the user shows an interest,
+	// and a fetch, which may well be in the background, isn't that
+	if gui.State.Panels.PullRequests.Loaded {
+		scope = append(scope, PULL_REQUESTS)
+	}
+	_ = gui.refreshSidePanels(refreshOptions{scope: scope, mode: ASYNC})
 
 	return err
 }
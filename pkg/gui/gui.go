@@ -9,6 +9,7 @@ import (
 	"sync"
 
 	"os/exec"
+	"reflect"
 	"strings"
 	"time"
 
@@ -20,7 +21,9 @@ import (
 	"github.com/jesseduffield/lazygit/pkg/commands/oscommands"
 	"github.com/jesseduffield/lazygit/pkg/commands/patch"
 	"github.com/jesseduffield/lazygit/pkg/config"
+	"github.com/jesseduffield/lazygit/pkg/gui/cmdlog"
 	"github.com/jesseduffield/lazygit/pkg/gui/filetree"
+	"github.com/jesseduffield/lazygit/pkg/gui/mergeconflicts"
 	"github.com/jesseduffield/lazygit/pkg/gui/modes/filtering"
 	"github.com/jesseduffield/lazygit/pkg/gui/types"
 	"github.com/jesseduffield/lazygit/pkg/i18n"
@@ -103,11 +106,20 @@ type Gui struct {
 	// this tells us whether our views have been initially set up
 	ViewsSetup bool
 
+	// the commands we've run on the user's behalf. Unlike our State, this
+	// carries over when switching repos
+	CmdLog     *cmdlog.CmdLog
+	showCmdLog bool
+
 	Views Views
 }
 
 type listPanelState struct {
 	SelectedLineIdx int
+
+	// when range selecting, the range spans from here to the selected line
+	RangeStartIdx   int
+	RangeSelectMode bool
 }
 
 func (h *listPanelState) SetSelectedLineIdx(value int) {
@@ -118,6 +130,33 @@ func (h *listPanelState) GetSelectedLineIdx() int {
 	return h.SelectedLineIdx
 }
 
+func (h *listPanelState) ToggleRangeSelect() {
+	h.RangeSelectMode = !h.RangeSelectMode
+	h.RangeStartIdx = h.SelectedLineIdx
+}
+
+func (h *listPanelState) CancelRangeSelect() {
+	h.RangeSelectMode = false
+}
+
+func (h *listPanelState) IsRangeSelecting() bool {
+	return h.RangeSelectMode
+}
+
+// GetSelectedRange returns the first and last index of the selected lines. When
+// we're not range selecting that's just the selected line
+func (h *listPanelState) GetSelectedRange() (int, int) {
+	if !h.RangeSelectMode {
+		return h.SelectedLineIdx, h.SelectedLineIdx
+	}
+
+	if h.RangeStartIdx < h.SelectedLineIdx {
+		return h.RangeStartIdx, h.SelectedLineIdx
+	}
+
+	return h.SelectedLineIdx, h.RangeStartIdx
+}
+
 // for now the staging panel state, unlike the other panel states, is going to be
 // non-mutative, so that we don't accidentally end up
 // with mismatches of data. We might change this in the future
@@ -126,23 +165,40 @@ type lBlPanelState struct {
 	FirstLineIdx     int
 	LastLineIdx      int
 	Diff             string
+	SecondaryDiff    string
 	PatchParser      *patch.PatchParser
 	SelectMode       SelectMode
 	SecondaryFocused bool // this is for if we show the left or right panel
 }
 
 type mergingPanelState struct {
-	ConflictIndex  int
-	ConflictTop    bool
-	Conflicts      []commands.Conflict
-	ConflictsMutex sync.Mutex
-	EditHistory    *stack.Stack
+	ConflictIndex     int
+	ConflictSelection mergeconflicts.Selection
+	Conflicts         []commands.Conflict
+	ConflictsMutex    sync.Mutex
+	EditHistory       *stack.Stack
 
 	// UserScrolling tells us if the user has started scrolling through the file themselves
 	// in which case we won't auto-scroll to a conflict.
 	UserScrolling bool
 }
 
+type blameTarget struct {
+	Filename string
+	// the ref we're blaming the file at, or an empty string for the working tree
+	Ref             string
+	SelectedLineIdx int
+}
+
+type blamePanelState struct {
+	blameTarget
+	Lines []*models.BlameLine
+
+	// the versions of the file we've blamed on our way back through its history,
+	// so that we can retrace our steps
+	History []blameTarget
+}
+
 type filePanelState struct {
 	listPanelState
 }
@@ -164,10 +220,27 @@ type tagsPanelState struct {
 	listPanelState
 }
 
+type worktreePanelState struct {
+	listPanelState
+}
+
+type pullRequestPanelState struct {
+	listPanelState
+
+	// Loaded tells us whether we've asked the hosting service for its pull
+	// requests yet, and Err why we couldn't get them, if we couldn't
+	Loaded bool
+	Err    error
+}
+
 type commitPanelState struct {
 	listPanelState
 
 	LimitCommits bool
+
+	// when following a range of lines, how each commit changed them, keyed by
+	// the commit's sha
+	LineRangeDiffs map[string]string
 }
 
 type reflogCommitPanelState struct {
@@ -213,6 +286,8 @@ type panelStates struct {
 	Remotes        *remotePanelState
 	RemoteBranches *remoteBranchesState
 	Tags           *tagsPanelState
+	Worktrees      *worktreePanelState
+	PullRequests   *pullRequestPanelState
 	Commits        *commitPanelState
 	ReflogCommits  *reflogCommitPanelState
 	SubCommits     *subCommitPanelState
@@ -220,6 +295,7 @@ type panelStates struct {
 	Menu           *menuPanelState
 	LineByLine     *lBlPanelState
 	Merging        *mergingPanelState
+	Blame          *blamePanelState
 	CommitFiles    *commitFilesPanelState
 	Submodules     *submodulePanelState
 	Suggestions    *suggestionsPanelState
@@ -245,12 +321,17 @@ type Views struct {
 	SearchPrefix  *gocui.View
 	Limit         *gocui.View
 	Suggestions   *gocui.View
+	CmdLog        *gocui.View
 }
 
 type searchingState struct {
 	view         *gocui.View
 	isSearching  bool
 	searchString string
+
+	// the list the user is narrowing down, if any. We show the filter in the
+	// search bar, so it's there for as long as the filter is
+	filterContext *ListContext
 }
 
 // startup stages so we don't need to load everything at once
@@ -282,10 +363,57 @@ func (m *CherryPicking) Active() bool {
 	return len(m.CherryPickedCommits) > 0
 }
 
+// Info is refreshed alongside the commits. Run is set while a `git bisect run`
+// script is going
+type Bisecting struct {
+	Info *models.BisectInfo
+	Run  *bisectRun
+}
+
+func (m *Bisecting) Active() bool {
+	return m.Info != nil && m.Info.Started
+}
+
+// RebasePlanning holds an interactive rebase which the user is putting together
+// before we run it. Todos are ordered like the commits panel, newest first
+type RebasePlanning struct {
+	Todos []*models.Commit
+	// the commit we'll rebase onto, or '--root' if the todos go all the way down
+	// to the first commit
+	BaseSha string
+	// if HEAD moves away from this commit, the plan no longer applies
+	HeadSha string
+}
+
+func (m *RebasePlanning) Active() bool {
+	return len(m.Todos) > 0
+}
+
+func (m *RebasePlanning) Reset() {
+	*m = RebasePlanning{}
+}
+
+// MarkedBaseCommit is a commit the user has marked so that only the commits
+// after it get rebased onto whichever branch they pick next
+type MarkedBaseCommit struct {
+	Commit *models.Commit
+}
+
+func (m *MarkedBaseCommit) Active() bool {
+	return m.Commit != nil
+}
+
+func (m *MarkedBaseCommit) Reset() {
+	m.Commit = nil
+}
+
 type Modes struct {
-	Filtering     filtering.Filtering
-	CherryPicking CherryPicking
-	Diffing       Diffing
+	Filtering        filtering.Filtering
+	CherryPicking    CherryPicking
+	Diffing          Diffing
+	Bisecting        Bisecting
+	RebasePlanning   RebasePlanning
+	MarkedBaseCommit MarkedBaseCommit
 }
 
 type guiStateMutexes struct {
@@ -313,26 +441,31 @@ type guiState struct {
 	// ReflogCommits are the ones used by the branches panel to obtain recency values
 	// if we're not in filtering mode, CommitFiles and FilteredReflogCommits will be
 	// one and the same
-	ReflogCommits     []*models.Commit
-	SubCommits        []*models.Commit
-	Remotes           []*models.Remote
-	RemoteBranches    []*models.RemoteBranch
-	Tags              []*models.Tag
-	MenuItems         []*menuItem
-	Updating          bool
-	Panels            *panelStates
-	SplitMainPanel    bool
-	MainContext       ContextKey // used to keep the main and secondary views' contexts in sync
-	RetainOriginalDir bool
-	IsRefreshingFiles bool
-	Searching         searchingState
-	ScreenMode        WindowMaximisation
-	SideView          *gocui.View
-	Ptmx              *os.File
-	PrevMainWidth     int
-	PrevMainHeight    int
-	OldInformation    string
-	StartupStage      StartupStage // Allows us to not load everything at once
+	ReflogCommits  []*models.Commit
+	SubCommits     []*models.Commit
+	Remotes        []*models.Remote
+	RemoteBranches []*models.RemoteBranch
+	Tags           []*models.Tag
+	Worktrees      []*models.Worktree
+	// PullRequests are the open pull requests of the repo, whereas
+	// BranchPullRequests holds the latest pull request of each branch, open or not
+	PullRequests       []*models.PullRequest
+	BranchPullRequests map[string]*models.PullRequest
+	MenuItems          []*menuItem
+	Updating           bool
+	Panels             *panelStates
+	SplitMainPanel     bool
+	MainContext        ContextKey // used to keep the main and secondary views' contexts in sync
+	RetainOriginalDir  bool
+	IsRefreshingFiles  bool
+	Searching          searchingState
+	ScreenMode         WindowMaximisation
+	SideView           *gocui.View
+	Ptmx               *os.File
+	PrevMainWidth      int
+	PrevMainHeight     int
+	OldInformation     string
+	StartupStage       StartupStage // Allows us to not load everything at once
 
 	Modes Modes
 
@@ -402,6 +535,8 @@ func (gui *Gui) resetState(filterPath string, reuseState bool) {
 			Remotes:        &remotePanelState{listPanelState{SelectedLineIdx: 0}},
 			RemoteBranches: &remoteBranchesState{listPanelState{SelectedLineIdx: -1}},
 			Tags:           &tagsPanelState{listPanelState{SelectedLineIdx: -1}},
+			Worktrees:      &worktreePanelState{listPanelState{SelectedLineIdx: -1}},
+			PullRequests:   &pullRequestPanelState{listPanelState: listPanelState{SelectedLineIdx: -1}},
 			Commits:        &commitPanelState{listPanelState: listPanelState{SelectedLineIdx: -1}, LimitCommits: true},
 			ReflogCommits:  &reflogCommitPanelState{listPanelState{SelectedLineIdx: 0}},
 			SubCommits:     &subCommitPanelState{listPanelState: listPanelState{SelectedLineIdx: 0}, refName: ""},
@@ -410,12 +545,13 @@ func (gui *Gui) resetState(filterPath string, reuseState bool) {
 			Menu:           &menuPanelState{listPanelState: listPanelState{SelectedLineIdx: 0}, OnPress: nil},
 			Suggestions:    &suggestionsPanelState{listPanelState: listPanelState{SelectedLineIdx: 0}},
 			Merging: &mergingPanelState{
-				ConflictIndex:  0,
-				ConflictTop:    true,
-				Conflicts:      []commands.Conflict{},
-				EditHistory:    stack.New(),
-				ConflictsMutex: sync.Mutex{},
+				ConflictIndex:     0,
+				ConflictSelection: mergeconflicts.TOP,
+				Conflicts:         []commands.Conflict{},
+				EditHistory:       stack.New(),
+				ConflictsMutex:    sync.Mutex{},
 			},
+			Blame: &blamePanelState{},
 		},
 		SideView: nil,
 		Ptmx:     nil,
@@ -426,6 +562,11 @@ func (gui *Gui) resetState(filterPath string, reuseState bool) {
 				ContextKey:          "",
 			},
 			Diffing: Diffing{},
+			Bisecting: Bisecting{
+				Info: models.NewBisectInfo(),
+			},
+			RebasePlanning:   RebasePlanning{},
+			MarkedBaseCommit: MarkedBaseCommit{},
 		},
 		ViewContextMap:    contexts.initialViewContextMap(),
 		ViewTabContextMap: contexts.initialViewTabContextMap(),
@@ -455,6 +596,9 @@ func NewGui(log *logrus.Entry, gitCommand *commands.GitCommand, oSCommand *oscom
 		RepoStateMap:         map[Repo]*guiState{},
 	}
 
+	gui.CmdLog = cmdlog.NewCmdLog(gui.onCmdLogChange)
+	gui.showCmdLog = config.GetUserConfig().Gui.ShowCommandLog
+
 	gui.resetState(filterPath, false)
 
 	gui.watchFilesForChanges()
@@ -497,21 +641,14 @@ func (gui *Gui) Run() error {
 	}
 
 	g.OnSearchEscape = gui.onSearchEscape
-	if err := gui.Config.ReloadUserConfig(); err != nil {
-		return nil
+	if err := gui.Config.ReloadUserConfig(UserConfigValidators()...); err != nil {
+		return err
 	}
 	userConfig := gui.Config.GetUserConfig()
-	g.SearchEscapeKey = gui.getKey(userConfig.Keybinding.Universal.Return)
-	g.NextSearchMatchKey = gui.getKey(userConfig.Keybinding.Universal.NextMatch)
-	g.PrevSearchMatchKey = gui.getKey(userConfig.Keybinding.Universal.PrevMatch)
 
 	g.ASCII = runtime.GOOS == "windows" && runewidth.IsEastAsian()
 
-	if userConfig.Gui.MouseEvents {
-		g.Mouse = true
-	}
-
-	if err := gui.setColorScheme(); err != nil {
+	if err := gui.applyUserConfig(); err != nil {
 		return err
 	}
 
@@ -521,6 +658,7 @@ func (gui *Gui) Run() error {
 	}
 
 	gui.goEvery(time.Second*time.Duration(userConfig.Refresher.RefreshInterval), gui.stopChan, gui.refreshFilesAndSubmodules)
+	gui.watchUserConfig()
 
 	g.SetManager(gocui.ManagerFunc(gui.layout), gocui.ManagerFunc(gui.getFocusLayout()))
 
@@ -568,7 +706,7 @@ func (gui *Gui) RunAndHandleError() error {
 	})
 }
 
-func (gui *Gui) runSubprocessWithSuspense(subprocess *exec.Cmd) error {
+func (gui *Gui) runSubprocessWithSuspense(span string, subprocess *exec.Cmd) error {
 	if replaying() {
 		// we do not yet support running subprocesses within integration tests. So if
 		// we're replaying an integration test and we're inside this method, something
@@ -581,7 +719,7 @@ func (gui *Gui) runSubprocessWithSuspense(subprocess *exec.Cmd) error {
 		return gui.surfaceError(err)
 	}
 
-	cmdErr := gui.runSubprocess(subprocess)
+	cmdErr := gui.runSubprocess(span, subprocess)
 
 	if err := gocui.Screen.Resume(); err != nil {
 		return gui.surfaceError(err)
@@ -594,14 +732,19 @@ func (gui *Gui) runSubprocessWithSuspense(subprocess *exec.Cmd) error {
 	return gui.surfaceError(cmdErr)
 }
 
-func (gui *Gui) runSubprocess(subprocess *exec.Cmd) error {
+func (gui *Gui) runSubprocess(span string, subprocess *exec.Cmd) error {
+	before, after := gui.CmdLog.Hooks(span)
+
 	subprocess.Stdout = os.Stdout
 	subprocess.Stderr = os.Stdout
 	subprocess.Stdin = os.Stdin
 
 	fmt.Fprintf(os.Stdout, "\n%s\n\n", utils.ColoredString("+ "+strings.Join(subprocess.Args, " "), color.FgBlue))
 
-	if err := subprocess.Run(); err != nil {
+	before(subprocess)
+	err := subprocess.Run()
+	after(subprocess, err)
+	if err != nil {
 		// not handling the error explicitly because usually we're going to see it
 		// in the output anyway
 		gui.Log.Error(err)
@@ -663,6 +806,24 @@ func (gui *Gui) showIntroPopupMessage(done chan struct{}) error {
 	})
 }
 
+// showConfigProblemsPopup tells the user about problems with their config
+// which we've carried on regardless of, like keys we don't know about
+func (gui *Gui) showConfigProblemsPopup(problems error) func(chan struct{}) error {
+	return func(done chan struct{}) error {
+		onClose := func() error {
+			done <- struct{}{}
+			return nil
+		}
+
+		return gui.ask(askOpts{
+			title:         gui.Tr.Error,
+			prompt:        utils.ColoredString(fmt.Sprintf("%s\n\n%s", gui.Tr.InvalidConfig, problems), color.FgRed),
+			handleConfirm: onClose,
+			handleClose:   onClose,
+		})
+	}
+}
+
 func (gui *Gui) goEvery(interval time.Duration, stop chan struct{}, function func() error) {
 	go utils.Safe(func() {
 		ticker := time.NewTicker(interval)
@@ -699,6 +860,126 @@ func (gui *Gui) startBackgroundFetch() {
 	}
 }
 
+// applyUserConfig applies the parts of the user config which the gui holds on
+// to, rather than looking them up as it goes
+func (gui *Gui) applyUserConfig() error {
+	userConfig := gui.Config.GetUserConfig()
+	gui.g.SearchEscapeKey = gui.getKey(userConfig.Keybinding.Universal.Return)
+	gui.g.NextSearchMatchKey = gui.getKey(userConfig.Keybinding.Universal.NextMatch)
+	gui.g.PrevSearchMatchKey = gui.getKey(userConfig.Keybinding.Universal.PrevMatch)
+	gui.g.Mouse = userConfig.Gui.MouseEvents
+
+	if err := gui.setColorScheme(); err != nil {
+		return err
+	}
+
+	// views take their text colour from the theme when they're created, so if
+	// they're already around we need to pass on the new colours. Popups set
+	// theirs each time they're shown
+	if gui.ViewsSetup {
+		for _, view := range []*gocui.View{
+			gui.Views.Status,
+			gui.Views.Files,
+			gui.Views.Branches,
+			gui.Views.Commits,
+			gui.Views.Stash,
+			gui.Views.CommitFiles,
+			gui.Views.Main,
+			gui.Views.Secondary,
+			gui.Views.CmdLog,
+			gui.Views.CommitMessage,
+			gui.Views.Credentials,
+		} {
+			view.FgColor = theme.GocuiDefaultTextColor
+		}
+		gui.Views.Options.FgColor = theme.OptionsColor
+	}
+
+	return nil
+}
+
+// watchUserConfig reloads the user config whenever one of its files changes,
+// so that the user can see the effect of their edits without restarting. We
+// check the files' modification times rather than relying on file watching
+// given editors tend to save by replacing the file
+func (gui *Gui) watchUserConfig() {
+	modTimes := gui.Config.GetUserConfigModTimes()
+
+	// set when the files have changed but we haven't reloaded yet. The view
+	// state can only be checked on the UI goroutine, so that's where we decide
+	// whether we can reload, and we keep asking until we can
+	var pendingMutex sync.Mutex
+	pending := false
+
+	gui.goEvery(time.Second, gui.stopChan, func() error {
+		newModTimes := gui.Config.GetUserConfigModTimes()
+
+		pendingMutex.Lock()
+		if !reflect.DeepEqual(newModTimes, modTimes) {
+			modTimes = newModTimes
+			pending = true
+		}
+		isPending := pending
+		pendingMutex.Unlock()
+
+		if !isPending {
+			return nil
+		}
+
+		gui.g.Update(func(*gocui.Gui) error {
+			// popups set up their own keybindings, which reloading would throw
+			// away, so we wait until there are none open
+			if !gui.ViewsSetup || gui.popupPanelFocused() || gui.Views.Confirmation.Visible {
+				return nil
+			}
+
+			pendingMutex.Lock()
+			isPending := pending
+			pending = false
+			pendingMutex.Unlock()
+
+			if !isPending {
+				return nil
+			}
+
+			return gui.onUserConfigChanged()
+		})
+
+		return nil
+	})
+}
+
+func (gui *Gui) onUserConfigChanged() error {
+	if err := gui.reloadUserConfig(); err != nil {
+		return gui.createErrorPanel(utils.ResolvePlaceholderString(gui.Tr.ConfigReloadError, map[string]string{"error": err.Error()}))
+	}
+
+	gui.raiseToast(gui.Tr.ConfigReloaded)
+
+	if err := gui.Config.ValidateUserConfig(UserConfigValidators()...); err != nil {
+		gui.Log.Warn(err)
+		// this refreshes the side panels too
+		return gui.createErrorPanel(fmt.Sprintf("%s\n\n%s", gui.Tr.InvalidConfig, err))
+	}
+
+	// a lot of the config only takes effect when things are rendered
+	return gui.refreshSidePanels(refreshOptions{mode: ASYNC})
+}
+
+// reloadUserConfig loads the user config again, e.g. because we've switched to
+// a repo with its own config, and applies it to the running gui
+func (gui *Gui) reloadUserConfig() error {
+	if err := gui.Config.ReloadUserConfig(UserConfigValidators()...); err != nil {
+		return err
+	}
+
+	if err := gui.applyUserConfig(); err != nil {
+		return err
+	}
+
+	return gui.resetKeybindings()
+}
+
 // setColorScheme sets the color scheme for the app based on the user config
 func (gui *Gui) setColorScheme() error {
 	userConfig := gui.Config.GetUserConfig()
@@ -8,6 +8,7 @@ import (
 	"unicode/utf8"
 
 	"github.com/jesseduffield/gocui"
+	"github.com/jesseduffield/lazygit/pkg/utils"
 )
 
 // Binding - a keybinding mapping a key and modifier to a handler. The keypress
@@ -23,6 +24,9 @@ type Binding struct {
 	Alternative string
 	Tag         string // e.g. 'navigation'. Used for grouping things in the cheatsheet
 	OpensMenu   bool
+	// if set, the binding only applies when this returns true. Otherwise its key
+	// does whatever it would do without the binding
+	Condition func() bool
 }
 
 // GetDisplayStrings returns the display string of a file
@@ -167,6 +171,10 @@ func (gui *Gui) getKeyDisplay(name string) string {
 }
 
 func GetKeyDisplay(key interface{}) string {
+	if key == nil {
+		return ""
+	}
+
 	keyInt := 0
 
 	switch key := key.(type) {
@@ -514,6 +522,27 @@ func (gui *Gui) GetInitialKeybindings() []*Binding {
 			Handler:     gui.handleToggleFileTreeView,
 			Description: gui.Tr.LcToggleTreeView,
 		},
+		{
+			ViewName:    "files",
+			Contexts:    []string{string(FILES_CONTEXT_KEY)},
+			Key:         gui.getKey(config.Files.ViewBlame),
+			Handler:     gui.handleFileBlame,
+			Description: gui.Tr.LcViewBlame,
+		},
+		{
+			ViewName:    "files",
+			Contexts:    []string{string(FILES_CONTEXT_KEY)},
+			Key:         gui.getKey(config.Files.OpenMergeTool),
+			Handler:     gui.handleOpenMergeTool,
+			Description: gui.Tr.LcOpenMergeTool,
+		},
+		{
+			ViewName:    "files",
+			Contexts:    []string{string(FILES_CONTEXT_KEY)},
+			Key:         gui.getKey(config.Files.ViewMergeConflictOptions),
+			Handler:     gui.handleCreateMergeConflictMenu,
+			Description: gui.Tr.LcViewMergeConflictOptions,
+		},
 		{
 			ViewName:    "branches",
 			Contexts:    []string{string(LOCAL_BRANCHES_CONTEXT_KEY)},
@@ -648,6 +677,7 @@ func (gui *Gui) GetInitialKeybindings() []*Binding {
 			Key:         gui.getKey(config.Universal.New),
 			Handler:     gui.handleCreateTag,
 			Description: gui.Tr.LcCreateTag,
+			OpensMenu:   true,
 		},
 		{
 			ViewName:    "branches",
@@ -664,6 +694,76 @@ func (gui *Gui) GetInitialKeybindings() []*Binding {
 			Handler:     gui.handleSwitchToSubCommits,
 			Description: gui.Tr.LcViewCommits,
 		},
+		{
+			ViewName:    "branches",
+			Contexts:    []string{string(LOCAL_BRANCHES_CONTEXT_KEY)},
+			Key:         gui.getKey(config.Branches.CreateWorktree),
+			Handler:     gui.handleCreateWorktreeFromBranch,
+			Description: gui.Tr.LcCreateWorktreeFromBranch,
+		},
+		{
+			ViewName:    "branches",
+			Contexts:    []string{string(WORKTREES_CONTEXT_KEY)},
+			Key:         gui.getKey(config.Universal.Select),
+			Handler:     gui.handleSwitchToWorktree,
+			Description: gui.Tr.LcSwitchToWorktree,
+		},
+		{
+			ViewName:    "branches",
+			Contexts:    []string{string(WORKTREES_CONTEXT_KEY)},
+			Key:         gui.getKey(config.Universal.New),
+			Handler:     gui.handleCreateWorktree,
+			Description: gui.Tr.LcCreateWorktree,
+		},
+		{
+			ViewName:    "branches",
+			Contexts:    []string{string(WORKTREES_CONTEXT_KEY)},
+			Key:         gui.getKey(config.Universal.Remove),
+			Handler:     gui.handleRemoveWorktree,
+			Description: gui.Tr.LcRemoveWorktree,
+		},
+		{
+			ViewName:    "branches",
+			Contexts:    []string{string(WORKTREES_CONTEXT_KEY)},
+			Key:         gui.getKey(config.Worktrees.Prune),
+			Handler:     gui.handlePruneWorktrees,
+			Description: gui.Tr.LcPruneWorktrees,
+		},
+		{
+			ViewName:    "branches",
+			Contexts:    []string{string(WORKTREES_CONTEXT_KEY)},
+			Key:         gui.getKey(config.Universal.CopyToClipboard),
+			Handler:     gui.handleCopySelectedSideContextItemToClipboard,
+			Description: gui.Tr.LcCopyWorktreePathToClipboard,
+		},
+		{
+			ViewName:    "branches",
+			Contexts:    []string{string(PULL_REQUESTS_CONTEXT_KEY)},
+			Key:         gui.getKey(config.Universal.Select),
+			Handler:     gui.handleCheckoutPullRequest,
+			Description: gui.Tr.LcCheckoutPullRequest,
+		},
+		{
+			ViewName:    "branches",
+			Contexts:    []string{string(PULL_REQUESTS_CONTEXT_KEY)},
+			Key:         gui.getKey(config.Universal.OpenFile),
+			Handler:     gui.handleOpenPullRequestInBrowser,
+			Description: gui.Tr.LcOpenPullRequestInBrowser,
+		},
+		{
+			ViewName:    "branches",
+			Contexts:    []string{string(PULL_REQUESTS_CONTEXT_KEY)},
+			Key:         gui.getKey(config.Universal.Refresh),
+			Handler:     gui.handleRefreshPullRequests,
+			Description: gui.Tr.LcRefreshPullRequests,
+		},
+		{
+			ViewName:    "branches",
+			Contexts:    []string{string(PULL_REQUESTS_CONTEXT_KEY)},
+			Key:         gui.getKey(config.Universal.CopyToClipboard),
+			Handler:     gui.handleCopySelectedSideContextItemToClipboard,
+			Description: gui.Tr.LcCopyPullRequestURLToClipboard,
+		},
 		{
 			ViewName:    "branches",
 			Contexts:    []string{string(REMOTE_BRANCHES_CONTEXT_KEY)},
@@ -847,6 +947,7 @@ func (gui *Gui) GetInitialKeybindings() []*Binding {
 			Key:         gui.getKey(config.Commits.TagCommit),
 			Handler:     gui.handleTagCommit,
 			Description: gui.Tr.LcTagCommit,
+			OpensMenu:   true,
 		},
 		{
 			ViewName:    "commits",
@@ -862,6 +963,49 @@ func (gui *Gui) GetInitialKeybindings() []*Binding {
 			Handler:     gui.handleCopySelectedCommitMessageToClipboard,
 			Description: gui.Tr.LcCopyCommitMessageToClipboard,
 		},
+		{
+			ViewName:    "commits",
+			Contexts:    []string{string(BRANCH_COMMITS_CONTEXT_KEY)},
+			Key:         gui.getKey(config.Commits.CreateWorktree),
+			Handler:     gui.handleCreateWorktreeFromCommit,
+			Description: gui.Tr.LcCreateWorktreeFromCommit,
+		},
+		{
+			ViewName:    "commits",
+			Contexts:    []string{string(BRANCH_COMMITS_CONTEXT_KEY)},
+			Key:         gui.getKey(config.Commits.ViewBisectOptions),
+			Handler:     gui.handleOpenBisectMenu,
+			Description: gui.Tr.LcViewBisectOptions,
+			OpensMenu:   true,
+		},
+		{
+			ViewName:    "commits",
+			Contexts:    []string{string(BRANCH_COMMITS_CONTEXT_KEY)},
+			Key:         gui.getKey(config.Commits.PlanInteractiveRebase),
+			Handler:     gui.handlePlanInteractiveRebase,
+			Description: gui.Tr.LcPlanInteractiveRebase,
+		},
+		{
+			ViewName:    "commits",
+			Contexts:    []string{string(BRANCH_COMMITS_CONTEXT_KEY)},
+			Key:         gui.getKey(config.Commits.InsertExecTodo),
+			Handler:     gui.handleInsertExecTodo,
+			Description: gui.Tr.LcInsertExecTodo,
+		},
+		{
+			ViewName:    "commits",
+			Contexts:    []string{string(BRANCH_COMMITS_CONTEXT_KEY)},
+			Key:         gui.getKey(config.Commits.InsertBreakTodo),
+			Handler:     gui.handleInsertBreakTodo,
+			Description: gui.Tr.LcInsertBreakTodo,
+		},
+		{
+			ViewName:    "commits",
+			Contexts:    []string{string(BRANCH_COMMITS_CONTEXT_KEY)},
+			Key:         gui.getKey(config.Commits.MarkCommitAsBaseForRebase),
+			Handler:     gui.handleMarkCommitAsBaseForRebase,
+			Description: gui.Tr.LcMarkCommitAsBaseForRebase,
+		},
 		{
 			ViewName:    "commits",
 			Contexts:    []string{string(REFLOG_COMMITS_CONTEXT_KEY)},
@@ -993,6 +1137,12 @@ func (gui *Gui) GetInitialKeybindings() []*Binding {
 			Handler:     gui.handleStashDrop,
 			Description: gui.Tr.LcDrop,
 		},
+		{
+			ViewName:    "stash",
+			Key:         gui.getKey(config.Stash.RenameStash),
+			Handler:     gui.handleRenameStash,
+			Description: gui.Tr.LcRenameStash,
+		},
 		{
 			ViewName:    "stash",
 			Key:         gui.getKey(config.Universal.New),
@@ -1083,6 +1233,12 @@ func (gui *Gui) GetInitialKeybindings() []*Binding {
 			Handler:     gui.handleToggleCommitFileTreeView,
 			Description: gui.Tr.LcToggleTreeView,
 		},
+		{
+			ViewName:    "commitFiles",
+			Key:         gui.getKey(config.CommitFiles.ViewBlame),
+			Handler:     gui.handleCommitFileBlame,
+			Description: gui.Tr.LcViewBlame,
+		},
 		{
 			ViewName:    "",
 			Key:         gui.getKey(config.Universal.FilteringMenu),
@@ -1104,6 +1260,19 @@ func (gui *Gui) GetInitialKeybindings() []*Binding {
 			Description: gui.Tr.LcOpenDiffingMenu,
 			OpensMenu:   true,
 		},
+		{
+			ViewName:    "",
+			Key:         gui.getKey(config.Universal.CommandLogMenu),
+			Handler:     gui.handleCreateCmdLogMenu,
+			Description: gui.Tr.LcCommandLogMenu,
+			OpensMenu:   true,
+		},
+		{
+			ViewName:    "",
+			Key:         gui.getKey(config.Universal.KeybindingsHelp),
+			Handler:     gui.handleOpenKeybindingsHelp,
+			Description: gui.Tr.LcOpenKeybindingsHelp,
+		},
 		{
 			ViewName: "secondary",
 			Key:      gocui.MouseWheelUp,
@@ -1174,6 +1343,13 @@ func (gui *Gui) GetInitialKeybindings() []*Binding {
 			Handler:     gui.handleResetSelection,
 			Description: gui.Tr.ResetSelection,
 		},
+		{
+			ViewName:    "main",
+			Contexts:    []string{string(MAIN_STAGING_CONTEXT_KEY)},
+			Key:         gui.getKey(config.Main.StashSelection),
+			Handler:     gui.handleStashSelection,
+			Description: gui.Tr.LcStashSelection,
+		},
 		{
 			ViewName:    "main",
 			Contexts:    []string{string(MAIN_STAGING_CONTEXT_KEY)},
@@ -1401,6 +1577,69 @@ func (gui *Gui) GetInitialKeybindings() []*Binding {
 			Handler:     gui.handleCommitEditorPress,
 			Description: gui.Tr.CommitChangesWithEditor,
 		},
+		{
+			ViewName:    "main",
+			Contexts:    []string{string(MAIN_BLAME_CONTEXT_KEY)},
+			Key:         gui.getKey(config.Universal.Return),
+			Handler:     gui.handleEscapeBlame,
+			Description: gui.Tr.LcExitBlame,
+		},
+		{
+			ViewName:    "main",
+			Contexts:    []string{string(MAIN_BLAME_CONTEXT_KEY)},
+			Key:         gui.getKey(config.Universal.GoInto),
+			Handler:     gui.handleBlameGoToCommit,
+			Description: gui.Tr.LcGoToBlamedCommit,
+		},
+		{
+			ViewName:    "main",
+			Contexts:    []string{string(MAIN_BLAME_CONTEXT_KEY)},
+			Key:         gui.getKey(config.Main.BlamePreviousVersion),
+			Handler:     gui.handleBlamePreviousVersion,
+			Description: gui.Tr.LcBlamePreviousVersion,
+		},
+		{
+			ViewName:    "main",
+			Contexts:    []string{string(MAIN_BLAME_CONTEXT_KEY)},
+			Key:         gui.getKey(config.Universal.PrevItem),
+			Handler:     gui.handleBlamePrevLine,
+			Description: gui.Tr.LcSelectLine,
+		},
+		{
+			ViewName:    "main",
+			Contexts:    []string{string(MAIN_BLAME_CONTEXT_KEY)},
+			Key:         gui.getKey(config.Universal.NextItem),
+			Handler:     gui.handleBlameNextLine,
+			Description: gui.Tr.LcSelectLine,
+		},
+		{
+			ViewName: "main",
+			Contexts: []string{string(MAIN_BLAME_CONTEXT_KEY)},
+			Key:      gui.getKey(config.Universal.PrevItemAlt),
+			Modifier: gocui.ModNone,
+			Handler:  gui.handleBlamePrevLine,
+		},
+		{
+			ViewName: "main",
+			Contexts: []string{string(MAIN_BLAME_CONTEXT_KEY)},
+			Key:      gui.getKey(config.Universal.NextItemAlt),
+			Modifier: gocui.ModNone,
+			Handler:  gui.handleBlameNextLine,
+		},
+		{
+			ViewName: "main",
+			Contexts: []string{string(MAIN_BLAME_CONTEXT_KEY)},
+			Key:      gocui.MouseWheelUp,
+			Modifier: gocui.ModNone,
+			Handler:  gui.handleBlamePrevLine,
+		},
+		{
+			ViewName: "main",
+			Contexts: []string{string(MAIN_BLAME_CONTEXT_KEY)},
+			Key:      gocui.MouseWheelDown,
+			Modifier: gocui.ModNone,
+			Handler:  gui.handleBlameNextLine,
+		},
 		{
 			ViewName:    "main",
 			Contexts:    []string{string(MAIN_MERGING_CONTEXT_KEY)},
@@ -1440,29 +1679,29 @@ func (gui *Gui) GetInitialKeybindings() []*Binding {
 			ViewName:    "main",
 			Contexts:    []string{string(MAIN_MERGING_CONTEXT_KEY)},
 			Key:         gui.getKey(config.Universal.PrevItem),
-			Handler:     gui.handleSelectTop,
-			Description: gui.Tr.SelectTop,
+			Handler:     gui.handleSelectPrevConflictHunk,
+			Description: gui.Tr.PrevHunk,
 		},
 		{
 			ViewName:    "main",
 			Contexts:    []string{string(MAIN_MERGING_CONTEXT_KEY)},
 			Key:         gui.getKey(config.Universal.NextItem),
-			Handler:     gui.handleSelectBottom,
-			Description: gui.Tr.SelectBottom,
+			Handler:     gui.handleSelectNextConflictHunk,
+			Description: gui.Tr.NextHunk,
 		},
 		{
 			ViewName: "main",
 			Contexts: []string{string(MAIN_MERGING_CONTEXT_KEY)},
 			Key:      gocui.MouseWheelUp,
 			Modifier: gocui.ModNone,
-			Handler:  gui.handleSelectTop,
+			Handler:  gui.handleSelectPrevConflictHunk,
 		},
 		{
 			ViewName: "main",
 			Contexts: []string{string(MAIN_MERGING_CONTEXT_KEY)},
 			Key:      gocui.MouseWheelDown,
 			Modifier: gocui.ModNone,
-			Handler:  gui.handleSelectBottom,
+			Handler:  gui.handleSelectNextConflictHunk,
 		},
 		{
 			ViewName: "main",
@@ -1483,14 +1722,14 @@ func (gui *Gui) GetInitialKeybindings() []*Binding {
 			Contexts: []string{string(MAIN_MERGING_CONTEXT_KEY)},
 			Key:      gui.getKey(config.Universal.PrevItemAlt),
 			Modifier: gocui.ModNone,
-			Handler:  gui.handleSelectTop,
+			Handler:  gui.handleSelectPrevConflictHunk,
 		},
 		{
 			ViewName: "main",
 			Contexts: []string{string(MAIN_MERGING_CONTEXT_KEY)},
 			Key:      gui.getKey(config.Universal.NextItemAlt),
 			Modifier: gocui.ModNone,
-			Handler:  gui.handleSelectBottom,
+			Handler:  gui.handleSelectNextConflictHunk,
 		},
 		{
 			ViewName:    "main",
@@ -1499,6 +1738,13 @@ func (gui *Gui) GetInitialKeybindings() []*Binding {
 			Handler:     gui.handlePopFileSnapshot,
 			Description: gui.Tr.LcUndo,
 		},
+		{
+			ViewName:    "main",
+			Contexts:    []string{string(MAIN_MERGING_CONTEXT_KEY)},
+			Key:         gui.getKey(config.Files.OpenMergeTool),
+			Handler:     gui.handleOpenMergeTool,
+			Description: gui.Tr.LcOpenMergeTool,
+		},
 		{
 			ViewName: "branches",
 			Contexts: []string{string(REMOTES_CONTEXT_KEY)},
@@ -1731,14 +1977,8 @@ func (gui *Gui) GetInitialKeybindings() []*Binding {
 }
 
 func (gui *Gui) keybindings() error {
-	bindings := gui.GetCustomCommandKeybindings()
-
-	bindings = append(bindings, gui.GetInitialKeybindings()...)
-
-	for _, binding := range bindings {
-		if err := gui.g.SetKeybinding(binding.ViewName, binding.Contexts, binding.Key, binding.Modifier, gui.wrappedHandler(binding.Handler)); err != nil {
-			return err
-		}
+	if err := gui.setKeybindings(); err != nil {
+		return err
 	}
 
 	for viewName := range gui.State.Contexts.initialViewTabContextMap() {
@@ -1752,3 +1992,82 @@ func (gui *Gui) keybindings() error {
 
 	return nil
 }
+
+func (gui *Gui) setKeybindings() error {
+	bindings := gui.GetCustomCommandKeybindings()
+
+	bindings = append(bindings, gui.GetInitialKeybindings()...)
+
+	return gui.registerKeybindings(bindings, func(*Binding) bool { return true })
+}
+
+// registerKeybindings sets up those of the given bindings that pass the filter.
+// A conditional binding falls back to the bindings after it in the full list,
+// whether or not they pass the filter, so that it behaves the same however
+// we got here
+func (gui *Gui) registerKeybindings(bindings []*Binding, filter func(*Binding) bool) error {
+	for i, binding := range bindings {
+		// bindings without a key are only reachable from the options menu
+		if binding.Key == nil || !filter(binding) {
+			continue
+		}
+
+		handler := binding.Handler
+		if binding.Condition != nil {
+			handler = gui.conditionalHandler(binding, bindings[i+1:])
+		}
+
+		if err := gui.g.SetKeybinding(binding.ViewName, binding.Contexts, binding.Key, binding.Modifier, gui.wrappedHandler(handler)); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// conditionalHandler runs the binding's handler if its condition holds, and
+// otherwise the handler of whichever of the given later bindings gocui would
+// have picked for the key, had the binding not been there
+func (gui *Gui) conditionalHandler(binding *Binding, laterBindings []*Binding) func() error {
+	return func() error {
+		if binding.Condition() {
+			return binding.Handler()
+		}
+
+		viewContext := ""
+		if view := gui.g.CurrentView(); view != nil {
+			viewContext = view.Context
+		}
+
+		// bindings for the binding's view come before global ones
+		for _, viewName := range []string{binding.ViewName, ""} {
+			for i, fallback := range laterBindings {
+				if fallback.ViewName != viewName || fallback.Key != binding.Key || fallback.Modifier != binding.Modifier {
+					continue
+				}
+				if len(fallback.Contexts) > 0 && !utils.IncludesString(fallback.Contexts, viewContext) {
+					continue
+				}
+
+				if fallback.Condition != nil {
+					return gui.conditionalHandler(fallback, laterBindings[i+1:])()
+				}
+				return fallback.Handler()
+			}
+		}
+
+		return nil
+	}
+}
+
+// resetKeybindings replaces our keybindings with those of the current user
+// config. Popups set up their own keybindings whenever they're shown, so we can
+// safely throw those away along with the rest
+func (gui *Gui) resetKeybindings() error {
+	gui.g.DeleteKeybindings("")
+	for _, view := range gui.g.Views() {
+		gui.g.DeleteKeybindings(view.Name())
+	}
+
+	return gui.setKeybindings()
+}
@@ -7,6 +7,7 @@ import (
 )
 
 const SEARCH_PREFIX = "search: "
+const FILTER_PREFIX = "filter: "
 const INFO_SECTION_PADDING = " "
 
 func (gui *Gui) informationStr() string {
@@ -48,6 +49,7 @@ func (gui *Gui) createAllViews() error {
 		{viewPtr: &gui.Views.Suggestions, name: "suggestions"},
 		{viewPtr: &gui.Views.Confirmation, name: "confirmation"},
 		{viewPtr: &gui.Views.Limit, name: "limit"},
+		{viewPtr: &gui.Views.CmdLog, name: "cmdLog"},
 	}
 
 	var err error
@@ -100,6 +102,10 @@ func (gui *Gui) createAllViews() error {
 	gui.Views.Limit.Title = gui.Tr.NotEnoughSpace
 	gui.Views.Limit.Wrap = true
 
+	gui.Views.CmdLog.Title = gui.Tr.CommandLogTitle
+	gui.Views.CmdLog.FgColor = theme.GocuiDefaultTextColor
+	gui.Views.CmdLog.Autoscroll = true
+
 	gui.Views.Status.Title = gui.Tr.StatusTitle
 	gui.Views.Status.FgColor = theme.GocuiDefaultTextColor
 
@@ -107,6 +113,7 @@ func (gui *Gui) createAllViews() error {
 	gui.Views.Search.FgColor = gocui.ColorGreen
 	gui.Views.Search.Frame = false
 	gui.Views.Search.Editable = true
+	gui.Views.Search.Editor = gocui.EditorFunc(gui.searchEditor)
 
 	gui.Views.AppStatus.BgColor = gocui.ColorDefault
 	gui.Views.AppStatus.FgColor = gocui.ColorCyan
@@ -230,6 +237,7 @@ func (gui *Gui) layout(g *gocui.Gui) error {
 		{viewName: "commitFiles", windowName: gui.State.Contexts.CommitFiles.GetWindowName(), frame: true},
 		{viewName: "commits", windowName: "commits", frame: true},
 		{viewName: "stash", windowName: "stash", frame: true},
+		{viewName: "cmdLog", windowName: "cmdLog", frame: true},
 		{viewName: "options", windowName: "options", frame: false},
 		{viewName: "searchPrefix", windowName: "searchPrefix", frame: false},
 		{viewName: "search", windowName: "search", frame: false},
@@ -280,7 +288,7 @@ func (gui *Gui) layout(g *gocui.Gui) error {
 		}
 
 		// check if the selected line is now out of view and if so refocus it
-		view.FocusPoint(0, listContext.GetPanelState().GetSelectedLineIdx())
+		listContext.focusSelectedLine(view)
 
 		view.SelBgColor = theme.GocuiSelectedLineBgColor
 
@@ -385,14 +393,18 @@ func (gui *Gui) onInitialViewsCreation() error {
 		return err
 	}
 
+	popupTasks := []func(chan struct{}) error{}
 	if !gui.Config.GetUserConfig().DisableStartupPopups {
-		popupTasks := []func(chan struct{}) error{}
 		storedPopupVersion := gui.Config.GetAppState().StartupPopupVersion
 		if storedPopupVersion < StartupPopupVersion {
 			popupTasks = append(popupTasks, gui.showIntroPopupMessage)
 		}
-		gui.showInitialPopups(popupTasks)
 	}
+	if err := gui.Config.ValidateUserConfig(UserConfigValidators()...); err != nil {
+		gui.Log.Warn(err)
+		popupTasks = append(popupTasks, gui.showConfigProblemsPopup(err))
+	}
+	gui.showInitialPopups(popupTasks)
 
 	if gui.showRecentRepos {
 		if err := gui.handleCreateRecentReposMenu(); err != nil {
@@ -6,6 +6,7 @@ import (
 	"github.com/go-errors/errors"
 	"github.com/jesseduffield/gocui"
 	"github.com/jesseduffield/lazygit/pkg/commands/patch"
+	"github.com/jesseduffield/lazygit/pkg/gui/modes/filtering"
 )
 
 // Currently there are two 'pseudo-panels' that make use of this 'pseudo-panel'.
@@ -72,6 +73,7 @@ func (gui *Gui) refreshLineByLinePanel(diff string, secondaryDiff string, second
 		FirstLineIdx:     firstLineIdx,
 		LastLineIdx:      lastLineIdx,
 		Diff:             diff,
+		SecondaryDiff:    secondaryDiff,
 		SecondaryFocused: secondaryFocused,
 	}
 	gui.State.Panels.LineByLine = state
@@ -333,7 +335,7 @@ func (gui *Gui) handleOpenFileAtLine() error {
 		selectedHunk := state.PatchParser.GetHunkContainingLine(state.SelectedLineIdx, 0)
 		lineNumber := selectedHunk.LineNumberOfLine(state.SelectedLineIdx)
 		filenameWithLineNum := fmt.Sprintf("%s:%d", filename, lineNumber)
-		if err := gui.OSCommand.OpenFile(filenameWithLineNum); err != nil {
+		if err := gui.osCommandWithSpan(gui.Tr.SpanOpenFile).OpenFile(filenameWithLineNum); err != nil {
 			return err
 		}
 
@@ -341,6 +343,68 @@ func (gui *Gui) handleOpenFileAtLine() error {
 	})
 }
 
+// selectedLineRange returns the selected lines as a range of lines of the file,
+// along with the ref whose version of the file the line numbers refer to
+func (gui *Gui) selectedLineRange() (string, *filtering.LineRange) {
+	gui.Mutexes.LineByLinePanelMutex.Lock()
+	defer gui.Mutexes.LineByLinePanelMutex.Unlock()
+
+	state := gui.State.Panels.LineByLine
+	if state == nil {
+		return "", nil
+	}
+
+	firstHunk := state.PatchParser.GetHunkContainingLine(state.FirstLineIdx, 0)
+	lastHunk := state.PatchParser.GetHunkContainingLine(state.LastLineIdx, 0)
+	// when selecting a whole hunk, the first selected line is the hunk header
+	firstLineIdx := state.FirstLineIdx
+	if firstLineIdx <= firstHunk.FirstLineIdx {
+		firstLineIdx = firstHunk.FirstLineIdx + 1
+	}
+
+	var filename string
+	var lineRange *filtering.LineRange
+	switch gui.State.MainContext {
+	case gui.State.Contexts.PatchBuilding.GetKey():
+		filename = gui.getSelectedCommitFileName()
+		lineRange = &filtering.LineRange{
+			Start: firstHunk.LineNumberOfLine(firstLineIdx),
+			End:   lastHunk.LineNumberOfLine(state.LastLineIdx),
+			Ref:   gui.State.Panels.CommitFiles.refName,
+		}
+	case gui.State.Contexts.Staging.GetKey():
+		file := gui.getSelectedFile()
+		if file == nil {
+			return "", nil
+		}
+		filename = file.Name
+		// it's only the lines' committed history we can follow, so we go by the
+		// old version of the file. For staged changes that's HEAD's version, but
+		// for unstaged changes it's the index's, so we map those line numbers
+		// back through the staged changes
+		lineRange = &filtering.LineRange{
+			Start: firstHunk.OldLineNumberOfLine(firstLineIdx),
+			End:   lastHunk.OldLineNumberOfLine(state.LastLineIdx),
+			Ref:   "HEAD",
+		}
+		if !state.SecondaryFocused {
+			stagedHunks := patch.GetHunksFromDiff(state.SecondaryDiff)
+			lineRange.Start = patch.OldLineNumber(stagedHunks, lineRange.Start)
+			lineRange.End = patch.OldLineNumber(stagedHunks, lineRange.End)
+		}
+	default:
+		return "", nil
+	}
+
+	// if we've only selected added lines there's nothing in the old version of
+	// the file to follow, so we follow the line they were added before
+	if lineRange.End < lineRange.Start {
+		lineRange.End = lineRange.Start
+	}
+
+	return filename, lineRange
+}
+
 func (gui *Gui) handleLineByLineNextPage() error {
 	return gui.withLBLActiveCheck(func(state *lBlPanelState) error {
 		newSelectedLineIdx := state.SelectedLineIdx + gui.pageDelta(gui.Views.Main)
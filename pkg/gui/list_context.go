@@ -1,9 +1,12 @@
 package gui
 
 import (
+	"sort"
+
 	"github.com/fatih/color"
 	"github.com/jesseduffield/gocui"
 	"github.com/jesseduffield/lazygit/pkg/gui/presentation"
+	"github.com/jesseduffield/lazygit/pkg/theme"
 	"github.com/jesseduffield/lazygit/pkg/utils"
 )
 
@@ -20,19 +23,34 @@ type ListContext struct {
 	// the boolean here tells us whether the item is nil. This is needed because you can't work it out on the calling end once the pointer is wrapped in an interface (unless you want to use reflection)
 	SelectedItem  func() (ListItem, bool)
 	GetPanelState func() IListPanelState
+	// returns the item at the given index, so that we can filter the list by
+	// the items' descriptions. Lists without it can't be filtered
+	GetItem func(int) ListItem
 
 	Gui                        *Gui
 	ResetMainViewOriginOnFocus bool
-	Kind                       ContextKind
-	ParentContext              Context
+	// whether the user can select a range of items to act on in bulk
+	SupportsRangeSelect bool
+	Kind                ContextKind
+	ParentContext       Context
 	// we can't know on the calling end whether a Context is actually a nil value without reflection, so we're storing this flag here to tell us. There has got to be a better way around this.
 	hasParent  bool
 	WindowName string
+
+	// what the user is filtering the list by, if anything
+	filter string
+	// the indices of the items matching the filter as of the last render, which
+	// are the items shown in the view
+	filteredIndices []int
 }
 
 type IListPanelState interface {
 	SetSelectedLineIdx(int)
 	GetSelectedLineIdx() int
+	ToggleRangeSelect()
+	CancelRangeSelect()
+	IsRangeSelecting() bool
+	GetSelectedRange() (int, int)
 }
 
 type ListItem interface {
@@ -97,12 +115,132 @@ func (lc *ListContext) OnRender() error {
 
 	if lc.GetDisplayStrings != nil {
 		lc.Gui.refreshSelectedLine(lc.GetPanelState(), lc.GetItemsLength())
-		lc.Gui.renderDisplayStrings(view, lc.GetDisplayStrings())
+		displayStrings := lc.GetDisplayStrings()
+		if lc.GetPanelState().IsRangeSelecting() {
+			startIdx, endIdx := lc.getSelectedRange()
+			for i := startIdx; i <= endIdx && i < len(displayStrings); i++ {
+				for j, cell := range displayStrings[i] {
+					displayStrings[i][j] = utils.ColoredString(utils.Decolorise(cell), theme.SelectedRangeBgColor)
+				}
+			}
+		}
+		if lc.isFiltering() {
+			displayStrings = lc.applyFilter(displayStrings)
+		}
+		lc.Gui.renderDisplayStrings(view, displayStrings)
 	}
 
 	return nil
 }
 
+func (lc *ListContext) isFiltering() bool {
+	return lc.filter != ""
+}
+
+// applyFilter works out which items match the filter and returns the display
+// strings of those items. Our panel state keeps referring to the selected
+// item by its index in the full list, so handlers act on the right item, but
+// if the selected item has been filtered out we select the first one left
+func (lc *ListContext) applyFilter(displayStrings [][]string) [][]string {
+	descriptions := make([]string, lc.GetItemsLength())
+	for i := range descriptions {
+		descriptions[i] = lc.GetItem(i).Description()
+	}
+	// we keep the items in the order they're listed in rather than sorting them
+	// by how well they match, so that e.g. commits still go from newest to oldest
+	lc.filteredIndices = utils.FuzzySearchIndexes(lc.filter, descriptions)
+	sort.Ints(lc.filteredIndices)
+
+	if lc.viewLineIdx(lc.GetPanelState().GetSelectedLineIdx()) == -1 {
+		if len(lc.filteredIndices) == 0 {
+			lc.GetPanelState().SetSelectedLineIdx(-1)
+		} else {
+			lc.GetPanelState().SetSelectedLineIdx(lc.filteredIndices[0])
+		}
+	}
+
+	filteredDisplayStrings := make([][]string, 0, len(lc.filteredIndices))
+	for _, idx := range lc.filteredIndices {
+		if idx < len(displayStrings) {
+			filteredDisplayStrings = append(filteredDisplayStrings, displayStrings[idx])
+		}
+	}
+
+	return filteredDisplayStrings
+}
+
+// viewLineIdx returns the line in the view of the item at the given index,
+// which is -1 if the item has been filtered out
+func (lc *ListContext) viewLineIdx(idx int) int {
+	if !lc.isFiltering() {
+		return idx
+	}
+
+	for i, filteredIdx := range lc.filteredIndices {
+		if filteredIdx == idx {
+			return i
+		}
+	}
+
+	return -1
+}
+
+// itemIdx returns the index of the item on the given line of the view, which
+// is -1 if there's no item there
+func (lc *ListContext) itemIdx(viewLineIdx int) int {
+	if !lc.isFiltering() {
+		return viewLineIdx
+	}
+
+	if viewLineIdx < 0 || viewLineIdx >= len(lc.filteredIndices) {
+		return -1
+	}
+
+	return lc.filteredIndices[viewLineIdx]
+}
+
+func (lc *ListContext) focusSelectedLine(view *gocui.View) {
+	view.FocusPoint(0, lc.viewLineIdx(lc.GetPanelState().GetSelectedLineIdx()))
+}
+
+// getSelectedRange returns the indices of the first and last selected items,
+// which may have moved out of bounds since the range was started if the list
+// has since shrunk
+func (lc *ListContext) getSelectedRange() (int, int) {
+	startIdx, endIdx := lc.GetPanelState().GetSelectedRange()
+	if startIdx < 0 {
+		startIdx = 0
+	}
+	if endIdx > lc.GetItemsLength()-1 {
+		endIdx = lc.GetItemsLength() - 1
+	}
+
+	return startIdx, endIdx
+}
+
+func (lc *ListContext) handleToggleRangeSelect() error {
+	// a range could take in items we've filtered out
+	if lc.Gui.popupPanelFocused() || lc.isFiltering() {
+		return nil
+	}
+
+	lc.GetPanelState().ToggleRangeSelect()
+
+	return lc.HandleRender()
+}
+
+// cancelRangeSelect is called when escaping, and by the handlers which act on
+// the selected range once they're done with it
+func (lc *ListContext) cancelRangeSelect() error {
+	if !lc.GetPanelState().IsRangeSelecting() {
+		return nil
+	}
+
+	lc.GetPanelState().CancelRangeSelect()
+
+	return lc.HandleRender()
+}
+
 func (lc *ListContext) GetKey() ContextKey {
 	return lc.ContextKey
 }
@@ -133,7 +271,14 @@ func (lc *ListContext) HandleFocus() error {
 		return nil
 	}
 
-	view.FocusPoint(0, lc.GetPanelState().GetSelectedLineIdx())
+	lc.focusSelectedLine(view)
+
+	// the highlighted range moves with the cursor
+	if lc.GetPanelState().IsRangeSelecting() {
+		if err := lc.HandleRender(); err != nil {
+			return err
+		}
+	}
 
 	if lc.ResetMainViewOriginOnFocus {
 		if err := lc.Gui.resetOrigin(lc.Gui.Views.Main); err != nil {
@@ -177,13 +322,25 @@ func (lc *ListContext) handleLineChange(change int) error {
 		return err
 	}
 
-	selectedLineIdx := lc.GetPanelState().GetSelectedLineIdx()
-	if (change < 0 && selectedLineIdx == 0) || (change > 0 && selectedLineIdx == lc.GetItemsLength()-1) {
-		return nil
-	}
+	if lc.isFiltering() {
+		// we move through the lines of the view rather than through every item
+		lineIdx := lc.viewLineIdx(lc.GetPanelState().GetSelectedLineIdx())
+		if (change < 0 && lineIdx <= 0) || (change > 0 && lineIdx == len(lc.filteredIndices)-1) {
+			return nil
+		}
 
-	lc.Gui.changeSelectedLine(lc.GetPanelState(), lc.GetItemsLength(), change)
-	view.FocusPoint(0, lc.GetPanelState().GetSelectedLineIdx())
+		lineState := &listPanelState{SelectedLineIdx: lineIdx}
+		lc.Gui.changeSelectedLine(lineState, len(lc.filteredIndices), change)
+		lc.GetPanelState().SetSelectedLineIdx(lc.itemIdx(lineState.SelectedLineIdx))
+	} else {
+		selectedLineIdx := lc.GetPanelState().GetSelectedLineIdx()
+		if (change < 0 && selectedLineIdx == 0) || (change > 0 && selectedLineIdx == lc.GetItemsLength()-1) {
+			return nil
+		}
+
+		lc.Gui.changeSelectedLine(lc.GetPanelState(), lc.GetItemsLength(), change)
+	}
+	lc.focusSelectedLine(view)
 
 	return lc.HandleFocus()
 }
@@ -228,14 +385,14 @@ func (lc *ListContext) handleClick() error {
 	}
 
 	prevSelectedLineIdx := lc.GetPanelState().GetSelectedLineIdx()
-	newSelectedLineIdx := view.SelectedLineIdx()
+	newSelectedLineIdx := lc.itemIdx(view.SelectedLineIdx())
 
 	// we need to focus the view
 	if err := lc.Gui.pushContext(lc); err != nil {
 		return err
 	}
 
-	if newSelectedLineIdx > lc.GetItemsLength()-1 {
+	if newSelectedLineIdx == -1 || newSelectedLineIdx > lc.GetItemsLength()-1 {
 		return nil
 	}
 
@@ -249,7 +406,7 @@ func (lc *ListContext) handleClick() error {
 }
 
 func (lc *ListContext) onSearchSelect(selectedLineIdx int) error {
-	lc.GetPanelState().SetSelectedLineIdx(selectedLineIdx)
+	lc.GetPanelState().SetSelectedLineIdx(lc.itemIdx(selectedLineIdx))
 	return lc.HandleFocus()
 }
 
@@ -257,16 +414,18 @@ func (gui *Gui) menuListContext() *ListContext {
 	return &ListContext{
 		ViewName:                   "menu",
 		ContextKey:                 "menu",
-		GetItemsLength:             func() int { return gui.Views.Menu.LinesHeight() },
+		GetItemsLength:             func() int { return len(gui.State.MenuItems) },
 		GetPanelState:              func() IListPanelState { return gui.State.Panels.Menu },
+		GetItem:                    func(i int) ListItem { return gui.State.MenuItems[i] },
 		OnFocus:                    gui.handleMenuSelect,
 		OnClickSelectedItem:        gui.onMenuPress,
 		Gui:                        gui,
 		ResetMainViewOriginOnFocus: false,
 		Kind:                       PERSISTENT_POPUP,
 		OnGetOptionsMap:            gui.getMenuOptions,
-
-		// no GetDisplayStrings field because we do a custom render on menu creation
+		GetDisplayStrings: func() [][]string {
+			return gui.getMenuDisplayStrings(gui.State.MenuItems)
+		},
 	}
 }
 
@@ -276,10 +435,12 @@ func (gui *Gui) filesListContext() *ListContext {
 		ContextKey:                 FILES_CONTEXT_KEY,
 		GetItemsLength:             func() int { return gui.State.FileManager.GetItemsLength() },
 		GetPanelState:              func() IListPanelState { return gui.State.Panels.Files },
+		GetItem:                    func(i int) ListItem { return gui.State.FileManager.GetItemAtIndex(i) },
 		OnFocus:                    gui.focusAndSelectFile,
 		OnClickSelectedItem:        gui.handleFilePress,
 		Gui:                        gui,
 		ResetMainViewOriginOnFocus: false,
+		SupportsRangeSelect:        true,
 		Kind:                       SIDE_CONTEXT,
 		GetDisplayStrings: func() [][]string {
 			lines := gui.State.FileManager.Render(gui.State.Modes.Diffing.Ref, gui.State.Submodules)
@@ -303,12 +464,14 @@ func (gui *Gui) branchesListContext() *ListContext {
 		ContextKey:                 LOCAL_BRANCHES_CONTEXT_KEY,
 		GetItemsLength:             func() int { return len(gui.State.Branches) },
 		GetPanelState:              func() IListPanelState { return gui.State.Panels.Branches },
+		GetItem:                    func(i int) ListItem { return gui.State.Branches[i] },
 		OnFocus:                    gui.handleBranchSelect,
 		Gui:                        gui,
 		ResetMainViewOriginOnFocus: true,
+		SupportsRangeSelect:        true,
 		Kind:                       SIDE_CONTEXT,
 		GetDisplayStrings: func() [][]string {
-			return presentation.GetBranchListDisplayStrings(gui.State.Branches, gui.State.ScreenMode != SCREEN_NORMAL, gui.State.Modes.Diffing.Ref)
+			return presentation.GetBranchListDisplayStrings(gui.State.Branches, gui.State.ScreenMode != SCREEN_NORMAL, gui.State.Modes.Diffing.Ref, gui.State.BranchPullRequests)
 		},
 		SelectedItem: func() (ListItem, bool) {
 			item := gui.getSelectedBranch()
@@ -323,6 +486,7 @@ func (gui *Gui) remotesListContext() *ListContext {
 		ContextKey:                 REMOTES_CONTEXT_KEY,
 		GetItemsLength:             func() int { return len(gui.State.Remotes) },
 		GetPanelState:              func() IListPanelState { return gui.State.Panels.Remotes },
+		GetItem:                    func(i int) ListItem { return gui.State.Remotes[i] },
 		OnFocus:                    gui.handleRemoteSelect,
 		OnClickSelectedItem:        gui.handleRemoteEnter,
 		Gui:                        gui,
@@ -344,6 +508,7 @@ func (gui *Gui) remoteBranchesListContext() *ListContext {
 		ContextKey:                 REMOTE_BRANCHES_CONTEXT_KEY,
 		GetItemsLength:             func() int { return len(gui.State.RemoteBranches) },
 		GetPanelState:              func() IListPanelState { return gui.State.Panels.RemoteBranches },
+		GetItem:                    func(i int) ListItem { return gui.State.RemoteBranches[i] },
 		OnFocus:                    gui.handleRemoteBranchSelect,
 		Gui:                        gui,
 		ResetMainViewOriginOnFocus: true,
@@ -364,12 +529,14 @@ func (gui *Gui) tagsListContext() *ListContext {
 		ContextKey:                 TAGS_CONTEXT_KEY,
 		GetItemsLength:             func() int { return len(gui.State.Tags) },
 		GetPanelState:              func() IListPanelState { return gui.State.Panels.Tags },
+		GetItem:                    func(i int) ListItem { return gui.State.Tags[i] },
 		OnFocus:                    gui.handleTagSelect,
 		Gui:                        gui,
 		ResetMainViewOriginOnFocus: true,
+		SupportsRangeSelect:        true,
 		Kind:                       SIDE_CONTEXT,
 		GetDisplayStrings: func() [][]string {
-			return presentation.GetTagListDisplayStrings(gui.State.Tags, gui.State.Modes.Diffing.Ref)
+			return presentation.GetTagListDisplayStrings(gui.State.Tags, gui.State.ScreenMode != SCREEN_NORMAL, gui.State.Modes.Diffing.Ref)
 		},
 		SelectedItem: func() (ListItem, bool) {
 			item := gui.getSelectedTag()
@@ -378,19 +545,67 @@ func (gui *Gui) tagsListContext() *ListContext {
 	}
 }
 
+func (gui *Gui) worktreesListContext() *ListContext {
+	return &ListContext{
+		ViewName:                   "branches",
+		ContextKey:                 WORKTREES_CONTEXT_KEY,
+		GetItemsLength:             func() int { return len(gui.State.Worktrees) },
+		GetPanelState:              func() IListPanelState { return gui.State.Panels.Worktrees },
+		GetItem:                    func(i int) ListItem { return gui.State.Worktrees[i] },
+		OnFocus:                    gui.handleWorktreeSelect,
+		OnClickSelectedItem:        gui.handleSwitchToWorktree,
+		Gui:                        gui,
+		ResetMainViewOriginOnFocus: true,
+		Kind:                       SIDE_CONTEXT,
+		GetDisplayStrings: func() [][]string {
+			return presentation.GetWorktreeListDisplayStrings(gui.State.Worktrees, gui.State.ScreenMode != SCREEN_NORMAL)
+		},
+		SelectedItem: func() (ListItem, bool) {
+			item := gui.getSelectedWorktree()
+			return item, item != nil
+		},
+	}
+}
+
+func (gui *Gui) pullRequestsListContext() *ListContext {
+	return &ListContext{
+		ViewName:                   "branches",
+		ContextKey:                 PULL_REQUESTS_CONTEXT_KEY,
+		GetItemsLength:             func() int { return len(gui.State.PullRequests) },
+		GetPanelState:              func() IListPanelState { return gui.State.Panels.PullRequests },
+		GetItem:                    func(i int) ListItem { return gui.State.PullRequests[i] },
+		OnFocus:                    gui.handlePullRequestSelect,
+		OnClickSelectedItem:        gui.handleCheckoutPullRequest,
+		Gui:                        gui,
+		ResetMainViewOriginOnFocus: true,
+		Kind:                       SIDE_CONTEXT,
+		GetDisplayStrings: func() [][]string {
+			return presentation.GetPullRequestListDisplayStrings(gui.State.PullRequests, gui.State.ScreenMode != SCREEN_NORMAL)
+		},
+		SelectedItem: func() (ListItem, bool) {
+			item := gui.getSelectedPullRequest()
+			return item, item != nil
+		},
+	}
+}
+
 func (gui *Gui) branchCommitsListContext() *ListContext {
 	return &ListContext{
 		ViewName:                   "commits",
 		ContextKey:                 BRANCH_COMMITS_CONTEXT_KEY,
 		GetItemsLength:             func() int { return len(gui.State.Commits) },
 		GetPanelState:              func() IListPanelState { return gui.State.Panels.Commits },
+		GetItem:                    func(i int) ListItem { return gui.State.Commits[i] },
 		OnFocus:                    gui.handleCommitSelect,
 		OnClickSelectedItem:        gui.handleViewCommitFiles,
 		Gui:                        gui,
 		ResetMainViewOriginOnFocus: true,
+		SupportsRangeSelect:        true,
 		Kind:                       SIDE_CONTEXT,
 		GetDisplayStrings: func() [][]string {
-			return presentation.GetCommitListDisplayStrings(gui.State.Commits, gui.State.ScreenMode != SCREEN_NORMAL, gui.cherryPickedCommitShaMap(), gui.State.Modes.Diffing.Ref)
+			// the graph doesn't line up once commits are filtered out of the list
+			showGraph := gui.Config.GetUserConfig().Gui.ShowCommitGraph && !gui.State.Contexts.BranchCommits.isFiltering()
+			return presentation.GetCommitListDisplayStrings(gui.State.Commits, gui.State.ScreenMode != SCREEN_NORMAL, gui.cherryPickedCommitShaMap(), gui.State.Modes.Diffing.Ref, gui.State.Modes.Bisecting.Info, showGraph)
 		},
 		SelectedItem: func() (ListItem, bool) {
 			item := gui.getSelectedLocalCommit()
@@ -405,6 +620,7 @@ func (gui *Gui) reflogCommitsListContext() *ListContext {
 		ContextKey:                 REFLOG_COMMITS_CONTEXT_KEY,
 		GetItemsLength:             func() int { return len(gui.State.FilteredReflogCommits) },
 		GetPanelState:              func() IListPanelState { return gui.State.Panels.ReflogCommits },
+		GetItem:                    func(i int) ListItem { return gui.State.FilteredReflogCommits[i] },
 		OnFocus:                    gui.handleReflogCommitSelect,
 		Gui:                        gui,
 		ResetMainViewOriginOnFocus: true,
@@ -425,12 +641,14 @@ func (gui *Gui) subCommitsListContext() *ListContext {
 		ContextKey:                 SUB_COMMITS_CONTEXT_KEY,
 		GetItemsLength:             func() int { return len(gui.State.SubCommits) },
 		GetPanelState:              func() IListPanelState { return gui.State.Panels.SubCommits },
+		GetItem:                    func(i int) ListItem { return gui.State.SubCommits[i] },
 		OnFocus:                    gui.handleSubCommitSelect,
 		Gui:                        gui,
 		ResetMainViewOriginOnFocus: true,
 		Kind:                       SIDE_CONTEXT,
 		GetDisplayStrings: func() [][]string {
-			return presentation.GetCommitListDisplayStrings(gui.State.SubCommits, gui.State.ScreenMode != SCREEN_NORMAL, gui.cherryPickedCommitShaMap(), gui.State.Modes.Diffing.Ref)
+			showGraph := gui.Config.GetUserConfig().Gui.ShowCommitGraph && !gui.State.Contexts.SubCommits.isFiltering()
+			return presentation.GetCommitListDisplayStrings(gui.State.SubCommits, gui.State.ScreenMode != SCREEN_NORMAL, gui.cherryPickedCommitShaMap(), gui.State.Modes.Diffing.Ref, gui.State.Modes.Bisecting.Info, showGraph)
 		},
 		SelectedItem: func() (ListItem, bool) {
 			item := gui.getSelectedSubCommit()
@@ -445,9 +663,11 @@ func (gui *Gui) stashListContext() *ListContext {
 		ContextKey:                 STASH_CONTEXT_KEY,
 		GetItemsLength:             func() int { return len(gui.State.StashEntries) },
 		GetPanelState:              func() IListPanelState { return gui.State.Panels.Stash },
+		GetItem:                    func(i int) ListItem { return gui.State.StashEntries[i] },
 		OnFocus:                    gui.handleStashEntrySelect,
 		Gui:                        gui,
 		ResetMainViewOriginOnFocus: true,
+		SupportsRangeSelect:        true,
 		Kind:                       SIDE_CONTEXT,
 		GetDisplayStrings: func() [][]string {
 			return presentation.GetStashEntryListDisplayStrings(gui.State.StashEntries, gui.State.Modes.Diffing.Ref)
@@ -466,6 +686,7 @@ func (gui *Gui) commitFilesListContext() *ListContext {
 		ContextKey:                 COMMIT_FILES_CONTEXT_KEY,
 		GetItemsLength:             func() int { return gui.State.CommitFileManager.GetItemsLength() },
 		GetPanelState:              func() IListPanelState { return gui.State.Panels.CommitFiles },
+		GetItem:                    func(i int) ListItem { return gui.State.CommitFileManager.GetItemAtIndex(i) },
 		OnFocus:                    gui.handleCommitFileSelect,
 		Gui:                        gui,
 		ResetMainViewOriginOnFocus: true,
@@ -497,6 +718,7 @@ func (gui *Gui) submodulesListContext() *ListContext {
 		ContextKey:                 SUBMODULES_CONTEXT_KEY,
 		GetItemsLength:             func() int { return len(gui.State.Submodules) },
 		GetPanelState:              func() IListPanelState { return gui.State.Panels.Submodules },
+		GetItem:                    func(i int) ListItem { return gui.State.Submodules[i] },
 		OnFocus:                    gui.handleSubmoduleSelect,
 		Gui:                        gui,
 		ResetMainViewOriginOnFocus: true,
@@ -536,7 +758,8 @@ func (gui *Gui) getListContexts() []*ListContext {
 		gui.State.Contexts.Remotes,
 		gui.State.Contexts.RemoteBranches,
 		gui.State.Contexts.Tags,
-		gui.State.Contexts.BranchCommits,
+		gui.State.Contexts.Worktrees,
+		gui.State.Contexts.PullRequests,
 		gui.State.Contexts.BranchCommits,
 		gui.State.Contexts.ReflogCommits,
 		gui.State.Contexts.SubCommits,
@@ -568,13 +791,40 @@ func (gui *Gui) getListContextKeyBindings() []*Binding {
 			{ViewName: listContext.ViewName, Contexts: []string{string(listContext.ContextKey)}, Key: gocui.MouseLeft, Modifier: gocui.ModNone, Handler: listContext.handleClick},
 		}...)
 
-		// the commits panel needs to lazyload things so it has a couple of its own handlers
+		// the commits panel needs to lazyload things so it has a few of its own handlers
 		openSearchHandler := gui.handleOpenSearch
+		openFilterHandler := gui.handleOpenFilter
 		gotoBottomHandler := listContext.handleGotoBottom
 		if listContext.ViewName == "commits" {
 			openSearchHandler = gui.handleOpenSearchForCommitsPanel
+			openFilterHandler = gui.handleOpenFilterForCommitsPanel
 			gotoBottomHandler = gui.handleGotoBottomForCommitsPanel
 		}
+		// menus are short enough that narrowing them down beats jumping between matches
+		if listContext.ContextKey == MENU_CONTEXT_KEY {
+			openSearchHandler = func(string) error { return gui.handleOpenFilter(listContext) }
+		}
+
+		if listContext.GetItem != nil {
+			bindings = append(bindings, &Binding{
+				ViewName:    listContext.ViewName,
+				Contexts:    []string{string(listContext.ContextKey)},
+				Key:         gui.getKey(keybindingConfig.Universal.StartFilter),
+				Handler:     func() error { return openFilterHandler(listContext) },
+				Description: gui.Tr.LcStartFilter,
+				Tag:         "navigation",
+			})
+		}
+
+		if listContext.SupportsRangeSelect {
+			bindings = append(bindings, &Binding{
+				ViewName:    listContext.ViewName,
+				Contexts:    []string{string(listContext.ContextKey)},
+				Key:         gui.getKey(keybindingConfig.Universal.ToggleRangeSelect),
+				Handler:     listContext.handleToggleRangeSelect,
+				Description: gui.Tr.LcToggleRangeSelect,
+			})
+		}
 
 		bindings = append(bindings, []*Binding{
 			{
@@ -65,6 +65,8 @@ func NewRenderStringWithoutScrollTask(str string) *renderStringWithoutScrollTask
 type runCommandTask struct {
 	cmd    *exec.Cmd
 	prefix string
+	stream bool
+	onDone func()
 }
 
 func (t *runCommandTask) GetKind() TaskKind {
@@ -79,6 +81,13 @@ func NewRunCommandTaskWithPrefix(cmd *exec.Cmd, prefix string) *runCommandTask {
 	return &runCommandTask{cmd: cmd, prefix: prefix}
 }
 
+// NewStreamCommandTask shows the command's output as it comes in, for commands
+// that take a while. onDone is called when the command exits or is killed, and
+// may be called more than once
+func NewStreamCommandTask(cmd *exec.Cmd, onDone func()) *runCommandTask {
+	return &runCommandTask{cmd: cmd, stream: true, onDone: onDone}
+}
+
 type runPtyTask struct {
 	cmd    *exec.Cmd
 	prefix string
@@ -126,6 +135,9 @@ func (gui *Gui) runTaskForView(view *gocui.View, task updateTask) error {
 
 	case RUN_COMMAND:
 		specificTask := task.(*runCommandTask)
+		if specificTask.stream {
+			return gui.newStreamCmdTask(view, specificTask.cmd, specificTask.onDone)
+		}
 		return gui.newCmdTask(view, specificTask.cmd, specificTask.prefix)
 
 	case RUN_PTY:
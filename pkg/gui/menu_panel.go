@@ -4,7 +4,9 @@ import (
 	"fmt"
 	"strings"
 
+	"github.com/fatih/color"
 	"github.com/jesseduffield/gocui"
+	"github.com/jesseduffield/lazygit/pkg/config"
 	"github.com/jesseduffield/lazygit/pkg/theme"
 	"github.com/jesseduffield/lazygit/pkg/utils"
 )
@@ -13,6 +15,8 @@ type menuItem struct {
 	displayString  string
 	displayStrings []string
 	onPress        func() error
+	// optional key which presses the item while the menu is open
+	key interface{}
 }
 
 // every item in a list context needs an ID
@@ -24,6 +28,19 @@ func (i *menuItem) ID() string {
 	return strings.Join(i.displayStrings, "-")
 }
 
+// Description is what we match against when filtering the menu
+func (i *menuItem) Description() string {
+	return utils.Decolorise(strings.Join(i.getDisplayStrings(), " "))
+}
+
+func (i *menuItem) getDisplayStrings() []string {
+	if i.displayStrings == nil {
+		return []string{i.displayString}
+	}
+
+	return i.displayStrings
+}
+
 // list panel functions
 
 func (gui *Gui) handleMenuSelect() error {
@@ -38,11 +55,16 @@ func (gui *Gui) getMenuOptions() map[string]string {
 	return map[string]string{
 		gui.getKeyDisplay(keybindingConfig.Universal.Return): gui.Tr.LcClose,
 		fmt.Sprintf("%s %s", gui.getKeyDisplay(keybindingConfig.Universal.PrevItem), gui.getKeyDisplay(keybindingConfig.Universal.NextItem)): gui.Tr.LcNavigate,
-		gui.getKeyDisplay(keybindingConfig.Universal.Select): gui.Tr.LcExecute,
+		gui.getKeyDisplay(keybindingConfig.Universal.Select):      gui.Tr.LcExecute,
+		gui.getKeyDisplay(keybindingConfig.Universal.StartSearch): gui.Tr.LcFilterMenu,
 	}
 }
 
 func (gui *Gui) handleMenuClose() error {
+	if gui.State.Searching.filterContext == gui.State.Contexts.Menu {
+		return gui.clearFilter()
+	}
+
 	return gui.returnFromContext()
 }
 
@@ -63,16 +85,18 @@ func (gui *Gui) createMenu(title string, items []*menuItem, createMenuOptions cr
 
 	gui.State.MenuItems = items
 
-	stringArrays := make([][]string, len(items))
-	for i, item := range items {
-		if item.displayStrings == nil {
-			stringArrays[i] = []string{item.displayString}
-		} else {
-			stringArrays[i] = item.displayStrings
+	// we may be opening this menu from another one which we'd filtered
+	if gui.State.Searching.filterContext == gui.State.Contexts.Menu {
+		if err := gui.clearFilter(); err != nil {
+			return err
 		}
 	}
 
-	list := utils.RenderDisplayStrings(stringArrays)
+	if err := gui.setMenuKeybindings(items); err != nil {
+		return err
+	}
+
+	list := utils.RenderDisplayStrings(gui.getMenuDisplayStrings(items))
 
 	x0, y0, x1, y1 := gui.getConfirmationPanelDimensions(false, list)
 	menuView, _ := gui.g.SetView("menu", x0, y0, x1, y1, 0)
@@ -94,9 +118,100 @@ func (gui *Gui) createMenu(title string, items []*menuItem, createMenuOptions cr
 
 func (gui *Gui) onMenuPress() error {
 	selectedLine := gui.State.Panels.Menu.SelectedLineIdx
+	// we may have filtered out every item
+	if selectedLine == -1 {
+		return nil
+	}
+
 	if err := gui.State.MenuItems[selectedLine].onPress(); err != nil {
 		return err
 	}
 
 	return gui.returnFromContext()
 }
+
+// getMenuDisplayStrings puts the items' keys in their own column, if any of
+// them have one
+func (gui *Gui) getMenuDisplayStrings(items []*menuItem) [][]string {
+	hasKeys := false
+	for _, item := range items {
+		if gui.getMenuItemKey(item) != nil {
+			hasKeys = true
+			break
+		}
+	}
+
+	displayStrings := make([][]string, len(items))
+	for i, item := range items {
+		displayStrings[i] = item.getDisplayStrings()
+		if hasKeys {
+			keyDisplay := ""
+			if key := gui.getMenuItemKey(item); key != nil {
+				keyDisplay = utils.ColoredString(GetKeyDisplay(key), color.FgCyan)
+			}
+			displayStrings[i] = append([]string{keyDisplay}, displayStrings[i]...)
+		}
+	}
+
+	return displayStrings
+}
+
+// reservedMenuKeys are the keys we need for pressing items, filtering or
+// closing the menu, which an item's key wouldn't do what it says for
+func reservedMenuKeys(keybindingConfig config.KeybindingConfig) []string {
+	return []string{
+		keybindingConfig.Universal.Return,
+		keybindingConfig.Universal.Confirm,
+		keybindingConfig.Universal.ConfirmAlt1,
+		keybindingConfig.Universal.Select,
+		keybindingConfig.Universal.StartSearch,
+	}
+}
+
+// getMenuItemKey returns the item's key, unless it's one of the menu's own
+func (gui *Gui) getMenuItemKey(item *menuItem) interface{} {
+	if item.key == nil {
+		return nil
+	}
+
+	for _, reservedKey := range reservedMenuKeys(gui.Config.GetUserConfig().Keybinding) {
+		if gui.getKey(reservedKey) == item.key {
+			return nil
+		}
+	}
+
+	return item.key
+}
+
+// setMenuKeybindings lets you press a menu item with its key. We want an item's
+// key to win out over the menu's own keybindings, like those for navigating,
+// and gocui goes with the first keybinding it finds for a key, so we set up the
+// menu's own keybindings again after those of the items
+func (gui *Gui) setMenuKeybindings(items []*menuItem) error {
+	gui.g.DeleteKeybindings("menu")
+
+	for i, item := range items {
+		key := gui.getMenuItemKey(item)
+		if key == nil {
+			continue
+		}
+
+		i := i
+		handler := func() error {
+			// the item may have been filtered out
+			if gui.State.Contexts.Menu.viewLineIdx(i) == -1 {
+				return nil
+			}
+			gui.State.Panels.Menu.SelectedLineIdx = i
+			return gui.onMenuPress()
+		}
+		if err := gui.g.SetKeybinding("menu", nil, key, gocui.ModNone, gui.wrappedHandler(handler)); err != nil {
+			return err
+		}
+	}
+
+	bindings := append(gui.GetCustomCommandKeybindings(), gui.GetInitialKeybindings()...)
+	return gui.registerKeybindings(bindings, func(binding *Binding) bool {
+		return binding.ViewName == "menu"
+	})
+}
@@ -0,0 +1,84 @@
+package gui
+
+import (
+	"github.com/jesseduffield/lazygit/pkg/commands"
+	"github.com/jesseduffield/lazygit/pkg/commands/models"
+	"github.com/jesseduffield/lazygit/pkg/utils"
+)
+
+// handleCreateMergeConflictMenu lets the user resolve whole files at once by
+// taking one side, which is also the only way to resolve a conflict where one
+// side deleted the file, given there's nothing to show in the merge panel
+func (gui *Gui) handleCreateMergeConflictMenu() error {
+	if !gui.anyFilesWithMergeConflicts() {
+		return gui.createErrorPanel(gui.Tr.NoMergeConflicts)
+	}
+
+	menuItems := []*menuItem{}
+
+	node := gui.getSelectedFileNode()
+	if node != nil && node.AnyFile(func(file *models.File) bool { return file.HasMergeConflicts }) {
+		for _, side := range []string{"ours", "theirs"} {
+			side := side
+
+			label := gui.Tr.LcUseOursFor
+			if side == "theirs" {
+				label = gui.Tr.LcUseTheirsFor
+			}
+			displayString := utils.ResolvePlaceholderString(label, map[string]string{"path": node.GetPath()})
+			if node.File != nil && node.File.MissingOnConflictSide(side) {
+				displayString += " " + gui.Tr.LcDeletesFile
+			}
+
+			menuItems = append(menuItems, &menuItem{
+				displayString: displayString,
+				onPress: func() error {
+					if err := gui.withSpan(gui.Tr.SpanResolveConflicts).ResolveDirConflictsWithSide(node, side); err != nil {
+						return gui.surfaceError(err)
+					}
+					return gui.handleConflictsResolvedWithSide()
+				},
+			})
+		}
+	}
+
+	menuItems = append(menuItems, []*menuItem{
+		{
+			displayString: gui.Tr.LcUseOursForAll,
+			onPress: func() error {
+				return gui.resolveAllConflictsWithSide("ours")
+			},
+		},
+		{
+			displayString: gui.Tr.LcUseTheirsForAll,
+			onPress: func() error {
+				return gui.resolveAllConflictsWithSide("theirs")
+			},
+		},
+	}...)
+
+	return gui.createMenu(gui.Tr.MergeConflictOptionsTitle, menuItems, createMenuOptions{showCancel: true})
+}
+
+func (gui *Gui) resolveAllConflictsWithSide(side string) error {
+	gitCommand := gui.withSpan(gui.Tr.SpanResolveConflicts)
+	for _, file := range gui.State.FileManager.GetAllFiles() {
+		if err := gitCommand.ResolveConflictWithSide(file, side); err != nil {
+			return gui.surfaceError(err)
+		}
+	}
+
+	return gui.handleConflictsResolvedWithSide()
+}
+
+func (gui *Gui) handleConflictsResolvedWithSide() error {
+	if err := gui.refreshSidePanels(refreshOptions{scope: []RefreshableView{FILES}, mode: SYNC}); err != nil {
+		return err
+	}
+
+	if gui.GitCommand.WorkingTreeState() != commands.REBASE_MODE_NORMAL && !gui.anyFilesWithMergeConflicts() {
+		return gui.promptToContinueRebase()
+	}
+
+	return nil
+}
@@ -16,22 +16,43 @@ import (
 	"github.com/jesseduffield/lazygit/pkg/gui/mergeconflicts"
 )
 
-func (gui *Gui) handleSelectTop() error {
+func (gui *Gui) handleSelectPrevConflictHunk() error {
 	return gui.withMergeConflictLock(func() error {
 		gui.takeOverMergeConflictScrolling()
-		gui.State.Panels.Merging.ConflictTop = true
+		gui.moveConflictSelection(-1)
 		return gui.refreshMergePanel()
 	})
 }
 
-func (gui *Gui) handleSelectBottom() error {
+func (gui *Gui) handleSelectNextConflictHunk() error {
 	return gui.withMergeConflictLock(func() error {
 		gui.takeOverMergeConflictScrolling()
-		gui.State.Panels.Merging.ConflictTop = false
+		gui.moveConflictSelection(1)
 		return gui.refreshMergePanel()
 	})
 }
 
+// moveConflictSelection moves between ours, the base version (if shown) and
+// theirs, stopping at either end
+func (gui *Gui) moveConflictSelection(change int) {
+	conflict := gui.getCurrentConflict()
+	if conflict == nil {
+		return
+	}
+
+	panelState := gui.State.Panels.Merging
+	selections := mergeconflicts.AvailableSelections(*conflict)
+	for i, selection := range selections {
+		if selection == panelState.ConflictSelection {
+			newIndex := i + change
+			if newIndex >= 0 && newIndex < len(selections) {
+				panelState.ConflictSelection = selections[newIndex]
+			}
+			return
+		}
+	}
+}
+
 func (gui *Gui) handleSelectNextConflict() error {
 	return gui.withMergeConflictLock(func() error {
 		gui.takeOverMergeConflictScrolling()
@@ -96,11 +117,7 @@ func (gui *Gui) handlePickHunk() error {
 			return err
 		}
 
-		selection := mergeconflicts.BOTTOM
-		if gui.State.Panels.Merging.ConflictTop {
-			selection = mergeconflicts.TOP
-		}
-		err := gui.resolveConflict(*conflict, selection)
+		err := gui.resolveConflict(*conflict, gui.State.Panels.Merging.ConflictSelection)
 		if err != nil {
 			panic(err)
 		}
@@ -127,7 +144,7 @@ func (gui *Gui) handlePickBothHunks() error {
 		if err := gui.pushFileSnapshot(); err != nil {
 			return err
 		}
-		err := gui.resolveConflict(*conflict, mergeconflicts.BOTH)
+		err := gui.resolveConflict(*conflict, mergeconflicts.ALL)
 		if err != nil {
 			panic(err)
 		}
@@ -193,8 +210,14 @@ func (gui *Gui) refreshMergePanel() error {
 		panelState.ConflictIndex = len(panelState.Conflicts) - 1
 	}
 
+	// moving from a diff3-style conflict to one without a base version (say, after
+	// the user edits the file) leaves nothing in the middle to select
+	if panelState.ConflictSelection == mergeconflicts.MIDDLE && !panelState.Conflicts[panelState.ConflictIndex].HasAncestor() {
+		panelState.ConflictSelection = mergeconflicts.TOP
+	}
+
 	hasFocus := gui.currentViewName() == "main"
-	content := mergeconflicts.ColoredConflictFile(cat, panelState.Conflicts, panelState.ConflictIndex, panelState.ConflictTop, hasFocus)
+	content := mergeconflicts.ColoredConflictFile(cat, panelState.Conflicts, panelState.ConflictIndex, panelState.ConflictSelection, hasFocus)
 
 	if err := gui.scrollToConflict(); err != nil {
 		return err
@@ -255,9 +278,10 @@ func (gui *Gui) getMergingOptions() map[string]string {
 	return map[string]string{
 		fmt.Sprintf("%s %s", gui.getKeyDisplay(keybindingConfig.Universal.PrevItem), gui.getKeyDisplay(keybindingConfig.Universal.NextItem)):   gui.Tr.LcSelectHunk,
 		fmt.Sprintf("%s %s", gui.getKeyDisplay(keybindingConfig.Universal.PrevBlock), gui.getKeyDisplay(keybindingConfig.Universal.NextBlock)): gui.Tr.LcNavigateConflicts,
-		gui.getKeyDisplay(keybindingConfig.Universal.Select):   gui.Tr.LcPickHunk,
-		gui.getKeyDisplay(keybindingConfig.Main.PickBothHunks): gui.Tr.LcPickBothHunks,
-		gui.getKeyDisplay(keybindingConfig.Universal.Undo):     gui.Tr.LcUndo,
+		gui.getKeyDisplay(keybindingConfig.Universal.Select):    gui.Tr.LcPickHunk,
+		gui.getKeyDisplay(keybindingConfig.Main.PickBothHunks):  gui.Tr.LcPickBothHunks,
+		gui.getKeyDisplay(keybindingConfig.Universal.Undo):      gui.Tr.LcUndo,
+		gui.getKeyDisplay(keybindingConfig.Files.OpenMergeTool): gui.Tr.LcOpenMergeTool,
 	}
 }
 
@@ -295,6 +319,70 @@ func (gui *Gui) handleCompleteMerge() error {
 	return gui.handleEscapeMerge()
 }
 
+func (gui *Gui) handleOpenMergeTool() error {
+	file := gui.getSelectedFile()
+	if file == nil {
+		return nil
+	}
+
+	if !file.HasMergeConflicts {
+		return gui.createErrorPanel(gui.Tr.FileNoMergeCons)
+	}
+
+	tool := gui.Config.GetUserConfig().Git.Merging.Tool
+	cmd := gui.GitCommand.OpenMergeToolCmd(tool, file.Name)
+	if err := gui.runSubprocessWithSuspense(gui.Tr.SpanOpenMergeTool, cmd); err != nil {
+		return err
+	}
+
+	if gui.currentViewName() == "main" {
+		if err := gui.handleEscapeMerge(); err != nil {
+			return err
+		}
+	}
+
+	return gui.handleMergeToolExit(file.Name)
+}
+
+// handleMergeToolExit stages the file if the merge tool resolved it. git
+// mergetool usually does this itself, but not when it can't trust the tool's
+// exit code and the file was saved as is
+func (gui *Gui) handleMergeToolExit(fileName string) error {
+	if err := gui.refreshSidePanels(refreshOptions{scope: []RefreshableView{FILES}, mode: SYNC}); err != nil {
+		return err
+	}
+
+	for _, file := range gui.State.FileManager.GetAllFiles() {
+		if file.Name != fileName || !file.HasMergeConflicts {
+			continue
+		}
+
+		// the tool might have deleted the file, in which case it's for the user to
+		// decide whether to stage that
+		content, err := ioutil.ReadFile(fileName)
+		if err != nil {
+			return nil
+		}
+		if len(mergeconflicts.FindConflicts(string(content))) > 0 {
+			gui.raiseToast(gui.Tr.MergeToolLeftConflicts)
+			return nil
+		}
+
+		if err := gui.withSpan(gui.Tr.SpanStageFile).StageFile(fileName); err != nil {
+			return gui.surfaceError(err)
+		}
+		if err := gui.refreshSidePanels(refreshOptions{scope: []RefreshableView{FILES}, mode: SYNC}); err != nil {
+			return err
+		}
+	}
+
+	if gui.GitCommand.WorkingTreeState() != commands.REBASE_MODE_NORMAL && !gui.anyFilesWithMergeConflicts() {
+		return gui.promptToContinueRebase()
+	}
+
+	return nil
+}
+
 // promptToContinueRebase asks the user if they want to continue the rebase/merge that's in progress
 func (gui *Gui) promptToContinueRebase() error {
 	gui.takeOverMergeConflictScrolling()
@@ -10,14 +10,51 @@ import (
 	"github.com/jesseduffield/lazygit/pkg/utils"
 )
 
+// Selection is a section of a conflict: ours at the top, the base version in the
+// middle (only with diff3-style conflicts), and theirs at the bottom
 type Selection int
 
 const (
 	TOP Selection = iota
+	MIDDLE
 	BOTTOM
-	BOTH
+	ALL
 )
 
+type lineType int
+
+const (
+	NOT_A_MARKER lineType = iota
+	START
+	ANCESTOR
+	TARGET
+	END
+)
+
+// markerType tells us which conflict bar the line is, if any. Bars are followed
+// by a label like 'HEAD' or the subject of the commit being picked, which could
+// be anything, except for the '=======' bar which has none
+func markerType(line string) lineType {
+	line = strings.TrimPrefix(line, "++")
+
+	isMarker := func(marker string) bool {
+		return line == marker || strings.HasPrefix(line, marker+" ")
+	}
+
+	switch {
+	case isMarker("<<<<<<<"):
+		return START
+	case isMarker("|||||||"):
+		return ANCESTOR
+	case line == "=======":
+		return TARGET
+	case isMarker(">>>>>>>"):
+		return END
+	}
+
+	return NOT_A_MARKER
+}
+
 func FindConflicts(content string) []commands.Conflict {
 	conflicts := make([]commands.Conflict, 0)
 
@@ -26,25 +63,43 @@ func FindConflicts(content string) []commands.Conflict {
 	}
 
 	var newConflict commands.Conflict
+	inConflict := false
 	for i, line := range utils.SplitLines(content) {
-		trimmedLine := strings.TrimPrefix(line, "++")
-		switch trimmedLine {
-		case "<<<<<<< HEAD", "<<<<<<< MERGE_HEAD", "<<<<<<< Updated upstream", "<<<<<<< ours":
+		switch markerType(line) {
+		case START:
 			newConflict = commands.Conflict{Start: i}
-		case "=======":
-			newConflict.Middle = i
-		default:
-			if strings.HasPrefix(trimmedLine, ">>>>>>> ") {
+			inConflict = true
+		case ANCESTOR:
+			if inConflict && newConflict.Middle == 0 {
+				newConflict.Ancestor = i
+			}
+		case TARGET:
+			if inConflict {
+				newConflict.Middle = i
+			}
+		case END:
+			if inConflict && newConflict.Middle != 0 {
 				newConflict.End = i
 				conflicts = append(conflicts, newConflict)
 			}
+			inConflict = false
 		}
-
 	}
+
 	return conflicts
 }
 
-func ColoredConflictFile(content string, conflicts []commands.Conflict, conflictIndex int, conflictTop, hasFocus bool) string {
+// AvailableSelections are the sections of the conflict that can be picked on
+// their own, from top to bottom
+func AvailableSelections(conflict commands.Conflict) []Selection {
+	if conflict.HasAncestor() {
+		return []Selection{TOP, MIDDLE, BOTTOM}
+	}
+
+	return []Selection{TOP, BOTTOM}
+}
+
+func ColoredConflictFile(content string, conflicts []commands.Conflict, conflictIndex int, selection Selection, hasFocus bool) string {
 	if len(conflicts) == 0 {
 		return content
 	}
@@ -52,11 +107,15 @@ func ColoredConflictFile(content string, conflicts []commands.Conflict, conflict
 	var outputBuffer bytes.Buffer
 	for i, line := range utils.SplitLines(content) {
 		colourAttr := theme.DefaultTextColor
-		if i == conflict.Start || i == conflict.Middle || i == conflict.End {
+		if i == conflict.Start || i == conflict.Middle || i == conflict.End || (conflict.HasAncestor() && i == conflict.Ancestor) {
 			colourAttr = color.FgRed
+		} else if conflict.HasAncestor() && i > conflict.Ancestor && i < conflict.Middle {
+			// the base version is there for reference, so we don't want it competing
+			// with ours and theirs for attention
+			colourAttr = color.FgCyan
 		}
 		colour := color.New(colourAttr)
-		if hasFocus && conflictIndex < len(conflicts) && conflicts[conflictIndex] == conflict && shouldHighlightLine(i, conflict, conflictTop) {
+		if hasFocus && conflictIndex < len(conflicts) && conflicts[conflictIndex] == conflict && shouldHighlightLine(i, conflict, selection) {
 			colour.Add(color.Bold)
 			colour.Add(theme.SelectedRangeBgColor)
 		}
@@ -68,19 +127,53 @@ func ColoredConflictFile(content string, conflicts []commands.Conflict, conflict
 	return outputBuffer.String()
 }
 
+// IsIndexToDelete tells us whether the line goes when resolving the conflict in
+// favour of the selected section. The bars always go
 func IsIndexToDelete(i int, conflict commands.Conflict, selection Selection) bool {
-	return i == conflict.Middle ||
-		i == conflict.Start ||
-		i == conflict.End ||
-		selection != BOTH &&
-			(selection == BOTTOM && i > conflict.Start && i < conflict.Middle) ||
-		(selection == TOP && i > conflict.Middle && i < conflict.End)
+	if i < conflict.Start || i > conflict.End {
+		return false
+	}
+
+	if i == conflict.Start || i == conflict.Middle || i == conflict.End || (conflict.HasAncestor() && i == conflict.Ancestor) {
+		return true
+	}
+
+	return selection != ALL && selection != sectionOf(i, conflict)
+}
+
+// sectionOf tells us which section of the conflict the line is in, given it
+// isn't one of the bars
+func sectionOf(i int, conflict commands.Conflict) Selection {
+	switch {
+	case i > conflict.Middle:
+		return BOTTOM
+	case conflict.HasAncestor() && i > conflict.Ancestor:
+		return MIDDLE
+	default:
+		return TOP
+	}
 }
 
 func shiftConflict(conflicts []commands.Conflict) (commands.Conflict, []commands.Conflict) {
 	return conflicts[0], conflicts[1:]
 }
 
-func shouldHighlightLine(index int, conflict commands.Conflict, top bool) bool {
-	return (index >= conflict.Start && index <= conflict.Middle && top) || (index >= conflict.Middle && index <= conflict.End && !top)
+// shouldHighlightLine tells us whether the line is in the selected section,
+// including the bars either side of it
+func shouldHighlightLine(index int, conflict commands.Conflict, selection Selection) bool {
+	topEnd := conflict.Middle
+	if conflict.HasAncestor() {
+		topEnd = conflict.Ancestor
+	}
+
+	switch selection {
+	case TOP:
+		return index >= conflict.Start && index <= topEnd
+	case MIDDLE:
+		return conflict.HasAncestor() && index >= conflict.Ancestor && index <= conflict.Middle
+	case BOTTOM:
+		return index >= conflict.Middle && index <= conflict.End
+	default:
+		return index >= conflict.Start && index <= conflict.End
+	}
 }
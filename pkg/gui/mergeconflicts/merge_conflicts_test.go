@@ -0,0 +1,132 @@
+package mergeconflicts
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/jesseduffield/lazygit/pkg/commands"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestFindConflicts is a function.
+func TestFindConflicts(t *testing.T) {
+	type scenario struct {
+		testName string
+		content  string
+		expected []commands.Conflict
+	}
+
+	scenarios := []scenario{
+		{
+			"empty file",
+			"",
+			[]commands.Conflict{},
+		},
+		{
+			"merge conflict",
+			`line
+<<<<<<< HEAD
+ours
+=======
+theirs
+>>>>>>> branch
+line
+<<<<<<< HEAD
+=======
+theirs
+>>>>>>> branch
+`,
+			[]commands.Conflict{
+				{Start: 1, Middle: 3, End: 5},
+				{Start: 7, Middle: 8, End: 10},
+			},
+		},
+		{
+			"diff3 conflict while picking a commit",
+			`<<<<<<< HEAD
+ours
+||||||| parent of 1234567 (Change the line)
+base
+=======
+theirs
+>>>>>>> 1234567 (Change the line)
+`,
+			[]commands.Conflict{
+				{Start: 0, Ancestor: 2, Middle: 4, End: 6},
+			},
+		},
+		{
+			"zdiff3 conflict with an empty base",
+			`<<<<<<< ours
+|||||||
+=======
+theirs
+>>>>>>> theirs
+`,
+			[]commands.Conflict{
+				{Start: 0, Ancestor: 1, Middle: 2, End: 4},
+			},
+		},
+		{
+			"bars without labels inside the content are ignored",
+			`=======
+>>>>>>>>>
+<<<<<<< Stashed changes
+ours
+=======
+theirs
+>>>>>>> Updated upstream
+`,
+			[]commands.Conflict{
+				{Start: 2, Middle: 4, End: 6},
+			},
+		},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.testName, func(t *testing.T) {
+			assert.EqualValues(t, s.expected, FindConflicts(s.content))
+		})
+	}
+}
+
+// TestIsIndexToDelete is a function.
+func TestIsIndexToDelete(t *testing.T) {
+	content := `before
+<<<<<<< HEAD
+ours
+||||||| base
+base
+=======
+theirs
+>>>>>>> branch
+after`
+
+	type scenario struct {
+		testName  string
+		selection Selection
+		expected  string
+	}
+
+	scenarios := []scenario{
+		{"ours", TOP, "before\nours\nafter"},
+		{"base", MIDDLE, "before\nbase\nafter"},
+		{"theirs", BOTTOM, "before\ntheirs\nafter"},
+		{"all", ALL, "before\nours\nbase\ntheirs\nafter"},
+	}
+
+	lines := strings.Split(content, "\n")
+	conflict := FindConflicts(content)[0]
+
+	for _, s := range scenarios {
+		t.Run(s.testName, func(t *testing.T) {
+			kept := []string{}
+			for i, line := range lines {
+				if !IsIndexToDelete(i, conflict, s.selection) {
+					kept = append(kept, line)
+				}
+			}
+			assert.EqualValues(t, s.expected, strings.Join(kept, "\n"))
+		})
+	}
+}
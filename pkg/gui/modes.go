@@ -40,7 +40,7 @@ func (gui *Gui) modeStatuses() []modeStatus {
 			isActive: gui.State.Modes.Filtering.Active,
 			description: func() string {
 				return utils.ColoredString(
-					fmt.Sprintf("%s '%s' %s", gui.Tr.LcFilteringBy, gui.State.Modes.Filtering.GetPath(), utils.ColoredString(gui.Tr.ResetInParentheses, color.Underline)),
+					fmt.Sprintf("%s %s", gui.filteringDescription(), utils.ColoredString(gui.Tr.ResetInParentheses, color.Underline)),
 					color.FgRed,
 					color.Bold,
 				)
@@ -57,5 +57,35 @@ func (gui *Gui) modeStatuses() []modeStatus {
 			},
 			reset: gui.exitCherryPickingMode,
 		},
+		{
+			isActive: gui.State.Modes.Bisecting.Active,
+			description: func() string {
+				return utils.ColoredString(
+					fmt.Sprintf("%s %s", gui.bisectStatusStr(), utils.ColoredString(gui.Tr.ResetInParentheses, color.Underline)),
+					color.FgGreen,
+				)
+			},
+			reset: gui.handleResetBisect,
+		},
+		{
+			isActive: gui.State.Modes.RebasePlanning.Active,
+			description: func() string {
+				return utils.ColoredString(
+					fmt.Sprintf("%s %s", gui.rebasePlanningStatusStr(), utils.ColoredString(gui.Tr.ResetInParentheses, color.Underline)),
+					color.FgBlue,
+				)
+			},
+			reset: gui.exitRebasePlanning,
+		},
+		{
+			isActive: gui.State.Modes.MarkedBaseCommit.Active,
+			description: func() string {
+				return utils.ColoredString(
+					fmt.Sprintf("%s %s", gui.markedBaseCommitStatusStr(), utils.ColoredString(gui.Tr.ResetInParentheses, color.Underline)),
+					color.FgYellow,
+				)
+			},
+			reset: gui.exitMarkedBaseCommit,
+		},
 	}
 }
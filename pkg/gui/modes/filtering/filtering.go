@@ -2,6 +2,18 @@ package filtering
 
 type Filtering struct {
 	path string // the filename that gets passed to git log
+
+	// set when we're following the history of a range of lines in the file
+	// rather than the whole file
+	lineRange *LineRange
+}
+
+type LineRange struct {
+	Start int
+	End   int
+
+	// the ref whose version of the file the line numbers refer to
+	Ref string
 }
 
 func NewFiltering(path string) Filtering {
@@ -14,12 +26,23 @@ func (m *Filtering) Active() bool {
 
 func (m *Filtering) Reset() {
 	m.path = ""
+	m.lineRange = nil
 }
 
 func (m *Filtering) SetPath(path string) {
 	m.path = path
+	m.lineRange = nil
 }
 
 func (m *Filtering) GetPath() string {
 	return m.path
 }
+
+func (m *Filtering) SetLineRange(path string, lineRange *LineRange) {
+	m.path = path
+	m.lineRange = lineRange
+}
+
+func (m *Filtering) GetLineRange() *LineRange {
+	return m.lineRange
+}
@@ -16,7 +16,12 @@ func (gui *Gui) getBindings(v *gocui.View) []*Binding {
 	bindings := append(gui.GetCustomCommandKeybindings(), gui.GetInitialKeybindings()...)
 
 	for _, binding := range bindings {
-		if GetKeyDisplay(binding.Key) != "" && binding.Description != "" {
+		if binding.Condition != nil && !binding.Condition() {
+			continue
+		}
+
+		// bindings without a key are here for the options menu's sake
+		if (binding.Key == nil || GetKeyDisplay(binding.Key) != "") && binding.Description != "" {
 			switch binding.ViewName {
 			case "":
 				bindingsGlobal = append(bindingsGlobal, binding)
@@ -60,7 +65,8 @@ func (gui *Gui) handleCreateOptionsMenu() error {
 		menuItems[i] = &menuItem{
 			displayStrings: []string{GetKeyDisplay(binding.Key), gui.displayDescription(binding)},
 			onPress: func() error {
-				if binding.Key == nil {
+				// the separator
+				if binding.Handler == nil {
 					return nil
 				}
 				if err := gui.handleMenuClose(); err != nil {
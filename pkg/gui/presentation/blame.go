@@ -0,0 +1,36 @@
+package presentation
+
+import (
+	"fmt"
+
+	"github.com/fatih/color"
+	"github.com/jesseduffield/lazygit/pkg/commands/models"
+	"github.com/jesseduffield/lazygit/pkg/utils"
+)
+
+func GetBlameDisplayStrings(lines []*models.BlameLine, notCommittedStr string) [][]string {
+	result := make([][]string, len(lines))
+
+	for i := range lines {
+		result[i] = getBlameLineDisplayStrings(lines[i], notCommittedStr)
+	}
+
+	return result
+}
+
+func getBlameLineDisplayStrings(b *models.BlameLine, notCommittedStr string) []string {
+	shaStr := utils.ColoredString(b.ShortSha(), color.FgYellow)
+	author := b.Author
+	if !b.Committed() {
+		shaStr = utils.ColoredString(b.ShortSha(), color.FgRed)
+		author = notCommittedStr
+	}
+
+	return []string{
+		shaStr,
+		utils.ColoredString(utils.TruncateWithEllipsis(author, 17), color.FgGreen),
+		utils.ColoredString(utils.UnixToTimeAgo(b.UnixTimestamp), color.FgCyan),
+		utils.ColoredString(fmt.Sprintf("%d", b.LineNumber), color.FgBlue),
+		b.Content,
+	}
+}
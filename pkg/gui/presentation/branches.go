@@ -10,19 +10,19 @@ import (
 	"github.com/jesseduffield/lazygit/pkg/utils"
 )
 
-func GetBranchListDisplayStrings(branches []*models.Branch, fullDescription bool, diffName string) [][]string {
+func GetBranchListDisplayStrings(branches []*models.Branch, fullDescription bool, diffName string, pullRequests map[string]*models.PullRequest) [][]string {
 	lines := make([][]string, len(branches))
 
 	for i := range branches {
 		diffed := branches[i].Name == diffName
-		lines[i] = getBranchDisplayStrings(branches[i], fullDescription, diffed)
+		lines[i] = getBranchDisplayStrings(branches[i], fullDescription, diffed, pullRequests[branches[i].Name])
 	}
 
 	return lines
 }
 
 // getBranchDisplayStrings returns the display string of branch
-func getBranchDisplayStrings(b *models.Branch, fullDescription bool, diffed bool) []string {
+func getBranchDisplayStrings(b *models.Branch, fullDescription bool, diffed bool, pr *models.PullRequest) []string {
 	displayName := b.Name
 	if b.DisplayName != "" {
 		displayName = b.DisplayName
@@ -41,6 +41,9 @@ func getBranchDisplayStrings(b *models.Branch, fullDescription bool, diffed bool
 		track := utils.ColoredString(fmt.Sprintf("↑%s↓%s", b.Pushables, b.Pullables), trackColor)
 		coloredName = fmt.Sprintf("%s %s", coloredName, track)
 	}
+	if pr != nil {
+		coloredName = fmt.Sprintf("%s %s", coloredName, GetPullRequestBadge(pr))
+	}
 
 	recencyColor := color.FgCyan
 	if b.Recency == "  *" {
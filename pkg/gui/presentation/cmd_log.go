@@ -0,0 +1,47 @@
+package presentation
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/fatih/color"
+	"github.com/jesseduffield/lazygit/pkg/gui/cmdlog"
+	"github.com/jesseduffield/lazygit/pkg/utils"
+)
+
+func GetCmdLogDisplayString(spans []cmdlog.Span) string {
+	lines := []string{}
+	for _, span := range spans {
+		lines = append(lines, utils.ColoredString(span.Name, color.FgYellow))
+		for _, entry := range span.Entries {
+			lines = append(lines, getCmdLogEntryDisplayString(entry))
+			for _, line := range strings.Split(strings.TrimRight(entry.Output, "\n"), "\n") {
+				if line != "" {
+					lines = append(lines, "    "+line)
+				}
+			}
+		}
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+func getCmdLogEntryDisplayString(entry *cmdlog.Entry) string {
+	status := "…"
+	commandColor := color.FgCyan
+	if entry.Done {
+		if entry.ExitCode == 0 {
+			status = utils.ColoredString("✓", color.FgGreen)
+		} else {
+			status = utils.ColoredString(fmt.Sprintf("✗ %d", entry.ExitCode), color.FgRed)
+			commandColor = color.FgRed
+		}
+	}
+
+	return fmt.Sprintf(
+		"  %s %s %s",
+		utils.ColoredString(entry.Start.Format("15:04:05"), color.FgBlue),
+		utils.ColoredString(entry.String(), commandColor),
+		status,
+	)
+}
@@ -9,25 +9,37 @@ import (
 	"github.com/jesseduffield/lazygit/pkg/utils"
 )
 
-func GetCommitListDisplayStrings(commits []*models.Commit, fullDescription bool, cherryPickedCommitShaMap map[string]bool, diffName string) [][]string {
+func GetCommitListDisplayStrings(commits []*models.Commit, fullDescription bool, cherryPickedCommitShaMap map[string]bool, diffName string, bisectInfo *models.BisectInfo, showGraph bool) [][]string {
 	lines := make([][]string, len(commits))
 
-	var displayFunc func(*models.Commit, map[string]bool, bool) []string
+	var displayFunc func(*models.Commit, map[string]bool, bool, *models.BisectInfo) []string
 	if fullDescription {
 		displayFunc = getFullDescriptionDisplayStringsForCommit
 	} else {
 		displayFunc = getDisplayStringsForCommit
 	}
 
+	var graphLines []string
+	if showGraph {
+		graphLines = RenderCommitGraph(commits)
+	}
+
 	for i := range commits {
 		diffed := commits[i].Sha == diffName
-		lines[i] = displayFunc(commits[i], cherryPickedCommitShaMap, diffed)
+		lines[i] = displayFunc(commits[i], cherryPickedCommitShaMap, diffed, bisectInfo)
+
+		if showGraph {
+			// the graph goes in front of the commit message, given that it's our
+			// last column and therefore the only one that isn't padded out
+			last := len(lines[i]) - 1
+			lines[i][last] = graphLines[i] + lines[i][last]
+		}
 	}
 
 	return lines
 }
 
-func getFullDescriptionDisplayStringsForCommit(c *models.Commit, cherryPickedCommitShaMap map[string]bool, diffed bool) []string {
+func getFullDescriptionDisplayStringsForCommit(c *models.Commit, cherryPickedCommitShaMap map[string]bool, diffed bool, bisectInfo *models.BisectInfo) []string {
 	red := color.New(color.FgRed)
 	yellow := color.New(color.FgYellow)
 	green := color.New(color.FgGreen)
@@ -73,10 +85,10 @@ func getFullDescriptionDisplayStringsForCommit(c *models.Commit, cherryPickedCom
 
 	truncatedAuthor := utils.TruncateWithEllipsis(c.Author, 17)
 
-	return []string{shaColor.Sprint(c.ShortSha()), secondColumnString, yellow.Sprint(truncatedAuthor), tagString + defaultColor.Sprint(c.Name)}
+	return []string{shaColor.Sprint(c.ShortSha()), secondColumnString, yellow.Sprint(truncatedAuthor), getBisectString(c, bisectInfo) + tagString + defaultColor.Sprint(c.Name)}
 }
 
-func getDisplayStringsForCommit(c *models.Commit, cherryPickedCommitShaMap map[string]bool, diffed bool) []string {
+func getDisplayStringsForCommit(c *models.Commit, cherryPickedCommitShaMap map[string]bool, diffed bool, bisectInfo *models.BisectInfo) []string {
 	red := color.New(color.FgRed)
 	yellow := color.New(color.FgYellow)
 	green := color.New(color.FgGreen)
@@ -120,7 +132,38 @@ func getDisplayStringsForCommit(c *models.Commit, cherryPickedCommitShaMap map[s
 		tagString = utils.ColoredStringDirect(strings.Join(c.Tags, " "), tagColor) + " "
 	}
 
-	return []string{shaColor.Sprint(c.ShortSha()), actionString + tagString + defaultColor.Sprint(c.Name)}
+	return []string{shaColor.Sprint(c.ShortSha()), actionString + getBisectString(c, bisectInfo) + tagString + defaultColor.Sprint(c.Name)}
+}
+
+// getBisectString shows the term a commit was marked with during a bisect, as
+// well as which commit we're currently meant to be testing
+func getBisectString(c *models.Commit, bisectInfo *models.BisectInfo) string {
+	if bisectInfo == nil || !bisectInfo.Started {
+		return ""
+	}
+
+	if c.Sha == bisectInfo.FirstNewSha {
+		return color.New(color.FgRed, color.Bold).Sprintf("<-- first %s commit ", bisectInfo.NewTerm)
+	}
+
+	if term, ok := bisectInfo.Term(c.Sha); ok {
+		var termColor color.Attribute
+		switch bisectInfo.Statuses[c.Sha] {
+		case models.BisectStatusNew:
+			termColor = color.FgRed
+		case models.BisectStatusOld:
+			termColor = color.FgGreen
+		default:
+			termColor = color.FgYellow
+		}
+		return color.New(termColor).Sprint(term) + " "
+	}
+
+	if c.Sha == bisectInfo.CurrentSha && !bisectInfo.Done() {
+		return color.New(color.FgMagenta, color.Bold).Sprint("<-- current ")
+	}
+
+	return ""
 }
 
 func actionColorMap(str string) color.Attribute {
@@ -0,0 +1,245 @@
+package presentation
+
+import (
+	"strings"
+
+	"github.com/fatih/color"
+	"github.com/jesseduffield/lazygit/pkg/commands/models"
+)
+
+var graphColors = []color.Attribute{
+	color.FgCyan,
+	color.FgGreen,
+	color.FgYellow,
+	color.FgBlue,
+	color.FgMagenta,
+	color.FgRed,
+}
+
+// a lane is a column of the graph, waiting on the commit with the given sha to
+// come along further down the list
+type graphLane struct {
+	sha   string
+	color color.Attribute
+}
+
+type graphCell struct {
+	glyph      string
+	glyphColor color.Attribute
+	// the character joining this cell to the one on its right
+	connector      string
+	connectorColor color.Attribute
+}
+
+// RenderCommitGraph returns the graph to show to the left of each commit,
+// computed from the commits' parents. Commits are expected to be in the order
+// that git log gives us, i.e. children before their parents
+func RenderCommitGraph(commits []*models.Commit) []string {
+	lines := make([]string, len(commits))
+	lanes := []*graphLane{}
+
+	colorIndex := 0
+	nextColor := func() color.Attribute {
+		result := graphColors[colorIndex%len(graphColors)]
+		colorIndex++
+		return result
+	}
+
+	for i, commit := range commits {
+		parents := commit.Parents
+		if commit.Status == "rebasing" {
+			// commits we've yet to rebase don't have parents that we know of, but
+			// they'll end up stacked on top of one another, and on top of HEAD
+			parents = nil
+			if i+1 < len(commits) {
+				parents = []string{commits[i+1].Sha}
+			}
+		}
+
+		var cells []graphCell
+		cells, lanes = renderGraphRow(lanes, commit.Sha, parents, nextColor)
+		lines[i] = renderGraphCells(cells)
+	}
+
+	return lines
+}
+
+func renderGraphRow(lanes []*graphLane, sha string, parents []string, nextColor func() color.Attribute) ([]graphCell, []*graphLane) {
+	prevLanes := make([]*graphLane, len(lanes))
+	copy(prevLanes, lanes)
+
+	// if nothing was waiting on this commit it's the tip of a branch so it gets
+	// a lane of its own
+	col := findLane(lanes, sha)
+	if col == -1 {
+		lanes, col = allocateLane(lanes, nil)
+		lanes[col] = &graphLane{sha: sha, color: nextColor()}
+	}
+	commitColor := lanes[col].color
+
+	// any other lanes waiting on this commit end here: this is where their
+	// branches forked off
+	targets := map[int]color.Attribute{}
+	converging := map[int]bool{}
+	for i, lane := range lanes {
+		if i != col && lane != nil && lane.sha == sha {
+			targets[i] = lane.color
+			converging[i] = true
+			lanes[i] = nil
+		}
+	}
+
+	// our own lane carries on to our first parent
+	if len(parents) == 0 {
+		lanes[col] = nil
+	} else {
+		lanes[col] = &graphLane{sha: parents[0], color: commitColor}
+	}
+
+	// other parents mean we're a merge commit, so we join the lane already
+	// headed for that parent or start a new one
+	startingLanes := map[int]bool{}
+	for _, parent := range parents[min(1, len(parents)):] {
+		i := findLane(lanes, parent)
+		if i == -1 {
+			lanes, i = allocateLane(lanes, converging)
+			lanes[i] = &graphLane{sha: parent, color: nextColor()}
+			startingLanes[i] = true
+		}
+		targets[i] = lanes[i].color
+	}
+
+	left, right := col, col
+	for i := range targets {
+		left = min(left, i)
+		right = max(right, i)
+	}
+
+	// nearer targets take precedence when colouring the horizontal lines, so we
+	// colour from the outside in
+	connectorColors := map[int]color.Attribute{}
+	for distance := max(col-left, right-col); distance > 0; distance-- {
+		if targetColor, ok := targets[col-distance]; ok {
+			for i := col - distance; i < col; i++ {
+				connectorColors[i] = targetColor
+			}
+		}
+		if targetColor, ok := targets[col+distance]; ok {
+			for i := col; i < col+distance; i++ {
+				connectorColors[i] = targetColor
+			}
+		}
+	}
+
+	glyph := "◯"
+	if len(parents) > 1 {
+		glyph = "⏣"
+	}
+
+	width := max(len(prevLanes), len(lanes))
+	cells := make([]graphCell, width)
+	for i := range cells {
+		cell := graphCell{glyph: " ", connector: " "}
+
+		targetColor, isTarget := targets[i]
+		switch {
+		case i == col:
+			cell.glyph = glyph
+			cell.glyphColor = commitColor
+		case converging[i]:
+			cell.glyph = pickGlyph(i < col, "╰", "╯")
+			cell.glyphColor = targetColor
+		case isTarget && startingLanes[i]:
+			cell.glyph = pickGlyph(i < col, "╭", "╮")
+			cell.glyphColor = targetColor
+		case isTarget:
+			cell.glyph = pickGlyph(i < col, "├", "┤")
+			cell.glyphColor = targetColor
+		case i < len(prevLanes) && prevLanes[i] != nil:
+			cell.glyph = "│"
+			cell.glyphColor = prevLanes[i].color
+		case left < i && i < right:
+			cell.glyph = "─"
+			cell.glyphColor = connectorColors[i]
+		}
+
+		if connectorColor, ok := connectorColors[i]; ok {
+			cell.connector = "─"
+			cell.connectorColor = connectorColor
+		}
+
+		cells[i] = cell
+	}
+
+	return cells, trimLanes(lanes)
+}
+
+func renderGraphCells(cells []graphCell) string {
+	var builder strings.Builder
+	write := func(str string, attr color.Attribute) {
+		if str == " " {
+			builder.WriteString(str)
+		} else {
+			builder.WriteString(color.New(attr).Sprint(str))
+		}
+	}
+
+	for _, cell := range cells {
+		write(cell.glyph, cell.glyphColor)
+		write(cell.connector, cell.connectorColor)
+	}
+
+	return builder.String()
+}
+
+func findLane(lanes []*graphLane, sha string) int {
+	for i, lane := range lanes {
+		if lane != nil && lane.sha == sha {
+			return i
+		}
+	}
+
+	return -1
+}
+
+// allocateLane returns the index of the leftmost free lane, skipping over
+// lanes that have only just been freed up so that we don't draw two things in
+// the same cell
+func allocateLane(lanes []*graphLane, exclude map[int]bool) ([]*graphLane, int) {
+	for i, lane := range lanes {
+		if lane == nil && !exclude[i] {
+			return lanes, i
+		}
+	}
+
+	return append(lanes, nil), len(lanes)
+}
+
+func trimLanes(lanes []*graphLane) []*graphLane {
+	for len(lanes) > 0 && lanes[len(lanes)-1] == nil {
+		lanes = lanes[:len(lanes)-1]
+	}
+
+	return lanes
+}
+
+func pickGlyph(isLeft bool, leftGlyph string, rightGlyph string) string {
+	if isLeft {
+		return leftGlyph
+	}
+	return rightGlyph
+}
+
+func min(a, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
+
+func max(a, b int) int {
+	if a > b {
+		return a
+	}
+	return b
+}
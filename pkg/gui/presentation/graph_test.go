@@ -0,0 +1,106 @@
+package presentation
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/fatih/color"
+	"github.com/jesseduffield/lazygit/pkg/commands/models"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestRenderCommitGraph is a function.
+func TestRenderCommitGraph(t *testing.T) {
+	color.NoColor = true
+
+	commit := func(sha string, parents ...string) *models.Commit {
+		return &models.Commit{Sha: sha, Parents: parents}
+	}
+
+	type scenario struct {
+		testName string
+		commits  []*models.Commit
+		expected string
+	}
+
+	scenarios := []scenario{
+		{
+			"linear history",
+			[]*models.Commit{
+				commit("c", "b"),
+				commit("b", "a"),
+				commit("a"),
+			},
+			`
+◯ c
+◯ b
+◯ a`,
+		},
+		{
+			"merged branch",
+			[]*models.Commit{
+				commit("f", "m"),
+				commit("m", "e", "d"),
+				commit("d", "c"),
+				commit("c", "b"),
+				commit("e", "b"),
+				commit("b", "a"),
+				commit("a"),
+			},
+			`
+◯ f
+⏣─╮ m
+│ ◯ d
+│ ◯ c
+◯ │ e
+◯─╯ b
+◯ a`,
+		},
+		{
+			"unmerged branch tip and nested merges",
+			[]*models.Commit{
+				commit("a", "m2"),
+				commit("b", "x"),
+				commit("m2", "m1", "y"),
+				commit("y", "m1"),
+				commit("m1", "x", "z"),
+				commit("z", "x"),
+				commit("x", "r"),
+				commit("r"),
+			},
+			`
+◯ a
+│ ◯ b
+⏣─│─╮ m2
+│ │ ◯ y
+⏣─│─╯─╮ m1
+│ │   ◯ z
+◯─╯───╯ x
+◯ r`,
+		},
+		{
+			"commits yet to be rebased",
+			[]*models.Commit{
+				{Sha: "d", Status: "rebasing"},
+				{Sha: "c", Status: "rebasing"},
+				commit("b", "a"),
+				commit("a"),
+			},
+			`
+◯ d
+◯ c
+◯ b
+◯ a`,
+		},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.testName, func(t *testing.T) {
+			lines := []string{}
+			for i, graphLine := range RenderCommitGraph(s.commits) {
+				lines = append(lines, graphLine+s.commits[i].Sha)
+			}
+			assert.EqualValues(t, strings.TrimPrefix(s.expected, "\n"), strings.Join(lines, "\n"))
+		})
+	}
+}
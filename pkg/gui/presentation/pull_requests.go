@@ -0,0 +1,90 @@
+package presentation
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/fatih/color"
+	"github.com/jesseduffield/lazygit/pkg/commands/models"
+	"github.com/jesseduffield/lazygit/pkg/theme"
+	"github.com/jesseduffield/lazygit/pkg/utils"
+)
+
+func GetPullRequestListDisplayStrings(pullRequests []*models.PullRequest, fullDescription bool) [][]string {
+	lines := make([][]string, len(pullRequests))
+
+	for i := range pullRequests {
+		lines[i] = getPullRequestDisplayStrings(pullRequests[i], fullDescription)
+	}
+
+	return lines
+}
+
+// getPullRequestDisplayStrings returns the display string of pull request
+func getPullRequestDisplayStrings(pr *models.PullRequest, fullDescription bool) []string {
+	result := []string{
+		GetPullRequestBadge(pr),
+		utils.ColoredString(pr.LocalBranchName(), GetBranchColor(pr.HeadBranch)),
+		utils.ColoredString(pr.Title, theme.DefaultTextColor),
+	}
+	if fullDescription {
+		result = append(result, utils.ColoredString(pr.Author, color.FgBlue))
+	}
+
+	return result
+}
+
+// GetPullRequestBadge is the pull request's number, coloured by its state and
+// followed by the outcome of its checks, if there are any
+func GetPullRequestBadge(pr *models.PullRequest) string {
+	numberColor := theme.DefaultTextColor
+	if !pr.Draft {
+		switch pr.State {
+		case "open":
+			numberColor = color.FgGreen
+		case "merged":
+			numberColor = color.FgMagenta
+		case "closed":
+			numberColor = color.FgRed
+		}
+	}
+
+	badge := utils.ColoredString(fmt.Sprintf("#%d", pr.Number), numberColor)
+
+	switch pr.CIStatus {
+	case "success":
+		badge += " " + utils.ColoredString("✓", color.FgGreen)
+	case "failure":
+		badge += " " + utils.ColoredString("✗", color.FgRed)
+	case "pending":
+		badge += " " + utils.ColoredString("●", color.FgYellow)
+	case "unknown":
+		badge += " " + utils.ColoredString("?", color.FgYellow)
+	}
+
+	return badge
+}
+
+// GetPullRequestSummary is shown in the main view for the selected pull request
+func GetPullRequestSummary(pr *models.PullRequest) string {
+	state := pr.State
+	if pr.Draft {
+		state += " (draft)"
+	}
+	if pr.CIStatus != "" {
+		state += ", checks " + pr.CIStatus
+	}
+
+	lines := []string{
+		fmt.Sprintf("%s %s", GetPullRequestBadge(pr), utils.ColoredString(pr.Title, color.Bold)),
+		fmt.Sprintf("%s wants to merge %s into %s", pr.Author, utils.ColoredString(pr.HeadBranch, GetBranchColor(pr.HeadBranch)), utils.ColoredString(pr.BaseBranch, GetBranchColor(pr.BaseBranch))),
+		state,
+		utils.ColoredString(pr.URL, color.FgBlue),
+	}
+
+	if pr.Body != "" {
+		lines = append(lines, "", strings.TrimSpace(pr.Body))
+	}
+
+	return strings.Join(lines, "\n")
+}
@@ -1,6 +1,7 @@
 package presentation
 
 import (
+	"github.com/fatih/color"
 	"github.com/jesseduffield/lazygit/pkg/commands/models"
 	"github.com/jesseduffield/lazygit/pkg/theme"
 	"github.com/jesseduffield/lazygit/pkg/utils"
@@ -23,5 +24,15 @@ func getStashEntryDisplayStrings(s *models.StashEntry, diffed bool) []string {
 	if diffed {
 		attr = theme.DiffTerminalColor
 	}
-	return []string{utils.ColoredString(s.Name, attr)}
+
+	timeAgo := ""
+	if s.UnixTimestamp != 0 {
+		timeAgo = utils.UnixToTimeAgo(s.UnixTimestamp)
+	}
+
+	return []string{
+		utils.ColoredString(timeAgo, color.FgCyan),
+		utils.ColoredString(s.Branch, GetBranchColor(s.Branch)),
+		utils.ColoredString(s.Message(), attr),
+	}
 }
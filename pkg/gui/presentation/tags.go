@@ -1,27 +1,57 @@
 package presentation
 
 import (
+	"github.com/fatih/color"
 	"github.com/jesseduffield/lazygit/pkg/commands/models"
 	"github.com/jesseduffield/lazygit/pkg/theme"
 	"github.com/jesseduffield/lazygit/pkg/utils"
 )
 
-func GetTagListDisplayStrings(tags []*models.Tag, diffName string) [][]string {
+func GetTagListDisplayStrings(tags []*models.Tag, fullDescription bool, diffName string) [][]string {
 	lines := make([][]string, len(tags))
 
 	for i := range tags {
 		diffed := tags[i].Name == diffName
-		lines[i] = getTagDisplayStrings(tags[i], diffed)
+		lines[i] = getTagDisplayStrings(tags[i], fullDescription, diffed)
 	}
 
 	return lines
 }
 
 // getTagDisplayStrings returns the display string of branch
-func getTagDisplayStrings(t *models.Tag, diffed bool) []string {
+func getTagDisplayStrings(t *models.Tag, fullDescription bool, diffed bool) []string {
 	attr := theme.DefaultTextColor
 	if diffed {
 		attr = theme.DiffTerminalColor
 	}
-	return []string{utils.ColoredString(t.Name, attr)}
+
+	if fullDescription {
+		return []string{
+			utils.ColoredString(t.Name, attr),
+			getTagTypeString(t),
+			utils.ColoredString(t.ShortTargetSha(), color.FgYellow),
+			utils.ColoredString(utils.UnixToDate(t.UnixTimestamp), color.FgBlue),
+			utils.ColoredString(t.Tagger, color.FgGreen),
+			utils.ColoredString(t.Message, attr),
+		}
+	}
+
+	return []string{
+		utils.ColoredString(t.Name, attr),
+		getTagTypeString(t),
+		utils.ColoredString(t.ShortTargetSha(), color.FgYellow),
+		utils.ColoredString(utils.UnixToTimeAgo(t.UnixTimestamp), color.FgCyan),
+		utils.ColoredString(utils.TruncateWithEllipsis(t.Tagger, 17), color.FgGreen),
+	}
+}
+
+func getTagTypeString(t *models.Tag) string {
+	switch {
+	case t.Signed:
+		return utils.ColoredString("signed", color.FgGreen)
+	case t.Annotated:
+		return utils.ColoredString("annotated", color.FgCyan)
+	default:
+		return utils.ColoredString("lightweight", color.FgMagenta)
+	}
 }
@@ -0,0 +1,75 @@
+package presentation
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/fatih/color"
+	"github.com/jesseduffield/lazygit/pkg/commands/models"
+	"github.com/jesseduffield/lazygit/pkg/theme"
+	"github.com/jesseduffield/lazygit/pkg/utils"
+)
+
+func GetWorktreeListDisplayStrings(worktrees []*models.Worktree, fullDescription bool) [][]string {
+	lines := make([][]string, len(worktrees))
+
+	for i := range worktrees {
+		lines[i] = getWorktreeDisplayStrings(worktrees[i], fullDescription)
+	}
+
+	return lines
+}
+
+// getWorktreeDisplayStrings returns the display string of worktree
+func getWorktreeDisplayStrings(w *models.Worktree, fullDescription bool) []string {
+	current := ""
+	if w.Current {
+		current = utils.ColoredString("  *", color.FgGreen)
+	}
+
+	nameColorAttr := theme.DefaultTextColor
+	if w.Prunable {
+		nameColorAttr = color.FgRed
+	}
+	name := utils.ColoredString(w.Name(), nameColorAttr)
+
+	flags := []string{}
+	if w.Main {
+		flags = append(flags, "main")
+	}
+	if w.Locked {
+		flags = append(flags, "locked")
+	}
+	if w.Prunable {
+		flags = append(flags, "prunable")
+	}
+	if len(flags) > 0 {
+		name = fmt.Sprintf("%s %s", name, utils.ColoredString("("+strings.Join(flags, ", ")+")", color.FgMagenta))
+	}
+
+	result := []string{current, name, getWorktreeHeadDisplay(w)}
+	if fullDescription {
+		result = append(result, utils.ColoredString(w.Path, color.FgBlue))
+	}
+
+	return result
+}
+
+func getWorktreeHeadDisplay(w *models.Worktree) string {
+	if w.Bare {
+		return utils.ColoredString("(bare)", color.FgYellow)
+	}
+
+	if w.Branch == "" {
+		return utils.ColoredString(fmt.Sprintf("(detached %s)", w.ShortHead()), color.FgYellow)
+	}
+
+	return utils.ColoredString(w.Branch, GetBranchColor(w.Branch))
+}
+
+// GetWorktreeSummary is shown in the main view for worktrees that have no log to show
+func GetWorktreeSummary(w *models.Worktree) string {
+	displayStrings := getWorktreeDisplayStrings(w, true)
+
+	return strings.Join(displayStrings[1:], "\n")
+}
@@ -0,0 +1,162 @@
+package gui
+
+import (
+	"github.com/jesseduffield/lazygit/pkg/commands"
+	"github.com/jesseduffield/lazygit/pkg/commands/hosting"
+	"github.com/jesseduffield/lazygit/pkg/commands/models"
+	"github.com/jesseduffield/lazygit/pkg/gui/presentation"
+)
+
+// list panel functions
+
+func (gui *Gui) getSelectedPullRequest() *models.PullRequest {
+	selectedLine := gui.State.Panels.PullRequests.SelectedLineIdx
+	if selectedLine == -1 || len(gui.State.PullRequests) == 0 {
+		return nil
+	}
+
+	return gui.State.PullRequests[selectedLine]
+}
+
+func (gui *Gui) handlePullRequestSelect() error {
+	state := gui.State.Panels.PullRequests
+
+	var task updateTask
+	pr := gui.getSelectedPullRequest()
+	if !state.Loaded {
+		// we don't hit the hosting service's API until the user shows an interest
+		state.Loaded = true
+		task = NewRenderStringTask(gui.Tr.LoadingPullRequests)
+		if err := gui.refreshSidePanels(refreshOptions{scope: []RefreshableView{PULL_REQUESTS}, mode: ASYNC}); err != nil {
+			return err
+		}
+	} else if state.Err != nil {
+		task = NewRenderStringTask(state.Err.Error())
+	} else if pr == nil {
+		task = NewRenderStringTask(gui.Tr.NoPullRequests)
+	} else {
+		task = NewRenderStringTask(presentation.GetPullRequestSummary(pr))
+	}
+
+	return gui.refreshMainViews(refreshMainOpts{
+		main: &viewUpdateOpts{
+			title: gui.Tr.PullRequestTitle,
+			task:  task,
+		},
+	})
+}
+
+// refreshPullRequests asks the hosting service for the repo's pull requests,
+// along with the CI status of any we have a local branch for. Failures are
+// shown in the main view rather than in a popup, given that most repos won't
+// have a token set up and, once loaded, we refresh on every fetch
+func (gui *Gui) refreshPullRequests() error {
+	state := gui.State.Panels.PullRequests
+	state.Loaded = true
+
+	client, err := commands.NewPullRequest(gui.GitCommand).HostingClient()
+	if err == nil {
+		err = gui.loadPullRequests(client)
+	}
+	state.Err = err
+	if err != nil {
+		gui.State.PullRequests = nil
+		gui.State.BranchPullRequests = nil
+	}
+
+	if err := gui.postRefreshUpdate(gui.State.Contexts.Branches); err != nil {
+		return err
+	}
+
+	return gui.postRefreshUpdate(gui.State.Contexts.PullRequests)
+}
+
+func (gui *Gui) loadPullRequests(client hosting.Client) error {
+	pullRequests, err := client.PullRequests()
+	if err != nil {
+		return err
+	}
+
+	localBranches := map[string]bool{}
+	for _, branch := range gui.State.Branches {
+		localBranches[branch.Name] = true
+	}
+
+	openPullRequests := []*models.PullRequest{}
+	branchPullRequests := map[string]*models.PullRequest{}
+	for _, pr := range pullRequests {
+		if pr.IsOpen() {
+			openPullRequests = append(openPullRequests, pr)
+		}
+
+		// a fork's branch having the same name as one of ours is a coincidence.
+		// Pull requests come most recently updated first, but an open one trumps
+		// any closed ones, given a branch name can be reused once merged
+		if pr.IsFork {
+			continue
+		}
+		existing, ok := branchPullRequests[pr.HeadBranch]
+		if !ok || (!existing.IsOpen() && pr.IsOpen()) {
+			branchPullRequests[pr.HeadBranch] = pr
+		}
+	}
+
+	for branchName, pr := range branchPullRequests {
+		if !pr.IsOpen() || !localBranches[branchName] {
+			continue
+		}
+		// one pull request's checks aren't worth losing the rest over
+		status, err := client.CIStatus(pr.HeadSha)
+		if err != nil {
+			gui.Log.Error(err)
+			status = "unknown"
+		}
+		pr.CIStatus = status
+	}
+
+	gui.State.PullRequests = openPullRequests
+	gui.State.BranchPullRequests = branchPullRequests
+
+	return nil
+}
+
+// specific functions
+
+func (gui *Gui) handleCheckoutPullRequest() error {
+	pr := gui.getSelectedPullRequest()
+	if pr == nil {
+		return nil
+	}
+
+	branchName := pr.LocalBranchName()
+	for _, branch := range gui.State.Branches {
+		if branch.Name == branchName {
+			return gui.handleCheckoutRef(branchName, handleCheckoutRefOptions{})
+		}
+	}
+
+	return gui.WithWaitingStatus(gui.Tr.FetchingPullRequestStatus, func() error {
+		if err := gui.withSpan(gui.Tr.SpanCheckoutPullRequest).FetchPullRequest(pr, gui.GitCommand.TrackedRemoteName(), gui.promptUserForCredential); err != nil {
+			return gui.surfaceError(err)
+		}
+
+		return gui.handleCheckoutRef(branchName, handleCheckoutRefOptions{})
+	})
+}
+
+func (gui *Gui) handleOpenPullRequestInBrowser() error {
+	pr := gui.getSelectedPullRequest()
+	if pr == nil {
+		return nil
+	}
+
+	if err := gui.OSCommand.OpenLink(pr.URL); err != nil {
+		return gui.surfaceError(err)
+	}
+
+	return nil
+}
+
+func (gui *Gui) handleRefreshPullRequests() error {
+	return gui.refreshSidePanels(refreshOptions{scope: []RefreshableView{PULL_REQUESTS}, mode: ASYNC})
+}
@@ -37,6 +37,14 @@ func (gui *Gui) handleQuit() error {
 func (gui *Gui) handleTopLevelReturn() error {
 	currentContext := gui.currentContext()
 
+	if currentContext == gui.State.Searching.filterContext {
+		return gui.clearFilter()
+	}
+
+	if listContext, ok := currentContext.(*ListContext); ok && listContext.GetPanelState().IsRangeSelecting() {
+		return listContext.cancelRangeSelect()
+	}
+
 	parentContext, hasParent := currentContext.GetParentContext()
 	if hasParent && currentContext != nil && parentContext != nil {
 		// TODO: think about whether this should be marked as a return rather than adding to the stack
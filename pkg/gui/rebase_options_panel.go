@@ -5,9 +5,14 @@ import (
 	"strings"
 
 	"github.com/jesseduffield/lazygit/pkg/commands"
+	"github.com/jesseduffield/lazygit/pkg/utils"
 )
 
 func (gui *Gui) handleCreateRebaseOptionsMenu() error {
+	if gui.State.Modes.RebasePlanning.Active() {
+		return gui.createRebasePlanMenu()
+	}
+
 	options := []string{"continue", "abort"}
 
 	if gui.GitCommand.WorkingTreeState() == commands.REBASE_MODE_REBASING {
@@ -23,6 +28,7 @@ func (gui *Gui) handleCreateRebaseOptionsMenu() error {
 			onPress: func() error {
 				return gui.genericMergeCommand(option)
 			},
+			key: rune(option[0]),
 		}
 	}
 
@@ -46,15 +52,23 @@ func (gui *Gui) genericMergeCommand(command string) error {
 	commandType := strings.Replace(status, "ing", "e", 1)
 	// we should end up with a command like 'git merge --continue'
 
+	span := utils.ResolvePlaceholderString(
+		gui.Tr.SpanMergeOrRebase,
+		map[string]string{
+			"commandType": strings.Title(commandType),
+			"command":     command,
+		},
+	)
+
 	// it's impossible for a rebase to require a commit so we'll use a subprocess only if it's a merge
 	if status == commands.REBASE_MODE_MERGING && command != "abort" && gui.Config.GetUserConfig().Git.Merging.ManualCommit {
 		sub := gui.OSCommand.PrepareSubProcess("git", commandType, fmt.Sprintf("--%s", command))
 		if sub != nil {
-			return gui.runSubprocessWithSuspense(sub)
+			return gui.runSubprocessWithSuspense(span, sub)
 		}
 		return nil
 	}
-	result := gui.GitCommand.GenericMergeOrRebaseAction(commandType, command)
+	result := gui.withSpan(span).GenericMergeOrRebaseAction(commandType, command)
 	if err := gui.handleGenericMergeCommandResult(result); err != nil {
 		return err
 	}
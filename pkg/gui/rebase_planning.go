@@ -0,0 +1,240 @@
+package gui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/fatih/color"
+	"github.com/jesseduffield/lazygit/pkg/commands"
+	"github.com/jesseduffield/lazygit/pkg/commands/models"
+	"github.com/jesseduffield/lazygit/pkg/utils"
+)
+
+// Planning a rebase lets the user stage any number of changes to the todo of an
+// interactive rebase (dropping, squashing, rewording, reordering, adding exec
+// and break lines) before running them all in one go. While planning, the
+// commits being rebased are shown as todos in the commits panel, just like
+// they are in the middle of a real rebase, so the same keybindings apply to both
+
+func (gui *Gui) rebasePlanningStatusStr() string {
+	return utils.ResolvePlaceholderString(
+		gui.Tr.LcPlanningRebase,
+		map[string]string{
+			"count": fmt.Sprintf("%d", len(gui.State.Modes.RebasePlanning.Todos)),
+			"key":   gui.getKeyDisplay(gui.Config.GetUserConfig().Keybinding.Universal.CreateRebaseOptionsMenu),
+		},
+	)
+}
+
+// handlePlanInteractiveRebase starts planning a rebase of the commits from HEAD
+// down to the selected one
+func (gui *Gui) handlePlanInteractiveRebase() error {
+	if ok, err := gui.validateNotInFilterMode(); err != nil || !ok {
+		return err
+	}
+
+	if gui.State.Modes.RebasePlanning.Active() {
+		return gui.createErrorPanel(gui.Tr.AlreadyPlanningRebase)
+	}
+
+	if gui.GitCommand.WorkingTreeState() != commands.REBASE_MODE_NORMAL {
+		return gui.createErrorPanel(gui.Tr.CantPlanRebaseMidRebase)
+	}
+
+	index := gui.State.Panels.Commits.SelectedLineIdx
+	if index < 0 || index >= len(gui.State.Commits) {
+		return nil
+	}
+
+	baseSha := "--root"
+	if index+1 < len(gui.State.Commits) {
+		baseSha = gui.State.Commits[index+1].Sha
+	}
+
+	todos := make([]*models.Commit, index+1)
+	for i, commit := range gui.State.Commits[:index+1] {
+		todo := *commit
+		todo.Status = "rebasing"
+		todo.Action = "pick"
+		if commit.IsMerge {
+			// like git, we flatten out merges unless told otherwise
+			todo.Action = "drop"
+		}
+		todos[i] = &todo
+	}
+
+	gui.State.Panels.Commits.CancelRangeSelect()
+	gui.State.Modes.RebasePlanning = RebasePlanning{
+		Todos:   todos,
+		BaseSha: baseSha,
+		HeadSha: gui.State.Commits[0].Sha,
+	}
+
+	return gui.renderRebasePlan()
+}
+
+// withRebasePlan swaps the commits we're planning to rebase for the todos of the
+// plan. If the base commit is nowhere to be found, the plan no longer applies and
+// we drop it
+func (gui *Gui) withRebasePlan(commits []*models.Commit) []*models.Commit {
+	plan := &gui.State.Modes.RebasePlanning
+	if !plan.Active() {
+		return commits
+	}
+
+	rest := []*models.Commit{}
+	if plan.BaseSha != "--root" {
+		baseIdx := -1
+		for i, commit := range commits {
+			if commit.Sha == plan.BaseSha && commit.Status != "rebasing" {
+				baseIdx = i
+				break
+			}
+		}
+		if baseIdx == -1 {
+			plan.Reset()
+			return commits
+		}
+		rest = commits[baseIdx:]
+	}
+
+	result := make([]*models.Commit, 0, len(plan.Todos)+len(rest))
+	result = append(result, plan.Todos...)
+	return append(result, rest...)
+}
+
+func (gui *Gui) renderRebasePlan() error {
+	gui.Mutexes.BranchCommitsMutex.Lock()
+	defer gui.Mutexes.BranchCommitsMutex.Unlock()
+
+	gui.State.Commits = gui.withRebasePlan(gui.State.Commits)
+
+	return gui.postRefreshUpdate(gui.State.Contexts.BranchCommits)
+}
+
+func (gui *Gui) exitRebasePlanning() error {
+	gui.State.Modes.RebasePlanning.Reset()
+	gui.State.Panels.Commits.CancelRangeSelect()
+
+	return gui.refreshSidePanels(refreshOptions{scope: []RefreshableView{COMMITS}})
+}
+
+// editRebaseTodos applies the edit to the rebase we're planning or, failing that,
+// to the todo file of the rebase in progress, logging it under the given span
+func (gui *Gui) editRebaseTodos(span string, edit func(todos []*models.Commit) ([]*models.Commit, error)) error {
+	if gui.State.Modes.RebasePlanning.Active() {
+		todos, err := edit(gui.State.Modes.RebasePlanning.Todos)
+		if err != nil {
+			return gui.surfaceError(err)
+		}
+		gui.State.Modes.RebasePlanning.Todos = todos
+
+		return gui.renderRebasePlan()
+	}
+
+	if err := gui.withSpan(span).EditRebaseTodos(edit); err != nil {
+		return gui.surfaceError(err)
+	}
+
+	return gui.refreshRebaseCommits()
+}
+
+// todoCount returns how many of the commits at the top of the panel are todos,
+// whether planned or part of the rebase in progress
+func (gui *Gui) todoCount() int {
+	count := 0
+	for _, commit := range gui.State.Commits {
+		if commit.Status != "rebasing" {
+			break
+		}
+		count++
+	}
+
+	return count
+}
+
+// insertTodoAtSelection adds the todo so that it runs right after the selected
+// commit. That means the selected commit may be the one just below the todos,
+// in which case the new line runs first
+func (gui *Gui) insertTodoAtSelection(span string, todo *models.Commit) error {
+	index := gui.State.Panels.Commits.SelectedLineIdx
+	planning := gui.State.Modes.RebasePlanning.Active()
+	if !planning && gui.GitCommand.WorkingTreeState() != commands.REBASE_MODE_REBASING {
+		return gui.createErrorPanel(gui.Tr.CantInsertTodoHere)
+	}
+
+	if index < 0 || index > gui.todoCount() {
+		return gui.createErrorPanel(gui.Tr.CantInsertTodoHere)
+	}
+
+	return gui.editRebaseTodos(span, func(todos []*models.Commit) ([]*models.Commit, error) {
+		return commands.InsertTodo(todos, index, todo)
+	})
+}
+
+func (gui *Gui) handleInsertExecTodo() error {
+	if ok, err := gui.validateNotInFilterMode(); err != nil || !ok {
+		return err
+	}
+
+	return gui.prompt(promptOpts{
+		title: gui.Tr.ExecTodoPrompt,
+		handleConfirm: func(command string) error {
+			command = strings.TrimSpace(command)
+			if command == "" {
+				return nil
+			}
+
+			return gui.insertTodoAtSelection(gui.Tr.SpanInsertExecTodo, commands.NewExecTodo(command))
+		},
+	})
+}
+
+func (gui *Gui) handleInsertBreakTodo() error {
+	if ok, err := gui.validateNotInFilterMode(); err != nil || !ok {
+		return err
+	}
+
+	return gui.insertTodoAtSelection(gui.Tr.SpanInsertBreakTodo, commands.NewBreakTodo())
+}
+
+func (gui *Gui) createRebasePlanMenu() error {
+	menuItems := []*menuItem{
+		{
+			displayStrings: []string{
+				gui.Tr.LcStartRebasePlan,
+				color.New(color.FgYellow).Sprint("git rebase --interactive " + gui.State.Modes.RebasePlanning.BaseSha),
+			},
+			onPress: gui.handleStartRebasePlan,
+		},
+		{
+			displayStrings: []string{gui.Tr.LcDiscardRebasePlan, ""},
+			onPress:        gui.exitRebasePlanning,
+		},
+	}
+
+	return gui.createMenu(gui.Tr.RebaseOptionsTitle, menuItems, createMenuOptions{showCancel: true})
+}
+
+func (gui *Gui) handleStartRebasePlan() error {
+	plan := gui.State.Modes.RebasePlanning
+	gui.State.Modes.RebasePlanning.Reset()
+	gui.State.Panels.Commits.CancelRangeSelect()
+
+	for _, todo := range plan.Todos {
+		if todo.Action == "reword" {
+			// rewording needs the user's editor, so we hand over the terminal
+			cmd, err := gui.withSpan(gui.Tr.SpanStartRebasePlan).PrepareInteractiveRebaseCommand(plan.BaseSha, commands.FormatRebaseTodo(plan.Todos), false)
+			if err != nil {
+				return gui.surfaceError(err)
+			}
+
+			return gui.runSubprocessWithSuspense(gui.Tr.SpanStartRebasePlan, cmd)
+		}
+	}
+
+	return gui.WithWaitingStatus(gui.Tr.RebasingStatus, func() error {
+		err := gui.withSpan(gui.Tr.SpanStartRebasePlan).RebaseWithTodo(plan.BaseSha, plan.Todos)
+		return gui.handleGenericMergeCommandResult(err)
+	})
+}
@@ -89,6 +89,12 @@ func (gui *Gui) dispatchSwitchToRepo(path string, reuse bool) error {
 
 		gui.resetState("", reuse)
 
+		// the repo we're switching to (or a directory above it) may have its own
+		// config. If that's broken we carry on with the config we have
+		if err := gui.reloadUserConfig(); err != nil {
+			return gui.createErrorPanel(utils.ResolvePlaceholderString(gui.Tr.ConfigReloadError, map[string]string{"error": err.Error()}))
+		}
+
 		return nil
 	})
 
@@ -8,7 +8,7 @@ import (
 )
 
 func (gui *Gui) resetToRef(ref string, strength string, options oscommands.RunCommandOptions) error {
-	if err := gui.GitCommand.ResetToCommit(ref, strength, options); err != nil {
+	if err := gui.withSpan(gui.Tr.SpanResetToCommit).ResetToCommit(ref, strength, options); err != nil {
 		return gui.surfaceError(err)
 	}
 
@@ -43,6 +43,7 @@ func (gui *Gui) createResetMenu(ref string) error {
 			onPress: func() error {
 				return gui.resetToRef(ref, strength, oscommands.RunCommandOptions{})
 			},
+			key: rune(strength[0]),
 		}
 	}
 
@@ -3,6 +3,7 @@ package gui
 import (
 	"fmt"
 
+	"github.com/jesseduffield/gocui"
 	"github.com/jesseduffield/lazygit/pkg/theme"
 	"github.com/jesseduffield/lazygit/pkg/utils"
 )
@@ -16,6 +17,7 @@ func (gui *Gui) handleOpenSearch(viewName string) error {
 	gui.State.Searching.isSearching = true
 	gui.State.Searching.view = view
 
+	gui.setViewContent(gui.Views.SearchPrefix, SEARCH_PREFIX)
 	gui.renderString(gui.Views.Search, "")
 
 	if err := gui.pushContext(gui.State.Contexts.Search); err != nil {
@@ -26,6 +28,10 @@ func (gui *Gui) handleOpenSearch(viewName string) error {
 }
 
 func (gui *Gui) handleSearch() error {
+	if !gui.State.Searching.isSearching {
+		return gui.handleConfirmFilter()
+	}
+
 	gui.State.Searching.searchString = gui.Views.Search.Buffer()
 	gui.Log.Warn(gui.State.Searching.searchString)
 	if err := gui.returnFromContext(); err != nil {
@@ -94,11 +100,21 @@ func (gui *Gui) onSearchEscape() error {
 		gui.State.Searching.view = nil
 	}
 
+	// we may have been searching through a filtered list
+	if gui.State.Searching.filterContext != nil {
+		gui.setViewContent(gui.Views.SearchPrefix, FILTER_PREFIX)
+		gui.renderFilterStatus()
+	}
+
 	return nil
 }
 
 func (gui *Gui) handleSearchEscape() error {
-	if err := gui.onSearchEscape(); err != nil {
+	if gui.State.Searching.isSearching {
+		if err := gui.onSearchEscape(); err != nil {
+			return err
+		}
+	} else if err := gui.clearFilter(); err != nil {
 		return err
 	}
 
@@ -108,3 +124,100 @@ func (gui *Gui) handleSearchEscape() error {
 
 	return nil
 }
+
+func (gui *Gui) searchPrefix() string {
+	if gui.State.Searching.isSearching {
+		return SEARCH_PREFIX
+	}
+
+	return FILTER_PREFIX
+}
+
+// handleOpenFilter lets the user narrow down the items of a list by typing,
+// using the same bar at the bottom of the screen as searching does
+func (gui *Gui) handleOpenFilter(listContext *ListContext) error {
+	if !gui.isPopupPanel(listContext.ViewName) && gui.popupPanelFocused() {
+		return nil
+	}
+
+	if err := gui.onSearchEscape(); err != nil {
+		return err
+	}
+	if err := listContext.cancelRangeSelect(); err != nil {
+		return err
+	}
+
+	gui.State.Searching.filterContext = listContext
+
+	// you can carry on from where you left off
+	gui.setViewContent(gui.Views.SearchPrefix, FILTER_PREFIX)
+	gui.renderString(gui.Views.Search, listContext.filter)
+	gui.g.Update(func(*gocui.Gui) error {
+		gui.Views.Search.EditGotoToEndOfLine()
+		return nil
+	})
+
+	return gui.pushContext(gui.State.Contexts.Search)
+}
+
+// onFilterChange is called as the user types the filter
+func (gui *Gui) onFilterChange(filter string) error {
+	listContext := gui.State.Searching.filterContext
+	if listContext == nil {
+		return nil
+	}
+
+	listContext.filter = filter
+	if err := listContext.HandleRender(); err != nil {
+		return err
+	}
+
+	return listContext.HandleFocus()
+}
+
+func (gui *Gui) handleConfirmFilter() error {
+	listContext := gui.State.Searching.filterContext
+	if listContext == nil || !listContext.isFiltering() {
+		if err := gui.clearFilter(); err != nil {
+			return err
+		}
+	} else {
+		gui.renderFilterStatus()
+	}
+
+	return gui.returnFromContext()
+}
+
+func (gui *Gui) renderFilterStatus() {
+	listContext := gui.State.Searching.filterContext
+	if listContext == nil {
+		return
+	}
+
+	gui.renderString(
+		gui.Views.Search,
+		fmt.Sprintf(
+			"%s %s",
+			utils.ResolvePlaceholderString(gui.Tr.FilterStatus, map[string]string{"filter": listContext.filter}),
+			utils.ColoredString(
+				fmt.Sprintf("%s: %s", gui.getKeyDisplay(gui.Config.GetUserConfig().Keybinding.Universal.Return), gui.Tr.LcClearFilter),
+				theme.OptionsFgColor,
+			),
+		),
+	)
+}
+
+// clearFilter shows all the items of the list we've been filtering again,
+// keeping the selected item selected
+func (gui *Gui) clearFilter() error {
+	listContext := gui.State.Searching.filterContext
+	if listContext == nil {
+		return nil
+	}
+
+	gui.State.Searching.filterContext = nil
+	listContext.filter = ""
+	listContext.filteredIndices = nil
+
+	return listContext.HandleRender()
+}
@@ -124,6 +124,41 @@ func (gui *Gui) handleResetSelection() error {
 	})
 }
 
+// handleStashSelection stashes the selected lines, taking them out of the
+// working tree, and out of the index too if we're looking at staged changes
+func (gui *Gui) handleStashSelection() error {
+	return gui.withLBLActiveCheck(func(state *lBlPanelState) error {
+		file := gui.getSelectedFile()
+		if file == nil {
+			return nil
+		}
+
+		stashPatch := patch.ModifiedPatchForRange(gui.Log, file.Name, state.Diff, state.FirstLineIdx, state.LastLineIdx, false, false)
+		if stashPatch == "" {
+			return nil
+		}
+		reversePatch := patch.ModifiedPatchForRange(gui.Log, file.Name, state.Diff, state.FirstLineIdx, state.LastLineIdx, true, false)
+
+		return gui.prompt(promptOpts{
+			title: gui.Tr.StashChanges,
+			handleConfirm: func(message string) error {
+				if err := gui.withSpan(gui.Tr.SpanStashSelection).StashPatch(stashPatch, reversePatch, message, state.SecondaryFocused); err != nil {
+					return err
+				}
+
+				if state.SelectMode == RANGE {
+					state.SelectMode = LINE
+				}
+
+				if err := gui.refreshSidePanels(refreshOptions{scope: []RefreshableView{STASH, FILES}}); err != nil {
+					return err
+				}
+				return gui.refreshStagingPanel(false, -1, state)
+			},
+		})
+	})
+}
+
 func (gui *Gui) applySelection(reverse bool, state *lBlPanelState) error {
 	file := gui.getSelectedFile()
 	if file == nil {
@@ -142,7 +177,7 @@ func (gui *Gui) applySelection(reverse bool, state *lBlPanelState) error {
 	if !reverse || state.SecondaryFocused {
 		applyFlags = append(applyFlags, "cached")
 	}
-	err := gui.GitCommand.ApplyPatch(patch, applyFlags...)
+	err := gui.withSpan(gui.Tr.SpanApplyPatch).ApplyPatch(patch, applyFlags...)
 	if err != nil {
 		return gui.surfaceError(err)
 	}
@@ -1,6 +1,8 @@
 package gui
 
 import (
+	"fmt"
+
 	"github.com/jesseduffield/lazygit/pkg/commands/models"
 	"github.com/jesseduffield/lazygit/pkg/utils"
 )
@@ -85,6 +87,10 @@ func (gui *Gui) handleStashPop() error {
 }
 
 func (gui *Gui) handleStashDrop() error {
+	if gui.State.Panels.Stash.IsRangeSelecting() {
+		return gui.handleDropSelectedStashEntries()
+	}
+
 	return gui.ask(askOpts{
 		title:  gui.Tr.StashDrop,
 		prompt: gui.Tr.SureDropStashEntry,
@@ -94,6 +100,59 @@ func (gui *Gui) handleStashDrop() error {
 	})
 }
 
+func (gui *Gui) handleDropSelectedStashEntries() error {
+	startIdx, endIdx := gui.State.Contexts.Stash.getSelectedRange()
+	stashEntries := gui.State.StashEntries[startIdx : endIdx+1]
+	if len(stashEntries) == 0 {
+		return nil
+	}
+
+	return gui.ask(askOpts{
+		title: gui.Tr.StashDrop,
+		prompt: utils.ResolvePlaceholderString(
+			gui.Tr.SureDropStashEntries,
+			map[string]string{"count": fmt.Sprintf("%d", len(stashEntries))},
+		),
+		handleConfirm: func() error {
+			gui.State.Panels.Stash.CancelRangeSelect()
+
+			span := utils.ResolvePlaceholderString(gui.Tr.SpanStashDo, map[string]string{"method": "drop"})
+			// dropping an entry shifts the indices of those after it, so we go from
+			// the oldest entry to the newest
+			for i := len(stashEntries) - 1; i >= 0; i-- {
+				if err := gui.withSpan(span).StashDo(stashEntries[i].Index, "drop"); err != nil {
+					return gui.surfaceError(err)
+				}
+			}
+			return gui.refreshSidePanels(refreshOptions{scope: []RefreshableView{STASH, FILES}})
+		},
+	})
+}
+
+func (gui *Gui) handleRenameStash() error {
+	stashEntry := gui.getSelectedStashEntry()
+	if stashEntry == nil {
+		return nil
+	}
+
+	return gui.prompt(promptOpts{
+		title:          utils.ResolvePlaceholderString(gui.Tr.RenameStashPrompt, map[string]string{"stashName": stashEntry.RefName()}),
+		initialContent: stashEntry.Message(),
+		handleConfirm: func(response string) error {
+			// we keep the part git adds about where the entry was stashed from
+			message := response
+			if stashEntry.Branch != "" {
+				message = fmt.Sprintf("On %s: %s", stashEntry.Branch, response)
+			}
+
+			if err := gui.withSpan(gui.Tr.SpanRenameStash).StashRename(stashEntry.Index, message); err != nil {
+				return err
+			}
+			return gui.refreshSidePanels(refreshOptions{scope: []RefreshableView{STASH}})
+		},
+	})
+}
+
 func (gui *Gui) stashDo(method string) error {
 	stashEntry := gui.getSelectedStashEntry()
 	if stashEntry == nil {
@@ -106,7 +165,8 @@ func (gui *Gui) stashDo(method string) error {
 
 		return gui.createErrorPanel(errorMessage)
 	}
-	if err := gui.GitCommand.StashDo(stashEntry.Index, method); err != nil {
+	span := utils.ResolvePlaceholderString(gui.Tr.SpanStashDo, map[string]string{"method": method})
+	if err := gui.withSpan(span).StashDo(stashEntry.Index, method); err != nil {
 		return gui.surfaceError(err)
 	}
 	return gui.refreshSidePanels(refreshOptions{scope: []RefreshableView{STASH, FILES}})
@@ -117,11 +177,15 @@ func (gui *Gui) handleStashSave(stashFunc func(message string) error) error {
 		return gui.createErrorPanel(gui.Tr.NoTrackedStagedFilesStash)
 	}
 
+	return gui.promptForStashMessage(stashFunc)
+}
+
+func (gui *Gui) promptForStashMessage(stashFunc func(message string) error) error {
 	return gui.prompt(promptOpts{
 		title: gui.Tr.StashChanges,
 		handleConfirm: func(stashComment string) error {
 			if err := stashFunc(stashComment); err != nil {
-				return gui.surfaceError(err)
+				return err
 			}
 			return gui.refreshSidePanels(refreshOptions{scope: []RefreshableView{STASH, FILES}})
 		},
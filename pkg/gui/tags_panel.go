@@ -1,6 +1,9 @@
 package gui
 
 import (
+	"strings"
+
+	"github.com/fatih/color"
 	"github.com/jesseduffield/lazygit/pkg/commands/models"
 	"github.com/jesseduffield/lazygit/pkg/utils"
 )
@@ -15,27 +18,72 @@ func (gui *Gui) getSelectedTag() *models.Tag {
 }
 
 func (gui *Gui) handleCreateTag() error {
-	return gui.prompt(promptOpts{
-		title: gui.Tr.CreateTagTitle,
-		handleConfirm: func(tagName string) error {
-			// leaving commit SHA blank so that we're just creating the tag for the current commit
-			if err := gui.GitCommand.CreateLightweightTag(tagName, ""); err != nil {
-				return gui.surfaceError(err)
+	// leaving commit SHA blank so that we're just creating the tag for the current commit
+	return gui.createTagMenu("")
+}
+
+func (gui *Gui) selectTagByName(tagName string) {
+	// find the index of the tag and set that as the currently selected line
+	for i, tag := range gui.State.Tags {
+		if tag.Name == tagName {
+			gui.State.Panels.Tags.SelectedLineIdx = i
+			if err := gui.State.Contexts.Tags.HandleRender(); err != nil {
+				gui.Log.Error(err)
 			}
-			return gui.refreshSidePanels(refreshOptions{scope: []RefreshableView{COMMITS, TAGS}, then: func() {
-				// find the index of the tag and set that as the currently selected line
-				for i, tag := range gui.State.Tags {
-					if tag.Name == tagName {
-						gui.State.Panels.Tags.SelectedLineIdx = i
-						if err := gui.State.Contexts.Tags.HandleRender(); err != nil {
-							gui.Log.Error(err)
-						}
-
-						return
+
+			return
+		}
+	}
+}
+
+// createTagMenu lets the user pick between a lightweight, annotated, or signed
+// tag. Annotated and signed tags have their message written in the user's editor
+func (gui *Gui) createTagMenu(commitSha string) error {
+	menuItems := []*menuItem{
+		{
+			displayStrings: []string{gui.Tr.LcLightweightTag, color.New(color.FgYellow).Sprint("git tag")},
+			onPress: func() error {
+				return gui.promptForTagName(func(tagName string) error {
+					if err := gui.withSpan(gui.Tr.SpanCreateLightweightTag).CreateLightweightTag(tagName, commitSha); err != nil {
+						return gui.surfaceError(err)
 					}
-				}
+					return gui.refreshSidePanels(refreshOptions{scope: []RefreshableView{COMMITS, TAGS}, then: func() {
+						gui.selectTagByName(tagName)
+					}})
+				})
 			},
-			})
+		},
+		{
+			displayStrings: []string{gui.Tr.LcAnnotatedTag, color.New(color.FgYellow).Sprint("git tag --annotate")},
+			onPress: func() error {
+				return gui.promptForTagName(func(tagName string) error {
+					return gui.runSubprocessWithSuspense(gui.Tr.SpanCreateAnnotatedTag, gui.GitCommand.CreateAnnotatedTagCmd(tagName, commitSha, false))
+				})
+			},
+		},
+		{
+			displayStrings: []string{gui.Tr.LcSignedTag, color.New(color.FgYellow).Sprint("git tag --sign")},
+			onPress: func() error {
+				return gui.promptForTagName(func(tagName string) error {
+					return gui.runSubprocessWithSuspense(gui.Tr.SpanCreateSignedTag, gui.GitCommand.CreateAnnotatedTagCmd(tagName, commitSha, true))
+				})
+			},
+		},
+	}
+
+	return gui.createMenu(gui.Tr.CreateTagTitle, menuItems, createMenuOptions{showCancel: true})
+}
+
+func (gui *Gui) promptForTagName(handleConfirm func(tagName string) error) error {
+	return gui.prompt(promptOpts{
+		title: gui.Tr.TagNameTitle,
+		handleConfirm: func(tagName string) error {
+			tagName = strings.TrimSpace(tagName)
+			if tagName == "" {
+				return nil
+			}
+
+			return handleConfirm(tagName)
 		},
 	})
 }
@@ -47,6 +95,11 @@ func (gui *Gui) handleTagSelect() error {
 	tag := gui.getSelectedTag()
 	if tag == nil {
 		task = NewRenderStringTask("No tags")
+	} else if tag.Annotated {
+		cmd := gui.OSCommand.ExecutableFromString(
+			gui.GitCommand.ShowTagCmdStr(tag.Name),
+		)
+		task = NewRunPtyTask(cmd)
 	} else {
 		cmd := gui.OSCommand.ExecutableFromString(
 			gui.GitCommand.GetBranchGraphCmdStr(tag.Name),
@@ -93,6 +146,10 @@ func (gui *Gui) handleCheckoutTag(tag *models.Tag) error {
 }
 
 func (gui *Gui) handleDeleteTag(tag *models.Tag) error {
+	if gui.State.Panels.Tags.IsRangeSelecting() {
+		return gui.handleDeleteSelectedTags()
+	}
+
 	prompt := utils.ResolvePlaceholderString(
 		gui.Tr.DeleteTagPrompt,
 		map[string]string{
@@ -104,7 +161,7 @@ func (gui *Gui) handleDeleteTag(tag *models.Tag) error {
 		title:  gui.Tr.DeleteTagTitle,
 		prompt: prompt,
 		handleConfirm: func() error {
-			if err := gui.GitCommand.DeleteTag(tag.Name); err != nil {
+			if err := gui.withSpan(gui.Tr.SpanDeleteTag).DeleteTag(tag.Name); err != nil {
 				return gui.surfaceError(err)
 			}
 			return gui.refreshSidePanels(refreshOptions{mode: ASYNC, scope: []RefreshableView{COMMITS, TAGS}})
@@ -112,6 +169,41 @@ func (gui *Gui) handleDeleteTag(tag *models.Tag) error {
 	})
 }
 
+func (gui *Gui) handleDeleteSelectedTags() error {
+	startIdx, endIdx := gui.State.Contexts.Tags.getSelectedRange()
+	tags := gui.State.Tags[startIdx : endIdx+1]
+	if len(tags) == 0 {
+		return nil
+	}
+
+	tagNames := make([]string, len(tags))
+	for i, tag := range tags {
+		tagNames[i] = tag.Name
+	}
+
+	prompt := utils.ResolvePlaceholderString(
+		gui.Tr.DeleteTagsPrompt,
+		map[string]string{
+			"tagNames": strings.Join(tagNames, ", "),
+		},
+	)
+
+	return gui.ask(askOpts{
+		title:  gui.Tr.DeleteTagTitle,
+		prompt: prompt,
+		handleConfirm: func() error {
+			gui.State.Panels.Tags.CancelRangeSelect()
+
+			for _, tagName := range tagNames {
+				if err := gui.withSpan(gui.Tr.SpanDeleteTag).DeleteTag(tagName); err != nil {
+					return gui.surfaceError(err)
+				}
+			}
+			return gui.refreshSidePanels(refreshOptions{mode: ASYNC, scope: []RefreshableView{COMMITS, TAGS}})
+		},
+	})
+}
+
 func (gui *Gui) handlePushTag(tag *models.Tag) error {
 	title := utils.ResolvePlaceholderString(
 		gui.Tr.PushTagTitle,
@@ -125,7 +217,7 @@ func (gui *Gui) handlePushTag(tag *models.Tag) error {
 		initialContent: "origin",
 		handleConfirm: func(response string) error {
 			return gui.WithWaitingStatus(gui.Tr.PushingTagStatus, func() error {
-				err := gui.GitCommand.PushTag(response, tag.Name, gui.promptUserForCredential)
+				err := gui.withSpan(gui.Tr.SpanPushTag).PushTag(response, tag.Name, gui.promptUserForCredential)
 				gui.handleCredentialsPopup(err)
 
 				return nil
@@ -1,6 +1,7 @@
 package gui
 
 import (
+	"math"
 	"os/exec"
 	"strings"
 
@@ -9,14 +10,25 @@ import (
 )
 
 func (gui *Gui) newCmdTask(view *gocui.View, cmd *exec.Cmd, prefix string) error {
+	_, height := view.Size()
+	_, oy := view.Origin()
+
+	return gui.startCmdTask(view, cmd, prefix, height+oy+10, nil)
+}
+
+// newStreamCmdTask reads all of the command's output as it arrives rather than
+// waiting for the user to scroll, so that long-running commands don't block on
+// a full pipe
+func (gui *Gui) newStreamCmdTask(view *gocui.View, cmd *exec.Cmd, onDone func()) error {
+	return gui.startCmdTask(view, cmd, "", math.MaxInt32, onDone)
+}
+
+func (gui *Gui) startCmdTask(view *gocui.View, cmd *exec.Cmd, prefix string, linesToRead int, onDone func()) error {
 	gui.Log.WithField(
 		"command",
 		strings.Join(cmd.Args, " "),
 	).Debug("RunCommand")
 
-	_, height := view.Size()
-	_, oy := view.Origin()
-
 	manager := gui.getManager(view)
 
 	r, err := cmd.StdoutPipe()
@@ -29,7 +41,7 @@ func (gui *Gui) newCmdTask(view *gocui.View, cmd *exec.Cmd, prefix string) error
 		return err
 	}
 
-	if err := manager.NewTask(manager.NewCmdTask(r, cmd, prefix, height+oy+10, nil)); err != nil {
+	if err := manager.NewTask(manager.NewCmdTask(r, cmd, prefix, linesToRead, onDone)); err != nil {
 		return err
 	}
 
@@ -173,14 +173,14 @@ func (gui *Gui) handleHardResetWithAutoStash(commitSha string, options handleHar
 			prompt: gui.Tr.AutoStashPrompt,
 			handleConfirm: func() error {
 				return gui.WithWaitingStatus(options.WaitingStatus, func() error {
-					if err := gui.GitCommand.StashSave(gui.Tr.StashPrefix + commitSha); err != nil {
+					if err := gui.withSpan(gui.Tr.SpanUndo).StashSave(gui.Tr.StashPrefix + commitSha); err != nil {
 						return gui.surfaceError(err)
 					}
 					if err := reset(); err != nil {
 						return err
 					}
 
-					if err := gui.GitCommand.StashDo(0, "pop"); err != nil {
+					if err := gui.withSpan(gui.Tr.SpanUndo).StashDo(0, "pop"); err != nil {
 						if err := gui.refreshSidePanels(refreshOptions{}); err != nil {
 							return err
 						}
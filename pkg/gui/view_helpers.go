@@ -28,19 +28,25 @@ const (
 	REMOTES
 	STATUS
 	SUBMODULES
+	WORKTREES
+	// pull requests come from the hosting service's API rather than git, so we
+	// only refresh them when asked to explicitly
+	PULL_REQUESTS
 )
 
 func getScopeNames(scopes []RefreshableView) []string {
 	scopeNameMap := map[RefreshableView]string{
-		COMMITS:    "commits",
-		BRANCHES:   "branches",
-		FILES:      "files",
-		SUBMODULES: "submodules",
-		STASH:      "stash",
-		REFLOG:     "reflog",
-		TAGS:       "tags",
-		REMOTES:    "remotes",
-		STATUS:     "status",
+		COMMITS:       "commits",
+		BRANCHES:      "branches",
+		FILES:         "files",
+		SUBMODULES:    "submodules",
+		STASH:         "stash",
+		REFLOG:        "reflog",
+		TAGS:          "tags",
+		REMOTES:       "remotes",
+		STATUS:        "status",
+		WORKTREES:     "worktrees",
+		PULL_REQUESTS: "pullRequests",
 	}
 
 	scopeNames := make([]string, len(scopes))
@@ -105,7 +111,7 @@ func (gui *Gui) refreshSidePanels(options refreshOptions) error {
 	f := func() {
 		var scopeMap map[RefreshableView]bool
 		if len(options.scope) == 0 {
-			scopeMap = arrToMap([]RefreshableView{COMMITS, BRANCHES, FILES, STASH, REFLOG, TAGS, REMOTES, STATUS})
+			scopeMap = arrToMap([]RefreshableView{COMMITS, BRANCHES, FILES, STASH, REFLOG, TAGS, REMOTES, WORKTREES, STATUS})
 		} else {
 			scopeMap = arrToMap(options.scope)
 		}
@@ -170,6 +176,30 @@ func (gui *Gui) refreshSidePanels(options refreshOptions) error {
 			}()
 		}
 
+		if scopeMap[WORKTREES] {
+			wg.Add(1)
+			func() {
+				if options.mode == ASYNC {
+					go utils.Safe(func() { _ = gui.refreshWorktrees() })
+				} else {
+					_ = gui.refreshWorktrees()
+				}
+				wg.Done()
+			}()
+		}
+
+		if scopeMap[PULL_REQUESTS] {
+			wg.Add(1)
+			func() {
+				if options.mode == ASYNC {
+					go utils.Safe(func() { _ = gui.refreshPullRequests() })
+				} else {
+					_ = gui.refreshPullRequests()
+				}
+				wg.Done()
+			}()
+		}
+
 		wg.Wait()
 
 		gui.refreshStatus()
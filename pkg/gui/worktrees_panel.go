@@ -0,0 +1,244 @@
+package gui
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/jesseduffield/lazygit/pkg/commands"
+	"github.com/jesseduffield/lazygit/pkg/commands/models"
+	"github.com/jesseduffield/lazygit/pkg/gui/presentation"
+	"github.com/jesseduffield/lazygit/pkg/utils"
+)
+
+// list panel functions
+
+func (gui *Gui) getSelectedWorktree() *models.Worktree {
+	selectedLine := gui.State.Panels.Worktrees.SelectedLineIdx
+	if selectedLine == -1 || len(gui.State.Worktrees) == 0 {
+		return nil
+	}
+
+	return gui.State.Worktrees[selectedLine]
+}
+
+func (gui *Gui) handleWorktreeSelect() error {
+	var task updateTask
+	worktree := gui.getSelectedWorktree()
+	if worktree == nil {
+		task = NewRenderStringTask(gui.Tr.NoWorktrees)
+	} else if worktree.Bare || worktree.Prunable {
+		task = NewRenderStringTask(presentation.GetWorktreeSummary(worktree))
+	} else {
+		cmd := gui.OSCommand.ExecutableFromString(
+			gui.GitCommand.GetBranchGraphCmdStr(worktree.RefName()),
+		)
+		task = NewRunPtyTask(cmd)
+	}
+
+	return gui.refreshMainViews(refreshMainOpts{
+		main: &viewUpdateOpts{
+			title: "Worktree",
+			task:  task,
+		},
+	})
+}
+
+func (gui *Gui) refreshWorktrees() error {
+	worktrees, err := gui.GitCommand.GetWorktrees()
+	if err != nil {
+		return gui.surfaceError(err)
+	}
+
+	gui.State.Worktrees = worktrees
+
+	return gui.postRefreshUpdate(gui.State.Contexts.Worktrees)
+}
+
+// specific functions
+
+func (gui *Gui) handleSwitchToWorktree() error {
+	worktree := gui.getSelectedWorktree()
+	if worktree == nil {
+		return nil
+	}
+
+	if worktree.Current {
+		return gui.createErrorPanel(gui.Tr.AlreadyInWorktree)
+	}
+
+	if worktree.Bare {
+		return gui.createErrorPanel(gui.Tr.CantSwitchToBareWorktree)
+	}
+
+	// if we were in a submodule, we want to forget about that stack of repos
+	// so that hitting escape in the worktree does nothing
+	gui.RepoPathStack = []string{}
+
+	return gui.dispatchSwitchToRepo(worktree.Path, true)
+}
+
+func (gui *Gui) handleCreateWorktree() error {
+	return gui.prompt(promptOpts{
+		title:               gui.Tr.NewWorktreeBranchName,
+		findSuggestionsFunc: gui.findBranchNameSuggestions,
+		handleConfirm: func(branchName string) error {
+			branchName = sanitizedBranchName(strings.TrimSpace(branchName))
+
+			ref := branchName
+			if ref == "" {
+				ref = "HEAD"
+			}
+
+			return gui.promptForWorktreePath(ref, func(path string) commands.NewWorktreeOptions {
+				return commands.NewWorktreeOptions{
+					Path:   path,
+					Branch: branchName,
+					Detach: branchName == "",
+				}
+			})
+		},
+	})
+}
+
+func (gui *Gui) handleCreateWorktreeFromBranch() error {
+	branch := gui.getSelectedBranch()
+	if branch == nil {
+		return nil
+	}
+
+	return gui.promptForWorktreePath(branch.Name, func(path string) commands.NewWorktreeOptions {
+		return commands.NewWorktreeOptions{
+			Path: path,
+			Base: branch.Name,
+		}
+	})
+}
+
+func (gui *Gui) handleCreateWorktreeFromCommit() error {
+	commit := gui.getSelectedLocalCommit()
+	if commit == nil {
+		return nil
+	}
+
+	return gui.promptForWorktreePath(commit.ShortSha(), func(path string) commands.NewWorktreeOptions {
+		return commands.NewWorktreeOptions{
+			Path:   path,
+			Base:   commit.Sha,
+			Detach: true,
+		}
+	})
+}
+
+// promptForWorktreePath asks for the path of a new worktree, suggesting a
+// sibling directory of the current repo named after the given ref, and then
+// creates the worktree
+func (gui *Gui) promptForWorktreePath(ref string, getOpts func(path string) commands.NewWorktreeOptions) error {
+	initialContent := ""
+	if currentPath, err := os.Getwd(); err == nil {
+		dirName := filepath.Base(currentPath) + "-" + strings.Replace(ref, "/", "-", -1)
+		initialContent = filepath.Join(filepath.Dir(currentPath), dirName)
+	}
+
+	title := utils.ResolvePlaceholderString(
+		gui.Tr.NewWorktreePath,
+		map[string]string{
+			"ref": ref,
+		},
+	)
+
+	return gui.prompt(promptOpts{
+		title:          title,
+		initialContent: initialContent,
+		handleConfirm: func(path string) error {
+			opts := getOpts(strings.TrimSpace(path))
+
+			return gui.WithWaitingStatus(gui.Tr.CreatingWorktreeStatus, func() error {
+				if err := gui.withSpan(gui.Tr.SpanNewWorktree).NewWorktree(opts); err != nil {
+					return err
+				}
+
+				return gui.refreshSidePanels(refreshOptions{scope: []RefreshableView{BRANCHES, WORKTREES}, then: func() {
+					gui.selectWorktreeByPath(opts.Path)
+				}})
+			})
+		},
+	})
+}
+
+func (gui *Gui) selectWorktreeByPath(path string) {
+	absPath, err := filepath.Abs(path)
+	if err != nil {
+		gui.Log.Error(err)
+		return
+	}
+
+	for i, worktree := range gui.State.Worktrees {
+		if filepath.Clean(worktree.Path) == absPath {
+			gui.State.Panels.Worktrees.SelectedLineIdx = i
+			if err := gui.pushContext(gui.State.Contexts.Worktrees); err != nil {
+				gui.Log.Error(err)
+			}
+
+			return
+		}
+	}
+}
+
+func (gui *Gui) handleRemoveWorktree() error {
+	worktree := gui.getSelectedWorktree()
+	if worktree == nil {
+		return nil
+	}
+
+	if worktree.Main {
+		return gui.createErrorPanel(gui.Tr.CantRemoveMainWorktree)
+	}
+
+	if worktree.Current {
+		return gui.createErrorPanel(gui.Tr.CantRemoveCurrentWorktree)
+	}
+
+	return gui.ask(askOpts{
+		title: gui.Tr.RemoveWorktree,
+		prompt: utils.ResolvePlaceholderString(
+			gui.Tr.RemoveWorktreePrompt,
+			map[string]string{
+				"worktreePath": worktree.Path,
+			},
+		),
+		handleConfirm: func() error {
+			return gui.removeWorktree(worktree, false)
+		},
+	})
+}
+
+func (gui *Gui) removeWorktree(worktree *models.Worktree, force bool) error {
+	if err := gui.withSpan(gui.Tr.SpanRemoveWorktree).RemoveWorktree(worktree.Path, force); err != nil {
+		if !force && strings.Contains(err.Error(), "--force") {
+			return gui.ask(askOpts{
+				title: gui.Tr.RemoveWorktree,
+				prompt: utils.ResolvePlaceholderString(
+					gui.Tr.ForceRemoveWorktreePrompt,
+					map[string]string{
+						"worktreePath": worktree.Path,
+					},
+				),
+				handleConfirm: func() error {
+					return gui.removeWorktree(worktree, true)
+				},
+			})
+		}
+		return gui.surfaceError(err)
+	}
+
+	return gui.refreshSidePanels(refreshOptions{mode: ASYNC, scope: []RefreshableView{BRANCHES, WORKTREES}})
+}
+
+func (gui *Gui) handlePruneWorktrees() error {
+	if err := gui.withSpan(gui.Tr.SpanPruneWorktrees).PruneWorktrees(); err != nil {
+		return gui.surfaceError(err)
+	}
+
+	return gui.refreshSidePanels(refreshOptions{mode: ASYNC, scope: []RefreshableView{WORKTREES}})
+}
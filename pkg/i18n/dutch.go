@@ -203,7 +203,6 @@ func dutchTranslationSet() TranslationSet {
 		ConflictsResolved:                   "alle merge conflicten zijn opgelost. Wilt je verder gaan?",
 		RebasingTitle:                       "Rebasen",
 		MergingTitle:                        "Merggen",
-		ConfirmRebase:                       "Weet je zeker dat je {{.checkedOutBranch}} op {{.selectedBranch}} wil rebasen?",
 		ConfirmMerge:                        "Weet je zeker dat je {{.selectedBranch}} in {{.checkedOutBranch}} wil mergen?",
 		FwdNoUpstream:                       "Kan niet de branch vooruitspoelen zonder upstream",
 		FwdCommitsToPush:                    "Je kan niet vooruitspoelen als de branch geen nieuwe commits heeft",
@@ -225,8 +224,6 @@ func dutchTranslationSet() TranslationSet {
 		NextHunk:                            "selecteer de volgende hunk",
 		PrevConflict:                        "selecteer voorgaand conflict",
 		NextConflict:                        "selecteer volgende conflict",
-		SelectTop:                           "selecteer bovenste hunk",
-		SelectBottom:                        "selecteer onderste hunk",
 		ScrollDown:                          "scroll omlaag",
 		ScrollUp:                            "scroll omhoog",
 		LcScrollUpMainPanel:                 "scroll naar beneden vanaf hooft paneel",
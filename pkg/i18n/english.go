@@ -214,7 +214,6 @@ type TranslationSet struct {
 	Title                               string
 	ConflictsResolved                   string
 	RebasingTitle                       string
-	ConfirmRebase                       string
 	ConfirmMerge                        string
 	FwdNoUpstream                       string
 	FwdCommitsToPush                    string
@@ -236,8 +235,6 @@ type TranslationSet struct {
 	NextHunk                            string
 	PrevConflict                        string
 	NextConflict                        string
-	SelectTop                           string
-	SelectBottom                        string
 	ScrollDown                          string
 	ScrollUp                            string
 	LcScrollUpMainPanel                 string
@@ -439,6 +436,251 @@ type TranslationSet struct {
 	ErrCannotEditDirectory              string
 	ErrStageDirWithInlineMergeConflicts string
 	ErrRepositoryMovedOrDeleted         string
+	WorktreesTitle                      string
+	NoWorktrees                         string
+	LcSwitchToWorktree                  string
+	LcCreateWorktree                    string
+	LcCreateWorktreeFromBranch          string
+	LcCreateWorktreeFromCommit          string
+	LcRemoveWorktree                    string
+	LcPruneWorktrees                    string
+	LcCopyWorktreePathToClipboard       string
+	NewWorktreeBranchName               string
+	NewWorktreePath                     string
+	CreatingWorktreeStatus              string
+	RemoveWorktree                      string
+	RemoveWorktreePrompt                string
+	ForceRemoveWorktreePrompt           string
+	CantRemoveMainWorktree              string
+	CantRemoveCurrentWorktree           string
+	AlreadyInWorktree                   string
+	CantSwitchToBareWorktree            string
+	Bisect                              string
+	LcViewBisectOptions                 string
+	BisectMarkStart                     string
+	BisectMark                          string
+	BisectSkip                          string
+	BisectRunScript                     string
+	BisectRunScriptPrompt               string
+	BisectRunTitle                      string
+	BisectingStatus                     string
+	LcResetBisect                       string
+	ResetBisectTitle                    string
+	ResetBisectPrompt                   string
+	LcBisectWaiting                     string
+	LcBisectCandidates                  string
+	LcBisectDone                        string
+	LcLightweightTag                    string
+	LcAnnotatedTag                      string
+	LcSignedTag                         string
+	BlameTitle                          string
+	LcViewBlame                         string
+	LcGoToBlamedCommit                  string
+	LcBlamePreviousVersion              string
+	LcExitBlame                         string
+	BlameNotCommitted                   string
+	BlameWorkingTree                    string
+	BlameLineNotCommitted               string
+	BlameCommitNotFound                 string
+	BlameNoPreviousVersion              string
+	LcSelectLine                        string
+	LcFilterByLines                     string
+	LcFilteringByLines                  string
+	CommandLogTitle                     string
+	LcCommandLogMenu                    string
+	LcShowCommandLog                    string
+	LcHideCommandLog                    string
+	LcExportCommandLog                  string
+	ExportCommandLogPrompt              string
+	CommandLogEmpty                     string
+	CommandLogExported                  string
+	SpanCheckoutFile                    string
+	SpanDiscardOldFileChange            string
+	SpanApplyPatch                      string
+	SpanDiscardAllChanges               string
+	SpanDiscardUnstagedChanges          string
+	SpanStageFile                       string
+	SpanUnstageFile                     string
+	SpanStageAll                        string
+	SpanUnstageAll                      string
+	SpanIgnoreFile                      string
+	SpanCommit                          string
+	SpanAmendCommit                     string
+	SpanEditFile                        string
+	SpanOpenFile                        string
+	SpanSetUpstream                     string
+	SpanPull                            string
+	SpanPush                            string
+	SpanFetch                           string
+	SpanShellCommand                    string
+	SpanStash                           string
+	SpanStashStaged                     string
+	SpanStashDo                         string
+	SpanBisectMark                      string
+	SpanBisectReset                     string
+	SpanUndo                            string
+	SpanCheckout                        string
+	SpanForceCheckout                   string
+	SpanNewBranch                       string
+	SpanDeleteBranch                    string
+	SpanMerge                           string
+	SpanRebaseBranch                    string
+	SpanFastForward                     string
+	SpanRenameBranch                    string
+	SpanCustomCommand                   string
+	SpanCherryPick                      string
+	SpanDeleteRemoteBranch              string
+	SpanSetBranchUpstream               string
+	SpanCreateLightweightTag            string
+	SpanCreateAnnotatedTag              string
+	SpanCreateSignedTag                 string
+	SpanDeleteTag                       string
+	SpanPushTag                         string
+	SpanNukeWorkingTree                 string
+	SpanDiscardAllUnstagedChanges       string
+	SpanRemoveUntrackedFiles            string
+	SpanSoftReset                       string
+	SpanMixedReset                      string
+	SpanHardReset                       string
+	SpanResetToCommit                   string
+	SpanRemoveSubmodule                 string
+	SpanResetSubmodule                  string
+	SpanAddSubmodule                    string
+	SpanUpdateSubmoduleUrl              string
+	SpanInitSubmodule                   string
+	SpanUpdateSubmodule                 string
+	SpanBulkInitSubmodules              string
+	SpanBulkUpdateSubmodules            string
+	SpanBulkResetSubmodules             string
+	SpanBulkDeinitSubmodules            string
+	SpanGitFlowFinish                   string
+	SpanGitFlowStart                    string
+	SpanNewWorktree                     string
+	SpanRemoveWorktree                  string
+	SpanPruneWorktrees                  string
+	SpanAddRemote                       string
+	SpanRemoveRemote                    string
+	SpanEditRemote                      string
+	SpanFetchRemote                     string
+	SpanMergeOrRebase                   string
+	SpanRemovePatchFromCommit           string
+	SpanMovePatchToSelectedCommit       string
+	SpanMovePatchIntoIndex              string
+	SpanMovePatchIntoNewCommit          string
+	SpanSquashDown                      string
+	SpanFixup                           string
+	SpanRewordCommit                    string
+	SpanDropCommit                      string
+	SpanMoveCommitDown                  string
+	SpanMoveCommitUp                    string
+	SpanEditCommit                      string
+	SpanAmendToCommit                   string
+	SpanRevertCommit                    string
+	SpanCreateFixupCommit               string
+	SpanSquashAboveFixupCommits         string
+	SpanBisectRun                       string
+	LcToggleRangeSelect                 string
+	DiscardSelectedTitle                string
+	DiscardSelectedPrompt               string
+	DeleteBranchesMessage               string
+	ForceDeleteBranchesMessage          string
+	DeleteTagsPrompt                    string
+	SureDropStashEntries                string
+	SureSquashTheseCommits              string
+	SureFixupTheseCommits               string
+	DeleteCommitsPrompt                 string
+	LcPlanInteractiveRebase             string
+	LcInsertExecTodo                    string
+	LcInsertBreakTodo                   string
+	ExecTodoPrompt                      string
+	AlreadyPlanningRebase               string
+	CantPlanRebaseMidRebase             string
+	CommitNotInRebasePlan               string
+	CantInsertTodoHere                  string
+	CantSetActionOnTodo                 string
+	LcPlanningRebase                    string
+	LcStartRebasePlan                   string
+	LcDiscardRebasePlan                 string
+	SpanStartRebasePlan                 string
+	LcMarkCommitAsBaseForRebase         string
+	CantMarkTodoAsBaseCommit            string
+	LcMarkedBaseCommit                  string
+	RebaseOntoBranchTitle               string
+	RebaseOntoBranchFromBaseTitle       string
+	LcSimpleRebase                      string
+	LcRebaseKeepingMerges               string
+	NoHostingServiceToken               string
+	PullRequestsTitle                   string
+	PullRequestTitle                    string
+	NoPullRequests                      string
+	LoadingPullRequests                 string
+	LcCheckoutPullRequest               string
+	LcOpenPullRequestInBrowser          string
+	LcRefreshPullRequests               string
+	LcCopyPullRequestURLToClipboard     string
+	FetchingPullRequestStatus           string
+	SpanCheckoutPullRequest             string
+	CreatePullRequestTitle              string
+	PullRequestBaseTitle                string
+	LcPullRequestBase                   string
+	LcPullRequestTitle                  string
+	LcPullRequestDescription            string
+	LcPullRequestDraft                  string
+	LcPullRequestReviewers              string
+	LcPullRequestLabels                 string
+	LcSubmitPullRequest                 string
+	LcYes                               string
+	LcNo                                string
+	PullRequestTitlePrompt              string
+	PullRequestReviewersPrompt          string
+	PullRequestLabelsPrompt             string
+	PullRequestTitleRequired            string
+	NoPullRequestBaseBranches           string
+	CreatingPullRequestStatus           string
+	PullRequestCreated                  string
+	LcOpenMergeTool                     string
+	SpanOpenMergeTool                   string
+	MergeToolLeftConflicts              string
+	LcViewMergeConflictOptions          string
+	MergeConflictOptionsTitle           string
+	LcUseOursFor                        string
+	LcUseTheirsFor                      string
+	LcUseOursForAll                     string
+	LcUseTheirsForAll                   string
+	LcDeletesFile                       string
+	NoMergeConflicts                    string
+	SpanResolveConflicts                string
+	LcStashAllChangesIncludingUntracked string
+	LcStashAllChangesKeepIndex          string
+	LcStashPath                         string
+	LcStashSelectedPaths                string
+	LcStashSelection                    string
+	NoStagedFilesToStash                string
+	SpanStashIncludingUntracked         string
+	SpanStashKeepIndex                  string
+	SpanStashPaths                      string
+	SpanStashSelection                  string
+	LcRenameStash                       string
+	RenameStashPrompt                   string
+	SpanRenameStash                     string
+	ConfigReloadError                   string
+	ConfigReloaded                      string
+	InvalidConfig                       string
+	LcOpenKeybindingsHelp               string
+	KeybindingsHelpTitle                string
+	LcStartFilter                       string
+	FilterStatus                        string
+	LcClearFilter                       string
+	LcFilterMenu                        string
+	NoMenuOptionsFromCommand            string
+	CustomCommandNoOutput               string
+	SpanSetTodoAction                   string
+	SpanInsertExecTodo                  string
+	SpanInsertBreakTodo                 string
+	LcStopBisectScript                  string
+	CantMoveCommitsWhileFiltering       string
+	SpanApplyPatchFromCommit            string
+	SpanApplyPatchFromCommitInReverse   string
 }
 
 const englishReleaseNotes = `lazygit 0.27 Release Notes
@@ -757,7 +999,7 @@ func englishTranslationSet() TranslationSet {
 		LcSelectHunk:                        "select hunk",
 		LcNavigateConflicts:                 "navigate conflicts",
 		LcPickHunk:                          "pick hunk",
-		LcPickBothHunks:                     "pick both hunks",
+		LcPickBothHunks:                     "pick all hunks",
 		LcUndo:                              "undo",
 		LcUndoReflog:                        "undo (via reflog) (experimental)",
 		LcRedoReflog:                        "redo (via reflog) (experimental)",
@@ -849,7 +1091,6 @@ func englishTranslationSet() TranslationSet {
 		GlobalTitle:                         "Global Keybindings",
 		ConflictsResolved:                   "all merge conflicts resolved. Continue?",
 		RebasingTitle:                       "Rebasing",
-		ConfirmRebase:                       "Are you sure you want to rebase {{.checkedOutBranch}} onto {{.selectedBranch}}?",
 		ConfirmMerge:                        "Are you sure you want to merge {{.selectedBranch}} into {{.checkedOutBranch}}?",
 		FwdNoUpstream:                       "Cannot fast-forward a branch with no upstream",
 		FwdCommitsToPush:                    "Cannot fast-forward a branch with commits to push",
@@ -871,8 +1112,6 @@ func englishTranslationSet() TranslationSet {
 		NextHunk:                            "select next hunk",
 		PrevConflict:                        "select previous conflict",
 		NextConflict:                        "select next conflict",
-		SelectTop:                           "select top hunk",
-		SelectBottom:                        "select bottom hunk",
 		ScrollDown:                          "scroll down",
 		ScrollUp:                            "scroll up",
 		LcScrollUpMainPanel:                 "scroll up main panel",
@@ -1074,5 +1313,250 @@ func englishTranslationSet() TranslationSet {
 		ErrCannotEditDirectory:              "Cannot edit directory: you can only edit individual files",
 		ErrStageDirWithInlineMergeConflicts: "Cannot stage/unstage directory containing files with inline merge conflicts. Please fix up the merge conflicts first",
 		ErrRepositoryMovedOrDeleted:         "Cannot find repo. It might have been moved or deleted ¯\\_(ツ)_/¯",
+		WorktreesTitle:                      "Worktrees Tab",
+		NoWorktrees:                         "No worktrees",
+		LcSwitchToWorktree:                  "switch to worktree",
+		LcCreateWorktree:                    "create worktree",
+		LcCreateWorktreeFromBranch:          "create worktree from branch",
+		LcCreateWorktreeFromCommit:          "create worktree from commit",
+		LcRemoveWorktree:                    "remove worktree",
+		LcPruneWorktrees:                    "prune worktrees",
+		LcCopyWorktreePathToClipboard:       "copy worktree path to clipboard",
+		NewWorktreeBranchName:               "New branch name for worktree (leave blank to detach HEAD):",
+		NewWorktreePath:                     "Path for new worktree of '{{.ref}}':",
+		CreatingWorktreeStatus:              "creating worktree",
+		RemoveWorktree:                      "Remove worktree",
+		RemoveWorktreePrompt:                "Are you sure you want to remove worktree '{{.worktreePath}}'?",
+		ForceRemoveWorktreePrompt:           "Worktree '{{.worktreePath}}' contains modified or untracked files. Are you sure you want to remove it? This is irreversible.",
+		CantRemoveMainWorktree:              "You cannot remove the main worktree",
+		CantRemoveCurrentWorktree:           "You cannot remove the worktree you are currently in",
+		AlreadyInWorktree:                   "You are already in this worktree",
+		CantSwitchToBareWorktree:            "Cannot switch to a bare repository",
+		Bisect:                              "Bisect",
+		LcViewBisectOptions:                 "view bisect options",
+		BisectMarkStart:                     "mark {{.sha}} as {{.term}} (start bisect)",
+		BisectMark:                          "mark {{.sha}} as {{.term}}",
+		BisectSkip:                          "skip {{.sha}}",
+		BisectRunScript:                     "run a script to find the first {{.term}} commit",
+		BisectRunScriptPrompt:               "Script to run (exit 0 if {{.oldTerm}}, 125 to skip, otherwise {{.newTerm}})",
+		BisectRunTitle:                      "Bisect run",
+		BisectingStatus:                     "bisecting",
+		LcResetBisect:                       "reset bisect",
+		ResetBisectTitle:                    "Reset bisect",
+		ResetBisectPrompt:                   "Are you sure you want to stop bisecting? This will take you back to {{.ref}}.",
+		LcBisectWaiting:                     "bisecting: waiting for {{.newTerm}} and {{.oldTerm}} commits",
+		LcBisectCandidates:                  "bisecting: {{.candidates}} candidates left (roughly {{.steps}} steps)",
+		LcBisectDone:                        "bisect found first {{.term}} commit {{.sha}}",
+		LcLightweightTag:                    "lightweight tag",
+		LcAnnotatedTag:                      "annotated tag",
+		LcSignedTag:                         "signed tag",
+		BlameTitle:                          "Blame",
+		LcViewBlame:                         "view blame",
+		LcGoToBlamedCommit:                  "go to commit in commits panel",
+		LcBlamePreviousVersion:              "blame line's previous version",
+		LcExitBlame:                         "return to previous blame, or exit blame",
+		BlameNotCommitted:                   "Not committed yet",
+		BlameWorkingTree:                    "working tree",
+		BlameLineNotCommitted:               "This line hasn't been committed yet",
+		BlameCommitNotFound:                 "Could not find this commit in the commits panel",
+		BlameNoPreviousVersion:              "There is no earlier version of this line",
+		LcSelectLine:                        "select line",
+		LcFilterByLines:                     "filter by lines {{.start}}-{{.end}} of '{{.path}}'",
+		LcFilteringByLines:                  "filtering by lines {{.start}}-{{.end}} of '{{.path}}'",
+		CommandLogTitle:                     "Command log",
+		LcCommandLogMenu:                    "show/hide or export the command log",
+		LcShowCommandLog:                    "show command log",
+		LcHideCommandLog:                    "hide command log",
+		LcExportCommandLog:                  "export command log as a shell script",
+		ExportCommandLogPrompt:              "Export command log to:",
+		CommandLogEmpty:                     "No commands have been run yet",
+		CommandLogExported:                  "Exported command log to {{.path}}",
+		SpanCheckoutFile:                    "Commit files: checkout file",
+		SpanDiscardOldFileChange:            "Rebase: discard file change from commit",
+		SpanApplyPatch:                      "Staging: apply patch",
+		SpanDiscardAllChanges:               "Files: discard all changes",
+		SpanDiscardUnstagedChanges:          "Files: discard unstaged changes",
+		SpanStageFile:                       "Files: stage",
+		SpanUnstageFile:                     "Files: unstage",
+		SpanStageAll:                        "Files: stage all",
+		SpanUnstageAll:                      "Files: unstage all",
+		SpanIgnoreFile:                      "Files: ignore",
+		SpanCommit:                          "Commit: commit",
+		SpanAmendCommit:                     "Commit: amend last commit",
+		SpanEditFile:                        "Files: edit",
+		SpanOpenFile:                        "Files: open",
+		SpanSetUpstream:                     "Branches: set upstream",
+		SpanPull:                            "Sync: pull",
+		SpanPush:                            "Sync: push",
+		SpanFetch:                           "Sync: fetch",
+		SpanShellCommand:                    "Shell command",
+		SpanStash:                           "Stash: stash changes",
+		SpanStashStaged:                     "Stash: stash staged changes",
+		SpanStashDo:                         "Stash: {{.method}}",
+		SpanBisectMark:                      "Bisect: mark commit",
+		SpanBisectReset:                     "Bisect: reset",
+		SpanUndo:                            "Undo: hard reset",
+		SpanCheckout:                        "Branches: checkout",
+		SpanForceCheckout:                   "Branches: force checkout",
+		SpanNewBranch:                       "Branches: new branch",
+		SpanDeleteBranch:                    "Branches: delete",
+		SpanMerge:                           "Branches: merge",
+		SpanRebaseBranch:                    "Rebase: rebase onto branch",
+		SpanFastForward:                     "Branches: fast-forward",
+		SpanRenameBranch:                    "Branches: rename",
+		SpanCustomCommand:                   "Custom command",
+		SpanCherryPick:                      "Commits: cherry-pick",
+		SpanDeleteRemoteBranch:              "Remote branches: delete",
+		SpanSetBranchUpstream:               "Remote branches: set as upstream",
+		SpanCreateLightweightTag:            "Tags: create lightweight tag",
+		SpanCreateAnnotatedTag:              "Tags: create annotated tag",
+		SpanCreateSignedTag:                 "Tags: create signed tag",
+		SpanDeleteTag:                       "Tags: delete",
+		SpanPushTag:                         "Tags: push",
+		SpanNukeWorkingTree:                 "Reset: nuke working tree",
+		SpanDiscardAllUnstagedChanges:       "Reset: discard unstaged changes",
+		SpanRemoveUntrackedFiles:            "Reset: discard untracked files",
+		SpanSoftReset:                       "Reset: soft reset",
+		SpanMixedReset:                      "Reset: mixed reset",
+		SpanHardReset:                       "Reset: hard reset",
+		SpanResetToCommit:                   "Reset: reset to commit",
+		SpanRemoveSubmodule:                 "Submodules: remove",
+		SpanResetSubmodule:                  "Submodules: stash and reset",
+		SpanAddSubmodule:                    "Submodules: add",
+		SpanUpdateSubmoduleUrl:              "Submodules: update URL",
+		SpanInitSubmodule:                   "Submodules: init",
+		SpanUpdateSubmodule:                 "Submodules: update",
+		SpanBulkInitSubmodules:              "Submodules: bulk init",
+		SpanBulkUpdateSubmodules:            "Submodules: bulk update",
+		SpanBulkResetSubmodules:             "Submodules: bulk stash and reset",
+		SpanBulkDeinitSubmodules:            "Submodules: bulk deinit",
+		SpanGitFlowFinish:                   "Git flow: finish branch",
+		SpanGitFlowStart:                    "Git flow: start branch",
+		SpanNewWorktree:                     "Worktrees: new worktree",
+		SpanRemoveWorktree:                  "Worktrees: remove",
+		SpanPruneWorktrees:                  "Worktrees: prune",
+		SpanAddRemote:                       "Remotes: add",
+		SpanRemoveRemote:                    "Remotes: remove",
+		SpanEditRemote:                      "Remotes: edit",
+		SpanFetchRemote:                     "Remotes: fetch",
+		SpanMergeOrRebase:                   "{{.commandType}}: {{.command}}",
+		SpanRemovePatchFromCommit:           "Patch: remove from commit",
+		SpanMovePatchToSelectedCommit:       "Patch: move to selected commit",
+		SpanMovePatchIntoIndex:              "Patch: move into index",
+		SpanMovePatchIntoNewCommit:          "Patch: move into new commit",
+		SpanSquashDown:                      "Rebase: squash down",
+		SpanFixup:                           "Rebase: fixup",
+		SpanRewordCommit:                    "Rebase: reword commit",
+		SpanDropCommit:                      "Rebase: drop commit",
+		SpanMoveCommitDown:                  "Rebase: move commit down",
+		SpanMoveCommitUp:                    "Rebase: move commit up",
+		SpanEditCommit:                      "Rebase: edit commit",
+		SpanAmendToCommit:                   "Rebase: amend commit with staged changes",
+		SpanRevertCommit:                    "Commits: revert",
+		SpanCreateFixupCommit:               "Commits: create fixup commit",
+		SpanSquashAboveFixupCommits:         "Rebase: squash fixup commits",
+		SpanBisectRun:                       "Bisect: run script",
+		LcToggleRangeSelect:                 "toggle range select",
+		DiscardSelectedTitle:                "Discard changes",
+		DiscardSelectedPrompt:               "Are you sure you want to discard all changes to the {{.count}} selected entries?",
+		DeleteBranchesMessage:               "Are you sure you want to delete the branches {{.branchNames}}?",
+		ForceDeleteBranchesMessage:          "{{.branchNames}} are not fully merged. Are you sure you want to delete them?",
+		DeleteTagsPrompt:                    "Are you sure you want to delete the tags {{.tagNames}}?",
+		SureDropStashEntries:                "Are you sure you want to drop these {{.count}} stash entries?",
+		SureSquashTheseCommits:              "Are you sure you want to squash these {{.count}} commits into the commit below?",
+		SureFixupTheseCommits:               "Are you sure you want to 'fixup' these {{.count}} commits? They will be merged into the commit below",
+		DeleteCommitsPrompt:                 "Are you sure you want to delete these {{.count}} commits?",
+		LcPlanInteractiveRebase:             "plan interactive rebase down to this commit",
+		LcInsertExecTodo:                    "add exec line after this commit (while planning or in an interactive rebase)",
+		LcInsertBreakTodo:                   "add break line after this commit (while planning or in an interactive rebase)",
+		ExecTodoPrompt:                      "Command to run after this commit:",
+		AlreadyPlanningRebase:               "You're already planning an interactive rebase. Start or discard it from the rebase options menu",
+		CantPlanRebaseMidRebase:             "You can't plan an interactive rebase while merging or rebasing",
+		CommitNotInRebasePlan:               "This commit isn't part of the rebase you're planning",
+		CantInsertTodoHere:                  "You can only add exec and break lines among the commits of an interactive rebase",
+		CantSetActionOnTodo:                 "Cannot {{.action}} a '{{.todoAction}}' line",
+		LcPlanningRebase:                    "planning interactive rebase of {{.count}} commits, press {{.key}} to start",
+		LcStartRebasePlan:                   "start planned rebase",
+		LcDiscardRebasePlan:                 "discard planned rebase",
+		SpanStartRebasePlan:                 "Rebase: start planned rebase",
+		LcMarkCommitAsBaseForRebase:         "mark as base commit for rebase (only rebase the commits after it)",
+		CantMarkTodoAsBaseCommit:            "You can't mark a commit that's still to be rebased as the base commit",
+		LcMarkedBaseCommit:                  "rebasing commits after {{.sha}}: select a branch and press {{.key}}",
+		RebaseOntoBranchTitle:               "Rebase '{{.checkedOutBranch}}' onto '{{.selectedBranch}}'",
+		RebaseOntoBranchFromBaseTitle:       "Rebase commits of '{{.checkedOutBranch}}' after {{.baseSha}} onto '{{.selectedBranch}}'",
+		LcSimpleRebase:                      "rebase",
+		LcRebaseKeepingMerges:               "rebase, keeping merge commits",
+		NoHostingServiceToken:               "No API token for {{.domain}}. Set one under hostingServices in your config or in ${{.envVar}}",
+		PullRequestsTitle:                   "Pull Requests Tab",
+		PullRequestTitle:                    "Pull Request",
+		NoPullRequests:                      "No open pull requests",
+		LoadingPullRequests:                 "Loading pull requests...",
+		LcCheckoutPullRequest:               "checkout pull request",
+		LcOpenPullRequestInBrowser:          "open pull request in browser",
+		LcRefreshPullRequests:               "refresh pull requests",
+		LcCopyPullRequestURLToClipboard:     "copy pull request URL to clipboard",
+		FetchingPullRequestStatus:           "Fetching pull request",
+		SpanCheckoutPullRequest:             "Check out pull request",
+		CreatePullRequestTitle:              "Create pull request",
+		PullRequestBaseTitle:                "Base branch",
+		LcPullRequestBase:                   "base",
+		LcPullRequestTitle:                  "title",
+		LcPullRequestDescription:            "description",
+		LcPullRequestDraft:                  "draft",
+		LcPullRequestReviewers:              "reviewers",
+		LcPullRequestLabels:                 "labels",
+		LcSubmitPullRequest:                 "create",
+		LcYes:                               "yes",
+		LcNo:                                "no",
+		PullRequestTitlePrompt:              "Title:",
+		PullRequestReviewersPrompt:          "Reviewers (comma-separated):",
+		PullRequestLabelsPrompt:             "Labels (comma-separated):",
+		PullRequestTitleRequired:            "A pull request needs a title",
+		NoPullRequestBaseBranches:           "There are no branches on origin to open a pull request against",
+		CreatingPullRequestStatus:           "Creating pull request",
+		PullRequestCreated:                  "Created pull request #{{.number}} and copied its URL to the clipboard",
+		LcOpenMergeTool:                     "open external merge tool (git mergetool)",
+		SpanOpenMergeTool:                   "Open merge tool",
+		MergeToolLeftConflicts:              "The merge tool left conflict markers in the file",
+		LcViewMergeConflictOptions:          "view merge conflict options: take ours or theirs for whole files",
+		MergeConflictOptionsTitle:           "Merge conflicts",
+		LcUseOursFor:                        "use ours for {{.path}}",
+		LcUseTheirsFor:                      "use theirs for {{.path}}",
+		LcUseOursForAll:                     "use ours for all conflicted files",
+		LcUseTheirsForAll:                   "use theirs for all conflicted files",
+		LcDeletesFile:                       "(deletes the file)",
+		NoMergeConflicts:                    "There are no merge conflicts",
+		SpanResolveConflicts:                "Resolve conflicts",
+		LcStashAllChangesIncludingUntracked: "stash all changes including untracked files",
+		LcStashAllChangesKeepIndex:          "stash all changes and keep staged changes",
+		LcStashPath:                         "stash changes to {{.path}}",
+		LcStashSelectedPaths:                "stash changes to the selected files",
+		LcStashSelection:                    "stash selected lines",
+		NoStagedFilesToStash:                "You have no staged files to stash",
+		SpanStashIncludingUntracked:         "Stash: stash changes including untracked files",
+		SpanStashKeepIndex:                  "Stash: stash changes and keep staged changes",
+		SpanStashPaths:                      "Stash: stash selected files",
+		SpanStashSelection:                  "Stash: stash selected lines",
+		LcRenameStash:                       "rename stash",
+		RenameStashPrompt:                   "Rename stash: {{.stashName}}",
+		SpanRenameStash:                     "Rename stash",
+		ConfigReloadError:                   "Couldn't load the config, so the previous config is still in use:\n\n{{.error}}",
+		ConfigReloaded:                      "Config reloaded",
+		InvalidConfig:                       "There are problems with your config:",
+		LcOpenKeybindingsHelp:               "search keybindings",
+		KeybindingsHelpTitle:                "Search keybindings",
+		LcStartFilter:                       "filter list",
+		FilterStatus:                        "showing items matching '{{.filter}}'",
+		LcClearFilter:                       "clear filter",
+		LcFilterMenu:                        "filter menu",
+		NoMenuOptionsFromCommand:            "'{{.command}}' didn't output anything to choose from",
+		CustomCommandNoOutput:               "The command didn't output anything",
+		SpanSetTodoAction:                   "Rebase: set todo action",
+		SpanInsertExecTodo:                  "Rebase: insert exec",
+		SpanInsertBreakTodo:                 "Rebase: insert break",
+		LcStopBisectScript:                  "stop bisect script",
+		CantMoveCommitsWhileFiltering:       "Clear the filter before moving commits",
+		SpanApplyPatchFromCommit:            "Patch: apply",
+		SpanApplyPatchFromCommitInReverse:   "Patch: apply in reverse",
 	}
 }
@@ -20,10 +20,16 @@ func NewTranslationSet(log *logrus.Entry) *TranslationSet {
 
 	log.Info("language: " + userLang)
 
+	return NewTranslationSetForLanguage(userLang)
+}
+
+// NewTranslationSetForLanguage creates a translation set for the given language
+// e.g. "nl" or "nl_NL", falling back to english for anything not translated
+func NewTranslationSetForLanguage(language string) *TranslationSet {
 	baseSet := englishTranslationSet()
 
 	for languageCode, translationSet := range GetTranslationSets() {
-		if strings.HasPrefix(userLang, languageCode) {
+		if strings.HasPrefix(language, languageCode) {
 			_ = mergo.Merge(&baseSet, translationSet, mergo.WithOverride)
 		}
 	}
@@ -141,7 +141,6 @@ func polishTranslationSet() TranslationSet {
 		CantFindHunk:                        `Nie można znaleźć kawałka`,
 		RebasingTitle:                       "Rebasing",
 		MergingTitle:                        "Merging",
-		ConfirmRebase:                       "Are you sure you want to rebase {{.checkedOutBranch}} onto {{.selectedBranch}}?",
 		ConfirmMerge:                        "Are you sure you want to merge {{.selectedBranch}} into {{.checkedOutBranch}}?",
 		FwdNoUpstream:                       "Cannot fast-forward a branch with no upstream",
 		FwdCommitsToPush:                    "Cannot fast-forward a branch with commits to push",
@@ -186,8 +185,6 @@ func polishTranslationSet() TranslationSet {
 		NextHunk:                            "select next hunk",
 		PrevConflict:                        "select previous conflict",
 		NextConflict:                        "select next conflict",
-		SelectTop:                           "select top hunk",
-		SelectBottom:                        "select bottom hunk",
 		ScrollDown:                          "scroll down",
 		ScrollUp:                            "scroll up",
 		AmendCommitTitle:                    "Amend Commit",
@@ -14,6 +14,9 @@ import (
 	"github.com/sirupsen/logrus"
 )
 
+// how often we refresh the view while reading output from a command
+const refreshInterval = time.Millisecond * 100
+
 type Task struct {
 	stop          chan struct{}
 	stopped       bool
@@ -92,6 +95,8 @@ func (m *ViewBufferManager) NewCmdTask(r io.Reader, cmd *exec.Cmd, prefix string
 				}
 			})
 
+			lastRefresh := time.Now()
+
 		outer:
 			for {
 				select {
@@ -122,6 +127,13 @@ func (m *ViewBufferManager) NewCmdTask(r io.Reader, cmd *exec.Cmd, prefix string
 							break outer
 						}
 						_, _ = m.writer.Write(append(scanner.Bytes(), []byte("\n")...))
+
+						// when reading a lot of lines from a slow command we want to show
+						// progress as we go rather than waiting until we're done
+						if time.Since(lastRefresh) > refreshInterval {
+							m.refreshView()
+							lastRefresh = time.Now()
+						}
 					}
 					m.refreshView()
 				case <-stop:
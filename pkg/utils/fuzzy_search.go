@@ -7,16 +7,30 @@ import (
 )
 
 func FuzzySearch(needle string, haystack []string) []string {
+	indexes := FuzzySearchIndexes(needle, haystack)
+
+	result := make([]string, len(indexes))
+	for i, index := range indexes {
+		result[i] = haystack[index]
+	}
+
+	return result
+}
+
+// FuzzySearchIndexes is like FuzzySearch but returns the indexes of the matches
+// in the haystack, for when we're searching some representation of the things
+// we're interested in
+func FuzzySearchIndexes(needle string, haystack []string) []int {
 	if needle == "" {
-		return []string{}
+		return []int{}
 	}
 
 	matches := fuzzy.Find(needle, haystack)
 	sort.Sort(matches)
 
-	result := make([]string, len(matches))
+	result := make([]int, len(matches))
 	for i, match := range matches {
-		result[i] = match.Str
+		result[i] = match.Index
 	}
 
 	return result
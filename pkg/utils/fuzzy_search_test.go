@@ -51,3 +51,34 @@ func TestFuzzySearch(t *testing.T) {
 		assert.EqualValues(t, s.expected, FuzzySearch(s.needle, s.haystack))
 	}
 }
+
+// TestFuzzySearchIndexes is a function.
+func TestFuzzySearchIndexes(t *testing.T) {
+	type scenario struct {
+		needle   string
+		haystack []string
+		expected []int
+	}
+
+	scenarios := []scenario{
+		{
+			needle:   "",
+			haystack: []string{"test"},
+			expected: []int{},
+		},
+		{
+			needle:   "mybranch",
+			haystack: []string{"my_branch", "mybranch", "branch", "this is my branch"},
+			expected: []int{1, 0, 3},
+		},
+		{
+			needle:   "test",
+			haystack: []string{"test", "other", "test"},
+			expected: []int{0, 2},
+		},
+	}
+
+	for _, s := range scenarios {
+		assert.EqualValues(t, s.expected, FuzzySearchIndexes(s.needle, s.haystack))
+	}
+}